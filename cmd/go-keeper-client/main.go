@@ -24,7 +24,9 @@ func main() {
 		logger.Fatal().Err(err).Msg("unable to initialize client")
 	}
 
-	if err = clt.Run(); err != nil {
-		logger.Fatal().Err(err).Msg("unable to launch client")
+	err = clt.Run()
+	clt.Close()
+	if err != nil {
+		logger.Fatal().Err(err).Msg(gokeeperclt.Describe(err))
 	}
 }
@@ -1,18 +1,91 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/rs/zerolog/log"
 
 	"github.com/serjyuriev/yandex-diploma-2/internal/app/gokeepersrv"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
 )
 
 func main() {
-	srv, err := gokeepersrv.NewServer()
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "healthcheck":
+			healthcheck(os.Args[2:])
+			return
+		case "config":
+			checkConfig(os.Args[2:])
+			return
+		}
+	}
+
+	cfg, err := config.LoadServerConfig(flag.CommandLine, os.Args[1:], os.LookupEnv)
+	if err != nil {
+		log.Fatal().Err(err).Msg("unable to load configuration")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv, err := gokeepersrv.NewServer(cfg)
 	if err != nil {
 		log.Fatal().Err(err).Msg("unable to initialize new server")
 	}
 
-	if err = srv.Start(); err != nil {
+	if err = srv.Run(ctx); err != nil {
 		log.Fatal().Err(err).Msg("unable to start server")
 	}
 }
+
+// healthcheck checks health of the server running with the same
+// configuration, exiting with non-zero code if it isn't serving.
+// By default readiness is checked, -liveness checks that server is up.
+func healthcheck(args []string) {
+	fs := flag.NewFlagSet("healthcheck", flag.ExitOnError)
+	liveness := fs.Bool("liveness", false, "check liveness instead of readiness")
+	cfg, err := config.LoadServerConfig(fs, args, os.LookupEnv)
+	if err != nil {
+		log.Fatal().Err(err).Msg("unable to load configuration")
+	}
+
+	service := gokeepersrv.ReadinessService
+	if *liveness {
+		service = gokeepersrv.LivenessService
+	}
+	if err := gokeepersrv.CheckHealth(cfg, service); err != nil {
+		log.Fatal().Err(err).Msg("server is unhealthy")
+	}
+}
+
+// checkConfig handles "config check" command, which loads configuration
+// the same way server does and reports every problem found in it,
+// exiting with non-zero code if there are any.
+func checkConfig(args []string) {
+	if len(args) == 0 || args[0] != "check" {
+		fmt.Fprintln(os.Stderr, "usage: go-keeper-server config check [-c file] [flags]")
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("config check", flag.ExitOnError)
+	if _, err := config.LoadServerConfig(fs, args[1:], os.LookupEnv); err != nil {
+		var invalid *config.ValidationError
+		if !errors.As(err, &invalid) {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(os.Stderr, "configuration is invalid:")
+		for _, problem := range invalid.Problems {
+			fmt.Fprintf(os.Stderr, "  - %s\n", problem)
+		}
+		os.Exit(1)
+	}
+	fmt.Println("configuration is valid")
+}
@@ -0,0 +1,253 @@
+// Package auth provides issuing and validation of access and refresh tokens
+// for gokeeper server app.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+)
+
+const (
+	// defaultAccessTokenTTL is used when access token lifetime is not configured.
+	defaultAccessTokenTTL = 15 * time.Minute
+	// issuer is put in every token issued by gokeeper server.
+	issuer = "gokeeper"
+	// challengeAudience tells login challenges from access tokens.
+	challengeAudience = "login-challenge"
+	// challengeTTL is how long user has to enter one-time code after password.
+	challengeTTL = 5 * time.Minute
+)
+
+var (
+	// ErrEmptySecret is raised when token signing secret is not configured.
+	ErrEmptySecret = errors.New("token signing secret can't be empty")
+	// ErrInvalidToken is raised when provided access token is malformed,
+	// has wrong signature or already expired.
+	ErrInvalidToken = errors.New("access token is invalid or expired")
+	// ErrInvalidRefreshToken is raised when provided refresh token is malformed.
+	ErrInvalidRefreshToken = errors.New("refresh token is invalid")
+	// ErrInvalidChallenge is raised when provided login challenge
+	// is malformed, has wrong signature or already expired.
+	ErrInvalidChallenge = errors.New("login challenge is invalid or expired")
+)
+
+// Claims holds information access token is issued for.
+type Claims struct {
+	UserID    uuid.UUID
+	SessionID uuid.UUID
+}
+
+// Manager provides access tokens methods.
+type Manager interface {
+	IssueAccessToken(claims Claims) (string, time.Time, error)
+	ParseAccessToken(token string) (Claims, error)
+	IssueChallenge(userID uuid.UUID) (string, error)
+	ParseChallenge(challenge string) (uuid.UUID, error)
+}
+
+// manager holds objects for access tokens implementation.
+type manager struct {
+	secret []byte
+	ttl    time.Duration
+	logger zerolog.Logger
+}
+
+// NewManager initializes app's access tokens manager.
+func NewManager(cfg config.ServerConfig, logger zerolog.Logger) (Manager, error) {
+	if cfg.Auth.Secret == "" {
+		logger.Err(ErrEmptySecret).Str("arg", "secret").Msg("token signing secret can't be empty")
+		return nil, ErrEmptySecret
+	}
+
+	ttl := cfg.Auth.AccessTokenTTL
+	if ttl <= 0 {
+		ttl = defaultAccessTokenTTL
+	}
+
+	logger.Info().Msg("access tokens manager was successfully initialized")
+	return &manager{
+		secret: []byte(cfg.Auth.Secret),
+		ttl:    ttl,
+		logger: logger,
+	}, nil
+}
+
+// IssueAccessToken returns signed access token for provided user's session
+// and the moment token expires at.
+func (m *manager) IssueAccessToken(claims Claims) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
+	registered := jwt.RegisteredClaims{
+		ID:        claims.SessionID.String(),
+		Issuer:    issuer,
+		Subject:   claims.UserID.String(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+
+	m.logger.Debug().Str("user", registered.Subject).Msg("signing new access token")
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, registered).SignedString(m.secret)
+	if err != nil {
+		m.logger.
+			Err(err).
+			Caller().
+			Str("user", registered.Subject).
+			Msg("unable to sign access token")
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// ParseAccessToken validates provided access token,
+// returning user and session token was issued for.
+func (m *manager) ParseAccessToken(token string) (Claims, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodHS256 {
+			return nil, ErrInvalidToken
+		}
+		return m.secret, nil
+	})
+	if err != nil {
+		m.logger.Debug().Err(err).Msg("access token validation failed")
+		return Claims{}, ErrInvalidToken
+	}
+	if !claims.VerifyIssuer(issuer, true) {
+		m.logger.Debug().Str("issuer", claims.Issuer).Msg("access token has unexpected issuer")
+		return Claims{}, ErrInvalidToken
+	}
+	if len(claims.Audience) > 0 {
+		m.logger.Debug().Strs("audience", claims.Audience).Msg("token isn't an access token")
+		return Claims{}, ErrInvalidToken
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		m.logger.Debug().Err(err).Msg("access token has malformed subject")
+		return Claims{}, ErrInvalidToken
+	}
+	sessionID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		m.logger.Debug().Err(err).Msg("access token has malformed session id")
+		return Claims{}, ErrInvalidToken
+	}
+	return Claims{UserID: userID, SessionID: sessionID}, nil
+}
+
+// IssueChallenge returns signed login challenge of the user, who has
+// entered correct password, but is yet to enter one-time code.
+// Challenge can't be used as access token.
+func (m *manager) IssueChallenge(userID uuid.UUID) (string, error) {
+	now := time.Now()
+	registered := jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		Issuer:    issuer,
+		Subject:   userID.String(),
+		Audience:  jwt.ClaimStrings{challengeAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(challengeTTL)),
+	}
+
+	m.logger.Debug().Str("user", registered.Subject).Msg("signing new login challenge")
+	challenge, err := jwt.NewWithClaims(jwt.SigningMethodHS256, registered).SignedString(m.secret)
+	if err != nil {
+		m.logger.
+			Err(err).
+			Caller().
+			Str("user", registered.Subject).
+			Msg("unable to sign login challenge")
+		return "", err
+	}
+	return challenge, nil
+}
+
+// ParseChallenge validates provided login challenge,
+// returning user it was issued for.
+func (m *manager) ParseChallenge(challenge string) (uuid.UUID, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(challenge, claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodHS256 {
+			return nil, ErrInvalidChallenge
+		}
+		return m.secret, nil
+	})
+	if err != nil {
+		m.logger.Debug().Err(err).Msg("login challenge validation failed")
+		return uuid.Nil, ErrInvalidChallenge
+	}
+	if !claims.VerifyIssuer(issuer, true) || !claims.VerifyAudience(challengeAudience, true) {
+		m.logger.Debug().Str("issuer", claims.Issuer).Msg("login challenge has unexpected issuer or audience")
+		return uuid.Nil, ErrInvalidChallenge
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		m.logger.Debug().Err(err).Msg("login challenge has malformed subject")
+		return uuid.Nil, ErrInvalidChallenge
+	}
+	return userID, nil
+}
+
+// NewRefreshToken generates new random refresh token for provided session,
+// returning the token itself and its hash to be stored on server.
+func NewRefreshToken(sessionID uuid.UUID) (string, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(secret)
+	return fmt.Sprintf("%s.%s", sessionID, encoded), hashRefreshToken(encoded), nil
+}
+
+// ParseRefreshToken splits provided refresh token,
+// returning session it belongs to and hash of its secret part.
+func ParseRefreshToken(token string) (uuid.UUID, string, error) {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return uuid.Nil, "", ErrInvalidRefreshToken
+	}
+	sessionID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, "", ErrInvalidRefreshToken
+	}
+	return sessionID, hashRefreshToken(parts[1]), nil
+}
+
+// hashRefreshToken returns hex encoded sha256 hash of refresh token's secret part.
+func hashRefreshToken(secret string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(secret)))
+}
+
+// claimsKey is the context key authenticated user's claims are stored with.
+type claimsKey struct{}
+
+// ContextWithClaims returns a copy of provided context
+// holding authenticated user's claims.
+func ContextWithClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns authenticated user's claims
+// previously put in the context with ContextWithClaims.
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(Claims)
+	return claims, ok
+}
+
+// UserIDFromContext returns authenticated user's uuid
+// previously put in the context with ContextWithClaims.
+func UserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	claims, ok := ClaimsFromContext(ctx)
+	return claims.UserID, ok
+}
@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueAccessToken(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	t.Run("success", func(t *testing.T) {
+		m := &manager{
+			secret: []byte("testsecret"),
+			ttl:    time.Minute,
+			logger: logger,
+		}
+		claims := Claims{UserID: uuid.New(), SessionID: uuid.New()}
+
+		token, expiresAt, err := m.IssueAccessToken(claims)
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+		require.WithinDuration(t, time.Now().Add(time.Minute), expiresAt, time.Second)
+	})
+}
+
+func TestParseAccessToken(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	m := &manager{
+		secret: []byte("testsecret"),
+		ttl:    time.Minute,
+		logger: logger,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		claims := Claims{UserID: uuid.New(), SessionID: uuid.New()}
+		token, _, err := m.IssueAccessToken(claims)
+		require.NoError(t, err)
+
+		parsed, err := m.ParseAccessToken(token)
+		require.NoError(t, err)
+		require.Equal(t, claims, parsed)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expired := &manager{
+			secret: []byte("testsecret"),
+			ttl:    -time.Minute,
+			logger: logger,
+		}
+		token, _, err := expired.IssueAccessToken(Claims{UserID: uuid.New(), SessionID: uuid.New()})
+		require.NoError(t, err)
+
+		_, err = m.ParseAccessToken(token)
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		other := &manager{
+			secret: []byte("othersecret"),
+			ttl:    time.Minute,
+			logger: logger,
+		}
+		token, _, err := other.IssueAccessToken(Claims{UserID: uuid.New(), SessionID: uuid.New()})
+		require.NoError(t, err)
+
+		_, err = m.ParseAccessToken(token)
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("wrong issuer", func(t *testing.T) {
+		claims := jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Issuer:    "someone",
+			Subject:   uuid.New().String(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+		require.NoError(t, err)
+
+		_, err = m.ParseAccessToken(token)
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("challenge", func(t *testing.T) {
+		challenge, err := m.IssueChallenge(uuid.New())
+		require.NoError(t, err)
+
+		_, err = m.ParseAccessToken(challenge)
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := m.ParseAccessToken("definitely.not.token")
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestParseChallenge(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	m := &manager{
+		secret: []byte("testsecret"),
+		ttl:    time.Minute,
+		logger: logger,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		challenge, err := m.IssueChallenge(uid)
+		require.NoError(t, err)
+
+		parsed, err := m.ParseChallenge(challenge)
+		require.NoError(t, err)
+		require.Equal(t, uid, parsed)
+	})
+
+	t.Run("access token", func(t *testing.T) {
+		token, _, err := m.IssueAccessToken(Claims{UserID: uuid.New(), SessionID: uuid.New()})
+		require.NoError(t, err)
+
+		_, err = m.ParseChallenge(token)
+		require.ErrorIs(t, err, ErrInvalidChallenge)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		claims := jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   uuid.New().String(),
+			Audience:  jwt.ClaimStrings{challengeAudience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+		}
+		challenge, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+		require.NoError(t, err)
+
+		_, err = m.ParseChallenge(challenge)
+		require.ErrorIs(t, err, ErrInvalidChallenge)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := m.ParseChallenge("definitely.not.challenge")
+		require.ErrorIs(t, err, ErrInvalidChallenge)
+	})
+}
+
+func TestRefreshToken(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		sid := uuid.New()
+		token, hash, err := NewRefreshToken(sid)
+		require.NoError(t, err)
+
+		sessionID, parsedHash, err := ParseRefreshToken(token)
+		require.NoError(t, err)
+		require.Equal(t, sid, sessionID)
+		require.Equal(t, hash, parsedHash)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		sid := uuid.New()
+		token, hash, err := NewRefreshToken(sid)
+		require.NoError(t, err)
+
+		_, parsedHash, err := ParseRefreshToken(token + "x")
+		require.NoError(t, err)
+		require.NotEqual(t, hash, parsedHash)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, _, err := ParseRefreshToken("not-a-session.")
+		require.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+		_, _, err = ParseRefreshToken("no-separator")
+		require.ErrorIs(t, err, ErrInvalidRefreshToken)
+	})
+}
+
+func TestUserIDFromContext(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		userID, ok := UserIDFromContext(ContextWithClaims(context.Background(), Claims{UserID: uid}))
+		require.True(t, ok)
+		require.Equal(t, uid, userID)
+	})
+
+	t.Run("no user", func(t *testing.T) {
+		_, ok := UserIDFromContext(context.Background())
+		require.False(t, ok)
+	})
+}
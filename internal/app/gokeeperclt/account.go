@@ -0,0 +1,122 @@
+package gokeeperclt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+)
+
+var (
+	// ErrPasswordMismatch is raised when new master password
+	// and its confirmation are not the same.
+	ErrPasswordMismatch = errors.New("passwords don't match")
+	// ErrDeletionNotConfirmed is raised when user doesn't confirm account deletion.
+	ErrDeletionNotConfirmed = errors.New("account deletion wasn't confirmed")
+)
+
+// changePassword asks for the new master password and replaces the current
+// one with it. Vault key is re-encrypted with the new password, so items
+// stay readable, and the other sessions of the user are terminated by server.
+func (c *Client) changePassword(ctx context.Context) error {
+	password, err := c.askUser("enter new master password:")
+	if err != nil {
+		return err
+	}
+	if password == "" {
+		return fmt.Errorf("password cannot be empty")
+	}
+	confirm, err := c.askUser("repeat new master password:")
+	if err != nil {
+		return err
+	}
+	if password != confirm {
+		return ErrPasswordMismatch
+	}
+
+	keyResp, err := c.rpc.GetVaultKey(ctx, &g.GetVaultKeyRequest{})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return err
+	}
+	if keyResp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(keyResp.Error)
+		return errors.New(keyResp.Error)
+	}
+
+	// user without vault key gets one wrapped with the new password on unlock
+	var wrapped *g.VaultKey
+	if keyResp.VaultKey != nil && len(keyResp.VaultKey.WrappedKey) != 0 {
+		c.logger.Debug().Msg("re-encrypting vault key with the new password")
+		vaultKey, err := unwrapVaultKey(c.user.Password, keyResp.VaultKey)
+		if err != nil {
+			c.logger.Err(err).Caller().Msg("unable to unwrap vault key")
+			return err
+		}
+		wrapped, err = wrapVaultKey(password, vaultKey)
+		if err != nil {
+			c.logger.Err(err).Caller().Msg("unable to wrap vault key")
+			return err
+		}
+	}
+
+	resp, err := c.rpc.ChangePassword(ctx, &g.ChangePasswordRequest{
+		OldPassword: c.user.Password,
+		NewPassword: password,
+		VaultKey:    wrapped,
+	})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return err
+	}
+	if resp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(resp.Error)
+		return errors.New(resp.Error)
+	}
+
+	c.user.Password = password
+	fmt.Println("master password was changed, your other sessions were terminated")
+	return nil
+}
+
+// deleteAccount asks user to confirm deletion with master password, deletes
+// user's account along with all its data and forgets saved session and vault.
+func (c *Client) deleteAccount(ctx context.Context) error {
+	fmt.Println("your account and all your items will be deleted permanently")
+	password, err := c.askUser("enter your master password to confirm:")
+	if err != nil {
+		return err
+	}
+	if password == "" {
+		return ErrDeletionNotConfirmed
+	}
+
+	resp, err := c.rpc.DeleteAccount(ctx, &g.DeleteAccountRequest{Password: password})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return err
+	}
+	if resp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(resp.Error)
+		return errors.New(resp.Error)
+	}
+
+	for _, path := range []string{c.sessionPath(), c.cachePath()} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			c.logger.Err(err).Caller().Str("path", path).Msg("unable to remove saved user's data")
+		}
+	}
+	fmt.Println("your account was deleted")
+	return nil
+}
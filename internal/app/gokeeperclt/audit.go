@@ -0,0 +1,76 @@
+package gokeeperclt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+)
+
+// displayAuditEvents prints user's audit log to stdout, newest events first,
+// asking whether to show the next page until the log ends.
+func (c *Client) displayAuditEvents(ctx context.Context) error {
+	token := ""
+	fmt.Println("\n------------------ AUDIT -----------------")
+	for {
+		resp, err := c.rpc.ListAuditEvents(ctx, &g.ListAuditEventsRequest{
+			PageSize:  int32(c.mode.AuditPageSize),
+			PageToken: token,
+		})
+		if err != nil {
+			c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+			return err
+		}
+		if resp.Error != "" {
+			c.logger.
+				Error().
+				Caller().
+				Msg(resp.Error)
+			return errors.New(resp.Error)
+		}
+
+		for _, event := range resp.Events {
+			fmt.Printf("Time: %s\n", event.Time.AsTime().Local().Format(time.RFC1123))
+			fmt.Printf("Event: %s\n", event.Type)
+			if event.ClientIp != "" {
+				fmt.Printf("IP: %s\n", event.ClientIp)
+			}
+			if details := formatDetails(event.Details); details != "" {
+				fmt.Printf("Details: %s\n", details)
+			}
+			fmt.Println("------------------------------------------")
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+		answer, err := c.askUser("show more events? (y/n)")
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(answer, "y") {
+			break
+		}
+		token = resp.NextPageToken
+	}
+	fmt.Println()
+	return nil
+}
+
+// formatDetails returns event details as key=value pairs sorted by key.
+func formatDetails(details map[string]string) string {
+	keys := make([]string, 0, len(details))
+	for k := range details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + details[k]
+	}
+	return strings.Join(pairs, " ")
+}
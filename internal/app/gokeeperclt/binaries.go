@@ -0,0 +1,315 @@
+package gokeeperclt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// blobChunkSize is the size of plaintext chunks
+	// binary files are encrypted and uploaded in.
+	blobChunkSize = 1 << 20
+	// transferAttempts is the number of attempts to upload
+	// or download a file before giving up.
+	transferAttempts = 3
+	// transferBackoff is the delay before the next attempt.
+	transferBackoff = time.Second
+)
+
+var (
+	// ErrNotBinary is raised when user tries to download
+	// content of the item, which is not a file.
+	ErrNotBinary = errors.New("item is not a file")
+	// ErrChecksumMismatch is raised when received chunk
+	// of the file doesn't match its checksum.
+	ErrChecksumMismatch = errors.New("chunk checksum mismatch")
+	// ErrUnexpectedOffset is raised when server sends
+	// chunk of the file, which wasn't requested.
+	ErrUnexpectedOffset = errors.New("chunk offset doesn't match requested one")
+)
+
+// castagnoli is the CRC-32C table chunks are checksummed with.
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// uploadBinary encrypts file at provided path chunk by chunk and uploads it
+// to server as a blob. Interrupted upload is resumed from the offset
+// server has already received.
+func (c *Client) uploadBinary(ctx context.Context, path string) (*g.BinaryItem, error) {
+	if c.offline {
+		return nil, ErrOffline
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to open file")
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to get file info")
+		return nil, err
+	}
+
+	item := &g.BinaryItem{
+		BlobId: uuid.New().String(),
+		Name:   filepath.Base(path),
+		Size:   info.Size(),
+	}
+	chunks := (uint64(info.Size()) + blobChunkSize - 1) / blobChunkSize
+	if chunks == 0 {
+		chunks = 1
+	}
+
+	var offset int64
+	var complete bool
+	for attempt := 1; !complete; attempt++ {
+		c.logger.Debug().Str("blob", item.BlobId).Int64("offset", offset).Msg("uploading file")
+		err = c.sendBlob(ctx, file, item.BlobId, offset, chunks)
+		if err == nil || !isResumable(err) || attempt == transferAttempts {
+			break
+		}
+
+		c.logger.Info().Err(err).Str("blob", item.BlobId).Msg("upload was interrupted, resuming")
+		time.Sleep(transferBackoff)
+		if offset, complete, err = c.uploadOffset(ctx, item.BlobId); err != nil {
+			break
+		}
+	}
+	if err != nil {
+		c.logger.Err(err).Caller().Str("blob", item.BlobId).Msg("unable to upload file")
+		return nil, err
+	}
+	return item, nil
+}
+
+// sendBlob sends chunks of the file starting at provided offset of the blob.
+func (c *Client) sendBlob(ctx context.Context, file *os.File, blobID string, offset int64, chunks uint64) error {
+	sealedChunkSize := int64(blobChunkSize + c.vault.Overhead())
+	if offset%sealedChunkSize != 0 {
+		return ErrUnexpectedOffset
+	}
+	index := uint64(offset / sealedChunkSize)
+	if index >= chunks {
+		// everything was received, but the blob wasn't completed,
+		// the last chunk is sent again to complete it
+		index = chunks - 1
+	}
+	if _, err := file.Seek(int64(index)*blobChunkSize, io.SeekStart); err != nil {
+		c.logger.Err(err).Caller().Msg("unable to seek file")
+		return err
+	}
+
+	stream, err := c.rpc.UploadBinary(ctx)
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return err
+	}
+
+	buf := make([]byte, blobChunkSize)
+	for ; index < chunks; index++ {
+		n, err := io.ReadFull(file, buf)
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			c.logger.Err(err).Caller().Msg("unable to read file")
+			return err
+		}
+
+		last := index == chunks-1
+		sealed, err := c.vault.SealChunk(buf[:n], []byte(blobID), index, last)
+		if err != nil {
+			c.logger.Err(err).Caller().Msg("unable to encrypt chunk")
+			return err
+		}
+		if err := stream.Send(&g.UploadBinaryRequest{
+			BlobId:   blobID,
+			Offset:   int64(index) * sealedChunkSize,
+			Data:     sealed,
+			Checksum: crc32.Checksum(sealed, castagnoli),
+			Last:     last,
+		}); err != nil {
+			// actual error is returned by CloseAndRecv
+			break
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(resp.Error)
+		return errors.New(resp.Error)
+	}
+	return nil
+}
+
+// isResumable reports whether interrupted upload can be resumed:
+// server can't be reached or another request is completing the blob.
+func isResumable(err error) bool {
+	return isUnavailable(err) || status.Code(err) == codes.Aborted
+}
+
+// uploadOffset sends an rpc request to server to get size
+// of the already uploaded part of the blob and whether it is complete.
+func (c *Client) uploadOffset(ctx context.Context, blobID string) (int64, bool, error) {
+	resp, err := c.rpc.GetUploadOffset(ctx, &g.GetUploadOffsetRequest{BlobId: blobID})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return 0, false, err
+	}
+	if resp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(resp.Error)
+		return 0, false, errors.New(resp.Error)
+	}
+	return resp.Offset, resp.Complete, nil
+}
+
+// downloadBinary downloads file of the binary item with provided id,
+// decrypting it chunk by chunk, and writes it to provided path.
+// By default file is written to the current directory under its original name.
+// Interrupted download is resumed from the last decrypted chunk.
+func (c *Client) downloadBinary(ctx context.Context, id string, path string) error {
+	if c.offline {
+		return ErrOffline
+	}
+
+	var item *g.BinaryItem
+	for _, binary := range c.user.Binaries {
+		if binary.Id == id {
+			item = binary
+		}
+	}
+	if item == nil {
+		if _, ok := c.items[id]; ok {
+			return ErrNotBinary
+		}
+		return ErrUnknownItem
+	}
+	if item.BlobId == "" {
+		return ErrNotBinary
+	}
+	if path == "" {
+		path = filepath.Base(item.Name)
+	}
+
+	part := path + ".part"
+	file, err := os.OpenFile(part, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to create file")
+		return err
+	}
+	defer os.Remove(part)
+	defer file.Close()
+
+	var index uint64
+	for attempt := 1; ; attempt++ {
+		c.logger.Debug().Str("blob", item.BlobId).Uint64("chunk", index).Msg("downloading file")
+		err = c.receiveBlob(ctx, file, item.BlobId, &index)
+		if err == nil || !isUnavailable(err) || attempt == transferAttempts {
+			break
+		}
+
+		c.logger.Info().Err(err).Str("blob", item.BlobId).Msg("download was interrupted, resuming")
+		time.Sleep(transferBackoff)
+		if err = file.Truncate(int64(index) * blobChunkSize); err != nil {
+			break
+		}
+		if _, err = file.Seek(int64(index)*blobChunkSize, io.SeekStart); err != nil {
+			break
+		}
+	}
+	if err != nil {
+		c.logger.Err(err).Caller().Str("blob", item.BlobId).Msg("unable to download file")
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		c.logger.Err(err).Caller().Msg("unable to save file")
+		return err
+	}
+	if err := os.Rename(part, path); err != nil {
+		c.logger.Err(err).Caller().Msg("unable to save file")
+		return err
+	}
+	fmt.Printf("file was saved to %s\n", path)
+	return nil
+}
+
+// receiveBlob receives blob starting at the chunk with provided index,
+// writing decrypted chunks to file. Index is advanced after every written chunk.
+func (c *Client) receiveBlob(ctx context.Context, file *os.File, blobID string, index *uint64) error {
+	sealedChunkSize := blobChunkSize + c.vault.Overhead()
+	offset := int64(*index) * int64(sealedChunkSize)
+
+	stream, err := c.rpc.DownloadBinary(ctx, &g.DownloadBinaryRequest{BlobId: blobID, Offset: offset})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return err
+	}
+
+	var buf []byte
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if resp.Offset != offset {
+			return ErrUnexpectedOffset
+		}
+		if crc32.Checksum(resp.Data, castagnoli) != resp.Checksum {
+			return ErrChecksumMismatch
+		}
+		offset += int64(len(resp.Data))
+
+		// the last chunk can't be told apart until the stream ends,
+		// so at least one chunk is always kept in the buffer
+		buf = append(buf, resp.Data...)
+		for len(buf) > sealedChunkSize {
+			if err := c.writeChunk(file, buf[:sealedChunkSize], blobID, *index, false); err != nil {
+				return err
+			}
+			*index++
+			buf = buf[sealedChunkSize:]
+		}
+	}
+
+	if err := c.writeChunk(file, buf, blobID, *index, true); err != nil {
+		return err
+	}
+	*index++
+	return nil
+}
+
+// writeChunk decrypts chunk of the blob and writes it to file.
+func (c *Client) writeChunk(file *os.File, sealed []byte, blobID string, index uint64, last bool) error {
+	plaintext, err := c.vault.OpenChunk(sealed, []byte(blobID), index, last)
+	if err != nil {
+		c.logger.Err(err).Caller().Uint64("chunk", index).Msg("unable to decrypt chunk")
+		return err
+	}
+	if _, err := file.Write(plaintext); err != nil {
+		c.logger.Err(err).Caller().Msg("unable to write file")
+		return err
+	}
+	return nil
+}
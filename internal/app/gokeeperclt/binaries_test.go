@@ -0,0 +1,168 @@
+package gokeeperclt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"hash/crc32"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// blobServer keeps uploaded blobs in memory. Upload is dropped once
+// after dropAfter chunks to check that it is resumed.
+type blobServer struct {
+	g.UnimplementedGokeeperServer
+
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	dropAfter int
+}
+
+func (s *blobServer) UploadBinary(stream g.Gokeeper_UploadBinaryServer) error {
+	var id string
+	received := 0
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if crc32.Checksum(in.Data, castagnoli) != in.Checksum {
+			return status.Error(codes.DataLoss, "checksum mismatch")
+		}
+
+		s.mu.Lock()
+		id = in.BlobId
+		if in.Offset == int64(len(s.blobs[id])) {
+			s.blobs[id] = append(s.blobs[id], in.Data...)
+		}
+		received++
+		drop := received == s.dropAfter
+		if drop {
+			s.dropAfter = 0
+		}
+		s.mu.Unlock()
+		if drop {
+			return status.Error(codes.Unavailable, "connection was dropped")
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return stream.SendAndClose(&g.UploadBinaryResponse{BlobId: id, Size: int64(len(s.blobs[id]))})
+}
+
+func (s *blobServer) GetUploadOffset(_ context.Context, in *g.GetUploadOffsetRequest) (*g.GetUploadOffsetResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &g.GetUploadOffsetResponse{Offset: int64(len(s.blobs[in.BlobId]))}, nil
+}
+
+func (s *blobServer) DownloadBinary(in *g.DownloadBinaryRequest, stream g.Gokeeper_DownloadBinaryServer) error {
+	s.mu.Lock()
+	blob := s.blobs[in.BlobId]
+	s.mu.Unlock()
+
+	const piece = 300 * 1024
+	for offset := in.Offset; offset < int64(len(blob)); offset += piece {
+		end := offset + piece
+		if end > int64(len(blob)) {
+			end = int64(len(blob))
+		}
+		if err := stream.Send(&g.DownloadBinaryResponse{
+			Offset:   offset,
+			Data:     blob[offset:end],
+			Checksum: crc32.Checksum(blob[offset:end], castagnoli),
+			Size:     int64(len(blob)),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestBinaryTransfer(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	blobs := &blobServer{blobs: make(map[string][]byte), dropAfter: 2}
+	g.RegisterGokeeperServer(srv, blobs)
+	go srv.Serve(listener)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	dir := t.TempDir()
+	clt := newTestClient(dir, "somepwd")
+	clt.rpc = g.NewGokeeperClient(conn)
+	vaultKey, _, err := clt.newVaultKey("somepwd")
+	require.NoError(t, err)
+	require.NoError(t, clt.useVaultKey(vaultKey))
+
+	for name, size := range map[string]int{
+		"several chunks": 3*blobChunkSize + 12345,
+		"empty file":     0,
+	} {
+		t.Run(name, func(t *testing.T) {
+			content := make([]byte, size)
+			_, err := rand.Read(content)
+			require.NoError(t, err)
+			path := filepath.Join(dir, "source.bin")
+			require.NoError(t, os.WriteFile(path, content, 0600))
+
+			item, err := clt.uploadBinary(context.Background(), path)
+			require.NoError(t, err)
+			require.Equal(t, "source.bin", item.Name)
+			require.Equal(t, int64(size), item.Size)
+			if size > 0 {
+				require.False(t, bytes.Contains(blobs.blobs[item.BlobId], content[:size/2]))
+			}
+
+			item.Id = item.BlobId
+			clt.user.Binaries = []*g.BinaryItem{item}
+			out := filepath.Join(dir, "downloaded.bin")
+			require.NoError(t, clt.downloadBinary(context.Background(), item.Id, out))
+
+			downloaded, err := os.ReadFile(out)
+			require.NoError(t, err)
+			require.True(t, bytes.Equal(content, downloaded))
+		})
+	}
+
+	t.Run("tampered blob", func(t *testing.T) {
+		path := filepath.Join(dir, "source.bin")
+		require.NoError(t, os.WriteFile(path, []byte("some data"), 0600))
+		item, err := clt.uploadBinary(context.Background(), path)
+		require.NoError(t, err)
+		blobs.blobs[item.BlobId][len(blobs.blobs[item.BlobId])-1] ^= 1
+
+		item.Id = item.BlobId
+		clt.user.Binaries = []*g.BinaryItem{item}
+		out := filepath.Join(dir, "tampered.bin")
+		require.Error(t, clt.downloadBinary(context.Background(), item.Id, out))
+		_, err = os.Stat(out)
+		require.True(t, os.IsNotExist(err))
+	})
+}
@@ -0,0 +1,301 @@
+package gokeeperclt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// ErrOffline is raised when user requests an operation,
+	// which can't be performed without connection to server.
+	ErrOffline = errors.New("operation requires connection to server")
+	// ErrNoCache is raised when server is unreachable
+	// and there is no saved copy of the user's vault.
+	ErrNoCache = errors.New("server is unreachable and there is no saved copy of your vault")
+)
+
+// vaultCache holds the last synced state of the user's vault,
+// along with items added while server was unreachable.
+type vaultCache struct {
+	Revision int64             `json:"revision"`
+	Items    []*g.Item         `json:"items"`
+	Logins   []*g.LoginItem    `json:"logins"`
+	Cards    []*g.BankCardItem `json:"cards"`
+	Texts    []*g.TextItem     `json:"texts"`
+	Binaries []*g.BinaryItem   `json:"binaries"`
+	Pending  []*g.Item         `json:"pending"`
+}
+
+// cacheFile is the on-disk representation of the cache.
+// Vault is encrypted with the vault key, which is stored wrapped
+// with the key derived from user's master password, so the cache
+// can be opened without server.
+type cacheFile struct {
+	VaultKey *g.VaultKey `json:"vault_key"`
+	Vault    []byte      `json:"vault"`
+}
+
+// identified is implemented by all items, which have an id.
+type identified interface {
+	GetId() string
+}
+
+// isUnavailable reports whether err means that server can't be reached.
+func isUnavailable(err error) bool {
+	code := status.Code(err)
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
+
+// cachePath returns path of the file user's vault is cached in.
+func (c *Client) cachePath() string {
+	return c.userFilePath(".vault")
+}
+
+// loadCache reads cached vault of the user, decrypting it with the vault key.
+// When vault key is not known yet, it is unwrapped from the cache
+// with user's master password. Missing or unreadable cache is not
+// an error while online: the vault is synced from scratch then.
+func (c *Client) loadCache() error {
+	c.cache = &vaultCache{}
+	if c.cfg.Session.Dir == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(c.cachePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		c.logger.Err(err).Caller().Msg("unable to read cache")
+		return err
+	}
+	var file cacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		c.logger.Err(err).Caller().Msg("unable to decode cache")
+		return err
+	}
+
+	if c.vault == nil {
+		if file.VaultKey == nil {
+			return ErrNoCache
+		}
+		c.logger.Debug().Msg("unwrapping vault key from cache")
+		vaultKey, err := unwrapVaultKey(c.user.Password, file.VaultKey)
+		if err != nil {
+			return err
+		}
+		if err := c.useVaultKey(vaultKey); err != nil {
+			return err
+		}
+		c.vaultKey = file.VaultKey
+	}
+
+	plaintext, err := c.vault.Open(file.Vault)
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to decrypt cache")
+		return err
+	}
+	cache := &vaultCache{}
+	if err := json.Unmarshal(plaintext, cache); err != nil {
+		c.logger.Err(err).Caller().Msg("unable to decode cached vault")
+		return err
+	}
+	c.cache = cache
+	return nil
+}
+
+// saveCache encrypts and saves current state of the user's vault.
+func (c *Client) saveCache() {
+	if c.cfg.Session.Dir == "" || c.cache == nil || c.vault == nil || c.vaultKey == nil {
+		return
+	}
+
+	plaintext, err := json.Marshal(c.cache)
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to encode cache")
+		return
+	}
+	sealed, err := c.vault.Seal(plaintext)
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to encrypt cache")
+		return
+	}
+	data, err := json.Marshal(&cacheFile{VaultKey: c.vaultKey, Vault: sealed})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to encode cache")
+		return
+	}
+
+	if err := os.MkdirAll(c.cfg.Session.Dir, 0700); err != nil {
+		c.logger.Err(err).Caller().Msg("unable to create cache directory")
+		return
+	}
+	if err := os.WriteFile(c.cachePath(), data, 0600); err != nil {
+		c.logger.Err(err).Caller().Msg("unable to save cache")
+	}
+}
+
+// openOfflineVault loads cached vault of the user when server is unreachable.
+func (c *Client) openOfflineVault() error {
+	c.offline = true
+	if err := c.loadCache(); err != nil {
+		return err
+	}
+	if c.vault == nil {
+		return ErrNoCache
+	}
+	return c.useCachedItems()
+}
+
+// syncItems pushes items added while offline to server, requests
+// changes made since the last sync and applies them to the cache.
+func (c *Client) syncItems(ctx context.Context) error {
+	if err := c.loadCache(); err != nil {
+		c.logger.Info().Err(err).Msg("cache can't be used, syncing vault from scratch")
+		c.cache = &vaultCache{}
+	}
+
+	if err := c.pushPending(ctx); err != nil {
+		return err
+	}
+
+	resp, err := c.rpc.Sync(ctx, &g.SyncRequest{SinceRevision: c.cache.Revision})
+	if err != nil {
+		c.logger.
+			Err(err).
+			Caller().
+			Msg("unable to perform grpc request")
+		return err
+	}
+	if resp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(resp.Error)
+		return errors.New(resp.Error)
+	}
+	c.logger.Debug().Int64("since", c.cache.Revision).Int64("revision", resp.Revision).Msg("applying vault changes")
+	c.cache.apply(resp)
+	c.saveCache()
+
+	return c.useCachedItems()
+}
+
+// pushPending sends items added while offline to server.
+// Items, which were sent successfully, are removed from the queue.
+// Items queued before they were given ids get them before being sent,
+// so item, which server saved without the client knowing, isn't duplicated.
+func (c *Client) pushPending(ctx context.Context) error {
+	if len(c.cache.Pending) == 0 {
+		return nil
+	}
+
+	assigned := false
+	for _, item := range c.cache.Pending {
+		if item.Id == "" {
+			item.Id = uuid.NewString()
+			assigned = true
+		}
+	}
+	if assigned {
+		c.saveCache()
+	}
+
+	c.logger.Debug().Msgf("pushing %d items added offline", len(c.cache.Pending))
+	for len(c.cache.Pending) > 0 {
+		if err := c.sendItem(ctx, c.cache.Pending[0]); err != nil {
+			c.saveCache()
+			return err
+		}
+		c.cache.Pending = c.cache.Pending[1:]
+	}
+	c.saveCache()
+	fmt.Println("items added offline were saved to server")
+	return nil
+}
+
+// useCachedItems decrypts cached items, so they can be displayed.
+// Legacy items are copied, so the cache keeps them encrypted.
+func (c *Client) useCachedItems() error {
+	c.user.Logins = clone(c.cache.Logins)
+	c.user.Cards = clone(c.cache.Cards)
+	c.user.Texts = clone(c.cache.Texts)
+	c.user.Binaries = clone(c.cache.Binaries)
+	if err := c.decryptLegacyItems(); err != nil {
+		return err
+	}
+	if err := c.decryptItems(c.cache.Items); err != nil {
+		return err
+	}
+	return c.decryptItems(c.cache.Pending)
+}
+
+// apply merges vault changes received from server into the cache.
+func (vc *vaultCache) apply(resp *g.SyncResponse) {
+	if vc.Revision == 0 {
+		*vc = vaultCache{Pending: vc.Pending}
+	}
+	for _, id := range resp.Deleted {
+		vc.Items = remove(vc.Items, id)
+		vc.Logins = remove(vc.Logins, id)
+		vc.Cards = remove(vc.Cards, id)
+		vc.Texts = remove(vc.Texts, id)
+		vc.Binaries = remove(vc.Binaries, id)
+	}
+	for _, item := range resp.Items {
+		vc.Items = upsert(vc.Items, item)
+	}
+	for _, item := range resp.Logins {
+		vc.Logins = upsert(vc.Logins, item)
+	}
+	for _, item := range resp.Cards {
+		vc.Cards = upsert(vc.Cards, item)
+	}
+	for _, item := range resp.Texts {
+		vc.Texts = upsert(vc.Texts, item)
+	}
+	for _, item := range resp.Binaries {
+		vc.Binaries = upsert(vc.Binaries, item)
+	}
+	vc.Revision = resp.Revision
+}
+
+// clone returns deep copy of items.
+func clone[T proto.Message](items []T) []T {
+	cloned := make([]T, 0, len(items))
+	for _, item := range items {
+		cloned = append(cloned, proto.Clone(item).(T))
+	}
+	return cloned
+}
+
+// upsert replaces item with the same id, or appends item if there is none.
+func upsert[T identified](items []T, item T) []T {
+	for i := range items {
+		if items[i].GetId() == item.GetId() {
+			items[i] = item
+			return items
+		}
+	}
+	return append(items, item)
+}
+
+// remove removes item with provided id.
+func remove[T identified](items []T, id string) []T {
+	for i := range items {
+		if items[i].GetId() == id {
+			return append(items[:i], items[i+1:]...)
+		}
+	}
+	return items
+}
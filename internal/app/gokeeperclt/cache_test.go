@@ -0,0 +1,155 @@
+package gokeeperclt
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestClient(dir, password string) *Client {
+	cfg := config.ClientConfig{}
+	cfg.Session.Dir = dir
+	return &Client{
+		cfg: cfg,
+		logger: zerolog.New(zerolog.ConsoleWriter{
+			Out:        os.Stdout,
+			TimeFormat: "02-01-2006 15:04:05 MST",
+		}).Level(zerolog.ErrorLevel),
+		user:  &g.User{Login: "user", Password: password},
+		items: make(map[string]itemRef),
+	}
+}
+
+func TestVaultCacheApply(t *testing.T) {
+	t.Run("initial sync", func(t *testing.T) {
+		cache := &vaultCache{Pending: []*g.Item{{Type: g.ItemType_ITEM_TYPE_TEXT}}}
+		cache.apply(&g.SyncResponse{
+			Revision: 3,
+			Items:    []*g.Item{{Id: "1", Revision: 2}},
+			Logins:   []*g.LoginItem{{Id: "2", Login: "login"}},
+		})
+		require.Equal(t, int64(3), cache.Revision)
+		require.Len(t, cache.Items, 1)
+		require.Len(t, cache.Logins, 1)
+		require.Len(t, cache.Pending, 1)
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		cache := &vaultCache{
+			Revision: 3,
+			Items:    []*g.Item{{Id: "1", Revision: 2}, {Id: "3", Revision: 3}},
+			Logins:   []*g.LoginItem{{Id: "2", Login: "login"}},
+		}
+		cache.apply(&g.SyncResponse{
+			Revision: 5,
+			Items:    []*g.Item{{Id: "1", Revision: 5}, {Id: "4", Revision: 4}},
+			Deleted:  []string{"2", "3"},
+		})
+		require.Equal(t, int64(5), cache.Revision)
+		require.Equal(t, []*g.Item{{Id: "1", Revision: 5}, {Id: "4", Revision: 4}}, cache.Items)
+		require.Empty(t, cache.Logins)
+	})
+}
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	clt := newTestClient(dir, "somepwd")
+	vaultKey, wrapped, err := clt.newVaultKey("somepwd")
+	require.NoError(t, err)
+	require.NoError(t, clt.useVaultKey(vaultKey))
+	clt.vaultKey = wrapped
+
+	payload, err := clt.seal([]byte{})
+	require.NoError(t, err)
+	clt.cache = &vaultCache{
+		Revision: 7,
+		Items:    []*g.Item{{Id: "1", Type: g.ItemType_ITEM_TYPE_TEXT, Payload: payload}},
+	}
+	clt.saveCache()
+
+	t.Run("offline", func(t *testing.T) {
+		offline := newTestClient(dir, "somepwd")
+		require.NoError(t, offline.openOfflineVault())
+		require.Equal(t, int64(7), offline.cache.Revision)
+		require.Len(t, offline.user.Texts, 1)
+		require.Equal(t, "1", offline.user.Texts[0].Id)
+		require.ErrorIs(t, offline.deleteItem(context.Background(), "1"), ErrOffline)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		offline := newTestClient(dir, "wrongpwd")
+		require.ErrorIs(t, offline.openOfflineVault(), ErrWrongMasterPassword)
+	})
+
+	t.Run("no cache", func(t *testing.T) {
+		offline := newTestClient(t.TempDir(), "somepwd")
+		require.ErrorIs(t, offline.openOfflineVault(), ErrNoCache)
+	})
+}
+
+// itemServer keeps ids of added items. Response to the first added item
+// is lost to check that resent item isn't duplicated.
+type itemServer struct {
+	g.UnimplementedGokeeperServer
+
+	added    map[string]int
+	dropped  bool
+	received int
+}
+
+func (s *itemServer) AddItem(_ context.Context, in *g.AddItemRequest) (*g.AddItemResponse, error) {
+	s.received++
+	s.added[in.Item.Id]++
+	if !s.dropped {
+		s.dropped = true
+		return nil, status.Error(codes.Unavailable, "connection lost")
+	}
+	return &g.AddItemResponse{Id: in.Item.Id}, nil
+}
+
+func TestPushPending(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	items := &itemServer{added: make(map[string]int)}
+	g.RegisterGokeeperServer(srv, items)
+	go srv.Serve(listener)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	clt := newTestClient(t.TempDir(), "somepwd")
+	clt.rpc = g.NewGokeeperClient(conn)
+	clt.cache = &vaultCache{Pending: []*g.Item{
+		{Type: g.ItemType_ITEM_TYPE_TEXT, Payload: []byte("queued before ids")},
+		{Id: "2", Type: g.ItemType_ITEM_TYPE_TEXT, Payload: []byte("ciphertext")},
+	}}
+
+	require.Error(t, clt.pushPending(context.Background()))
+	require.Len(t, clt.cache.Pending, 2)
+	id := clt.cache.Pending[0].Id
+	require.NotEmpty(t, id)
+
+	require.NoError(t, clt.pushPending(context.Background()))
+	require.Empty(t, clt.cache.Pending)
+	require.Equal(t, 3, items.received)
+	require.Equal(t, map[string]int{id: 2, "2": 1}, items.added)
+}
@@ -0,0 +1,152 @@
+package gokeeperclt
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// Ways to resolve conflict between local and remote versions of the item.
+const (
+	keepMine   = "1"
+	keepTheirs = "2"
+	keepBoth   = "3"
+	mergeMine  = "4"
+)
+
+// resolveConflict shows local and remote versions of the item,
+// which was changed on another device, and saves the version user chooses:
+// local one, remote one, both of them, or local one with meta fields merged.
+func (c *Client) resolveConflict(ctx context.Context, id string, mine proto.Message, current *g.Item) error {
+	theirs, err := c.openItem(current)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("item was changed on another device since your last sync")
+	fmt.Println("\n---------------- YOURS ----------------")
+	c.printItem(mine)
+	fmt.Println("\n---------------- THEIRS ----------------")
+	c.printItem(theirs)
+	fmt.Println()
+
+	choice, err := c.askUser(fmt.Sprintf(
+		"%s - keep yours, %s - keep theirs, %s - keep both, %s - keep yours with merged meta:",
+		keepMine, keepTheirs, keepBoth, mergeMine,
+	))
+	if err != nil {
+		return err
+	}
+
+	switch choice {
+	case keepMine:
+	case keepTheirs:
+		fmt.Println("kept version from another device")
+		return nil
+	case keepBoth:
+		c.logger.Debug().Str("item", id).Msg("saving local version as new item")
+		return c.addItem(ctx, current.Type, mine)
+	case mergeMine:
+		mergeMeta(mine, theirs)
+	default:
+		return fmt.Errorf("unknown option %q", choice)
+	}
+
+	c.logger.Debug().Str("item", id).Int64("revision", current.Revision).Msg("overwriting remote version")
+	next, err := c.updateItem(ctx, id, current.Revision, mine)
+	if err != nil || next == nil {
+		return err
+	}
+	return c.resolveConflict(ctx, id, mine, next)
+}
+
+// confirmDeletion shows current version of the item, which was changed
+// on another device, and asks user whether it should be deleted anyway.
+// Revision the deletion should expect is returned.
+func (c *Client) confirmDeletion(current *g.Item) (bool, int64, error) {
+	fmt.Println("item was changed on another device since your last sync")
+	var revision int64
+	if current != nil {
+		theirs, err := c.openItem(current)
+		if err != nil {
+			return false, 0, err
+		}
+		fmt.Println("\n---------------- THEIRS ----------------")
+		c.printItem(theirs)
+		fmt.Println()
+		revision = current.Revision
+	}
+
+	answer, err := c.askUser("delete it anyway? [y/N]")
+	if err != nil {
+		return false, 0, err
+	}
+	if strings.ToLower(answer) != "y" {
+		fmt.Println("item was kept")
+		return false, 0, nil
+	}
+	return true, revision, nil
+}
+
+// mergeMeta adds meta fields of the remote version of the item
+// to the local one. Local values win for fields present in both.
+func mergeMeta(mine, theirs proto.Message) {
+	type withMeta interface {
+		GetMeta() map[string]string
+	}
+	merged := make(map[string]string)
+	if t, ok := theirs.(withMeta); ok {
+		for k, v := range t.GetMeta() {
+			merged[k] = v
+		}
+	}
+	if m, ok := mine.(withMeta); ok {
+		for k, v := range m.GetMeta() {
+			merged[k] = v
+		}
+	}
+
+	switch mine := mine.(type) {
+	case *g.LoginItem:
+		mine.Meta = merged
+	case *g.BankCardItem:
+		mine.Meta = merged
+	case *g.TextItem:
+		mine.Meta = merged
+	case *g.BinaryItem:
+		mine.Meta = merged
+	}
+}
+
+// printItem prints single item of any type to stdout.
+func (c *Client) printItem(item proto.Message) {
+	switch item := item.(type) {
+	case *g.LoginItem:
+		c.printLoginItem(item)
+	case *g.BankCardItem:
+		c.printCardItem(item)
+	case *g.TextItem:
+		c.printTextItem(item)
+	case *g.BinaryItem:
+		c.printBinaryItem(item)
+	default:
+		fmt.Println("item of unknown type")
+	}
+}
+
+// askUser prints question and reads user's answer from stdin.
+func (c *Client) askUser(question string) (string, error) {
+	sc := bufio.NewScanner(os.Stdin)
+	fmt.Println(question)
+	sc.Scan()
+	if sc.Err() != nil {
+		c.logger.Err(sc.Err()).Caller().Msg("unable to scan user input")
+		return "", sc.Err()
+	}
+	return strings.TrimSpace(sc.Text()), nil
+}
@@ -0,0 +1,27 @@
+package gokeeperclt
+
+import (
+	"testing"
+
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeMeta(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mine := &g.TextItem{Value: "mine", Meta: map[string]string{"site": "mine", "tag": "work"}}
+		theirs := &g.TextItem{Value: "theirs", Meta: map[string]string{"site": "theirs", "note": "added"}}
+
+		mergeMeta(mine, theirs)
+		require.Equal(t, "mine", mine.Value)
+		require.Equal(t, map[string]string{"site": "mine", "tag": "work", "note": "added"}, mine.Meta)
+	})
+
+	t.Run("nil meta", func(t *testing.T) {
+		mine := &g.TextItem{Value: "mine"}
+		theirs := &g.TextItem{Meta: map[string]string{"note": "added"}}
+
+		mergeMeta(mine, theirs)
+		require.Equal(t, map[string]string{"note": "added"}, mine.Meta)
+	})
+}
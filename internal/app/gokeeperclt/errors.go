@@ -0,0 +1,90 @@
+package gokeeperclt
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// reasonMessages holds messages shown to the user for error
+// reasons reported by server in error details.
+var reasonMessages = map[string]string{
+	"INVALID_CREDENTIALS":      "wrong login or password",
+	"INVALID_SESSION":          "your session has expired or was revoked, please log in again",
+	"USER_EXISTS":              "user with such login already exists, choose another login",
+	"USER_NOT_FOUND":           "there is no user with such login, sign up first",
+	"VAULT_KEY_EXISTS":         "your vault is already set up",
+	"ITEM_NOT_FOUND":           "there is no item with such id in your vault",
+	"ITEM_EXISTS":              "item was already saved",
+	"REVISION_CONFLICT":        "item was changed on another device, sync and try again",
+	"BLOB_NOT_FOUND":           "file wasn't found on server, upload it again",
+	"BLOB_OFFSET_MISMATCH":     "file upload was interrupted, try again to resume it",
+	"BLOB_COMPLETING":          "file is still being saved on server, try again to resume upload",
+	"INCOMPLETE_BLOB":          "file wasn't completely uploaded, try again to resume upload",
+	"BLOB_ATTACHED":            "file already belongs to another item, upload it again",
+	"CHECKSUM_MISMATCH":        "file was corrupted in transfer, try again",
+	"PLAINTEXT_ITEMS_DISABLED": "server accepts encrypted items only, update your client",
+	"UNKNOWN_ITEM_TYPE":        "server doesn't support this item type, update your client",
+	"QUOTA_EXCEEDED":           "your storage quota is exceeded, remove some items or files",
+	"TOO_MANY_ATTEMPTS":        "too many failed login attempts, wait before trying again",
+	"INVALID_CODE":             "wrong or already used code",
+	"INVALID_CHALLENGE":        "code wasn't entered in time, log in again",
+	"TOTP_ENABLED":             "two-factor authentication is already on",
+	"TOTP_NOT_ENABLED":         "two-factor authentication is off",
+	"TOTP_NOT_PENDING":         "start enabling two-factor authentication first",
+	"VAULT_KEY_REQUIRED":       "your vault key has to be re-encrypted with the new password, update the client",
+}
+
+// codeMessages holds messages shown to the user for
+// error codes when server didn't report error reason.
+var codeMessages = map[codes.Code]string{
+	codes.InvalidArgument:    "request is malformed",
+	codes.NotFound:           "requested data wasn't found",
+	codes.AlreadyExists:      "such data already exists",
+	codes.Unauthenticated:    "you are not logged in or your session has expired, please log in again",
+	codes.PermissionDenied:   "you aren't allowed to do this",
+	codes.ResourceExhausted:  "your storage quota is exceeded",
+	codes.FailedPrecondition: "request can't be completed right now",
+	codes.Aborted:            "data was changed concurrently, try again",
+	codes.Unavailable:        "server is unavailable, try again later",
+	codes.DeadlineExceeded:   "server took too long to respond, try again later",
+	codes.DataLoss:           "data was corrupted in transfer, try again",
+	codes.Unimplemented:      "server doesn't support this operation, update server or client",
+	codes.Internal:           "server failed to process request, try again later",
+}
+
+// Describe returns message explaining err to the user. Errors returned
+// by server are described by their reason or code, other errors
+// are described by their own messages.
+func Describe(err error) string {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return err.Error()
+	}
+	st := grpcErr.GRPCStatus()
+
+	msg := ""
+	var retryDelay time.Duration
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			msg = reasonMessages[d.Reason]
+		case *errdetails.RetryInfo:
+			retryDelay = d.RetryDelay.AsDuration()
+		}
+	}
+	if msg == "" {
+		msg = codeMessages[st.Code()]
+	}
+	if msg == "" {
+		return st.Message()
+	}
+	if retryDelay > 0 {
+		msg = fmt.Sprintf("%s (retry in %s)", msg, retryDelay)
+	}
+	return msg
+}
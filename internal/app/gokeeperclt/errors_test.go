@@ -0,0 +1,50 @@
+package gokeeperclt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Run("reason", func(t *testing.T) {
+		st, err := status.New(codes.AlreadyExists, "user already exists").
+			WithDetails(&errdetails.ErrorInfo{Reason: "USER_EXISTS", Domain: "gokeeper"})
+		require.NoError(t, err)
+		require.Equal(t, reasonMessages["USER_EXISTS"], Describe(st.Err()))
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		st, err := status.New(codes.Unauthenticated, "login and/or password incorrect").
+			WithDetails(&errdetails.ErrorInfo{Reason: "INVALID_CREDENTIALS", Domain: "gokeeper"})
+		require.NoError(t, err)
+		require.Equal(t, reasonMessages["INVALID_CREDENTIALS"], Describe(fmt.Errorf("unable to log in: %w", st.Err())))
+	})
+
+	t.Run("code", func(t *testing.T) {
+		require.Equal(t, codeMessages[codes.NotFound], Describe(status.Error(codes.NotFound, "not found")))
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		st, err := status.New(codes.Unavailable, "storage is temporarily unavailable").
+			WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Second)})
+		require.NoError(t, err)
+		require.Equal(t, codeMessages[codes.Unavailable]+" (retry in 1s)", Describe(st.Err()))
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		require.Equal(t, "something", Describe(status.Error(codes.Unknown, "something")))
+	})
+
+	t.Run("local error", func(t *testing.T) {
+		require.Equal(t, ErrOffline.Error(), Describe(ErrOffline))
+		require.Equal(t, "plain", Describe(errors.New("plain")))
+	})
+}
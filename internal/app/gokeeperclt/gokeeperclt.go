@@ -4,31 +4,46 @@ package gokeeperclt
 import (
 	"bufio"
 	"context"
-	"crypto/aes"
 	"crypto/cipher"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/encryption"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/logging"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/tracing"
 	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
+// flushTimeout limits export of remaining traces on exit.
+const flushTimeout = 5 * time.Second
+
 // Client holds app's client-side related objects.
 type Client struct {
 	cfg          config.ClientConfig
 	rpc          g.GokeeperClient
+	conn         *grpc.ClientConn
+	flushTracing func(context.Context) error
 	logger       zerolog.Logger
 	mode         *mode
 	user         *g.User
 	buildVersion string
 	buildDate    string
-	nonce        []byte
-	aesgcm       cipher.AEAD
+	accessToken  string
+	refreshToken string
+	vault        *encryption.Sealer
+	vaultKey     *g.VaultKey
+	legacy       cipher.AEAD
+	items        map[string]itemRef
+	cache        *vaultCache
+	offline      bool
 }
 
 // mode stores all flag values.
@@ -42,6 +57,20 @@ type mode struct {
 	AddCardItem    bool
 	AddTextItem    bool
 	AddBinaryItem  bool
+	Logout         bool
+	ListSessions   bool
+	RevokeSession  string
+	Usage          bool
+	Audit          bool
+	AuditPageSize  int
+	EnableTOTP     bool
+	DisableTOTP    bool
+	ChangePassword bool
+	DeleteAccount  bool
+	EditItem       string
+	DeleteItem     string
+	Download       string
+	Output         string
 	BuildInfo      bool
 }
 
@@ -60,9 +89,26 @@ func NewClient(buildVersion string, buildDate string) (*Client, error) {
 	flag.BoolVar(&mode.AddCardItem, "abc", false, "add bank card item")
 	flag.BoolVar(&mode.AddTextItem, "atext", false, "add text item")
 	flag.BoolVar(&mode.AddBinaryItem, "abins", false, "add binary item")
+	flag.BoolVar(&mode.Logout, "logout", false, "terminate current session")
+	flag.BoolVar(&mode.ListSessions, "sessions", false, "list active sessions")
+	flag.StringVar(&mode.RevokeSession, "revoke", "", "terminate session with provided id")
+	flag.BoolVar(&mode.Usage, "usage", false, "display used storage and quotas")
+	flag.BoolVar(&mode.Audit, "audit", false, "display history of account's security events")
+	flag.BoolVar(&mode.EnableTOTP, "enable-2fa", false, "turn two-factor authentication on")
+	flag.BoolVar(&mode.DisableTOTP, "disable-2fa", false, "turn two-factor authentication off")
+	flag.BoolVar(&mode.ChangePassword, "change-password", false, "change master password")
+	flag.BoolVar(&mode.DeleteAccount, "delete-account", false, "delete account along with all items")
+	flag.IntVar(&mode.AuditPageSize, "audit-page", 0, "number of audit events shown at once (server default if not set)")
+	flag.StringVar(&mode.EditItem, "edit", "", "edit item with provided id")
+	flag.StringVar(&mode.DeleteItem, "delete", "", "delete item with provided id")
+	flag.StringVar(&mode.Download, "download", "", "download file of the binary item with provided id")
+	flag.StringVar(&mode.Output, "out", "", "path downloaded file is saved to")
 	flag.BoolVar(&mode.BuildInfo, "build", false, "display build information")
 
-	cfg := config.GetClientConfig()
+	cfg, err := config.LoadClientConfig(flag.CommandLine, os.Args[1:], os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
 
 	output := zerolog.ConsoleWriter{
 		Out:        os.Stdout,
@@ -74,25 +120,49 @@ func NewClient(buildVersion string, buildDate string) (*Client, error) {
 	} else {
 		level = zerolog.ErrorLevel
 	}
-	logger := zerolog.New(output).With().Timestamp().Logger().Level(level)
+	// client logs requests of the user, so secrets are kept out of them as well
+	logger := zerolog.New(logging.Redact(output)).With().Timestamp().Logger().Level(level)
 
 	logger.Debug().Msg("initializing go-keeper client")
 
-	aesblock, err := aes.NewCipher([]byte(cfg.Key))
+	clt := &Client{
+		cfg:          cfg,
+		logger:       logger,
+		mode:         mode,
+		user:         user,
+		buildVersion: buildVersion,
+		buildDate:    buildDate,
+		items:        make(map[string]itemRef),
+	}
+
+	if cfg.Key != "" {
+		logger.Debug().Msg("initializing legacy cipher")
+		legacy, err := newLegacyCipher(cfg.Key)
+		if err != nil {
+			logger.Err(err).Caller().Msg("unable to generate key")
+			return nil, err
+		}
+		clt.legacy = legacy
+	}
+
+	creds, err := clt.transportCredentials(cfg)
 	if err != nil {
-		logger.Err(err).Caller().Msg("unable to generate key")
 		return nil, err
 	}
-	aesgcm, err := cipher.NewGCM(aesblock)
+
+	logger.Debug().Msg("initializing tracing")
+	clt.flushTracing, err = tracing.Setup(context.Background(), cfg.Tracing, "gokeeper-client", logger)
 	if err != nil {
-		logger.Err(err).Caller().Msg("unable to generate key")
 		return nil, err
 	}
 
 	logger.Debug().Msg("creating gRPC client")
 	conn, err := grpc.Dial(
 		fmt.Sprintf("%s:%d", cfg.Server.Address, cfg.Server.Port),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
+		// trace context is injected into metadata of every request
+		grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor(), clt.authInterceptor),
+		grpc.WithChainStreamInterceptor(otelgrpc.StreamClientInterceptor(), clt.authStreamInterceptor),
 	)
 	if err != nil {
 		logger.
@@ -101,23 +171,26 @@ func NewClient(buildVersion string, buildDate string) (*Client, error) {
 			Msg("unable to connect to go-keeper server")
 		return nil, err
 	}
-	rpcClient := g.NewGokeeperClient(conn)
-
-	logger.Debug().Msg("parsing flags")
-	flag.Parse()
+	clt.conn = conn
+	clt.rpc = g.NewGokeeperClient(conn)
 
 	logger.Info().Msg("go-keeper client was successfully initialized")
-	return &Client{
-		cfg:          cfg,
-		rpc:          rpcClient,
-		logger:       logger,
-		mode:         mode,
-		user:         user,
-		buildVersion: buildVersion,
-		buildDate:    buildDate,
-		aesgcm:       aesgcm,
-		nonce:        []byte("123412341234"),
-	}, nil
+	return clt, nil
+}
+
+// Close flushes collected traces and closes connection to the server.
+func (c *Client) Close() error {
+	if c.flushTracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+		defer cancel()
+		if err := c.flushTracing(ctx); err != nil {
+			c.logger.Warn().Err(err).Msg("unable to flush traces")
+		}
+	}
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
 }
 
 // Run executes the main method of the client app.
@@ -139,17 +212,91 @@ func (c *Client) Run() error {
 			return err
 		}
 		fmt.Printf("successfully signed up, your user id is %s\n", userID)
+		c.saveRefreshToken()
 	} else {
-		userID, err := c.loginUser(context.Background(), c.user.Login, c.user.Password)
-		if err != nil {
+		if err := c.authenticate(context.Background()); err != nil {
+			if !isUnavailable(err) {
+				c.logger.
+					Err(err).
+					Caller().
+					Msg("unable to log user in")
+				return err
+			}
+			if err := c.openOfflineVault(); err != nil {
+				c.logger.
+					Err(err).
+					Caller().
+					Msg("unable to open saved copy of user's vault")
+				return err
+			}
+			if c.mode.Logout || c.mode.ListSessions || c.mode.RevokeSession != "" || c.mode.Usage || c.mode.Audit ||
+				c.mode.EnableTOTP || c.mode.DisableTOTP || c.mode.ChangePassword || c.mode.DeleteAccount {
+				return ErrOffline
+			}
+			fmt.Println("server is unreachable, showing your saved items")
+			return c.runItems(context.Background())
+		}
+		if c.mode.Logout {
+			return c.logout(context.Background())
+		}
+		if c.mode.ListSessions {
+			if err := c.displaySessions(context.Background()); err != nil {
+				c.logger.Err(err).Caller().Msg("unable to list sessions")
+				return err
+			}
+		}
+		if c.mode.RevokeSession != "" {
+			if err := c.revokeSession(context.Background(), c.mode.RevokeSession); err != nil {
+				c.logger.Err(err).Caller().Msg("unable to revoke session")
+				return err
+			}
+			fmt.Printf("session %s was revoked\n", c.mode.RevokeSession)
+		}
+		if c.mode.Usage {
+			if err := c.displayUsage(context.Background()); err != nil {
+				c.logger.Err(err).Caller().Msg("unable to get usage")
+				return err
+			}
+		}
+		if c.mode.Audit {
+			if err := c.displayAuditEvents(context.Background()); err != nil {
+				c.logger.Err(err).Caller().Msg("unable to list audit events")
+				return err
+			}
+		}
+		if c.mode.EnableTOTP {
+			if err := c.enableTOTP(context.Background()); err != nil {
+				c.logger.Err(err).Caller().Msg("unable to enable two-factor authentication")
+				return err
+			}
+		}
+		if c.mode.DisableTOTP {
+			if err := c.disableTOTP(context.Background()); err != nil {
+				c.logger.Err(err).Caller().Msg("unable to disable two-factor authentication")
+				return err
+			}
+		}
+		if c.mode.DeleteAccount {
+			if err := c.deleteAccount(context.Background()); err != nil {
+				c.logger.Err(err).Caller().Msg("unable to delete account")
+				return err
+			}
+			return nil
+		}
+		if c.mode.ChangePassword {
+			if err := c.changePassword(context.Background()); err != nil {
+				c.logger.Err(err).Caller().Msg("unable to change master password")
+				return err
+			}
+		}
+		if err := c.unlockVault(context.Background()); err != nil {
 			c.logger.
 				Err(err).
 				Caller().
-				Msg("unable to log user in")
+				Msg("unable to unlock user's vault")
 			return err
 		}
-		fmt.Printf("successfully logged in, your user id is %s\n", userID)
-		if err = c.updateItems(context.Background(), userID); err != nil {
+		if err := c.syncItems(context.Background()); err != nil {
 			c.logger.
 				Err(err).
 				Caller().
@@ -157,57 +304,84 @@ func (c *Client) Run() error {
 			return err
 		}
 		fmt.Println("updated your items")
-		if c.mode.GetLoginItems {
-			c.displayLoginItems()
+		return c.runItems(context.Background())
+	}
+	return nil
+}
+
+// runItems displays, adds, edits and deletes user's items
+// according to provided flags.
+func (c *Client) runItems(ctx context.Context) error {
+	if c.mode.GetLoginItems {
+		c.displayLoginItems()
+	}
+	if c.mode.GetCardItems {
+		c.displayCardItems()
+	}
+	if c.mode.GetTextItems {
+		c.displayTextItems()
+	}
+	if c.mode.GetBinaryItems {
+		c.displayBinaryItems()
+	}
+	if c.mode.AddLoginItem {
+		item, err := c.getLoginItemFromUser()
+		if err != nil {
+			c.logger.Err(err).Caller().Msg("unable to get login item from user")
+			return err
 		}
-		if c.mode.GetCardItems {
-			c.displayCardItems()
+		if err = c.addLoginItem(ctx, item); err != nil {
+			c.logger.Err(err).Caller().Msg("unable to add new login item")
 		}
-		if c.mode.GetTextItems {
-			c.displayTextItems()
+	}
+	if c.mode.AddCardItem {
+		item, err := c.getCardItemFromUser()
+		if err != nil {
+			c.logger.Err(err).Caller().Msg("unable to get card item from user")
+			return err
 		}
-		if c.mode.GetBinaryItems {
-			c.displayBinaryItems()
+		if err = c.addCardItem(ctx, item); err != nil {
+			c.logger.Err(err).Caller().Msg("unable to add new card item")
 		}
-		if c.mode.AddLoginItem {
-			item, err := c.getLoginItemFromUser()
-			if err != nil {
-				c.logger.Err(err).Caller().Msg("unable to get login item from user")
-				return err
-			}
-			if err = c.addLoginItem(context.Background(), item, userID); err != nil {
-				c.logger.Err(err).Caller().Msg("unable to add new login item")
-			}
+	}
+	if c.mode.AddTextItem {
+		item, err := c.getTextItemFromUser()
+		if err != nil {
+			c.logger.Err(err).Caller().Msg("unable to get text item from user")
+			return err
 		}
-		if c.mode.AddCardItem {
-			item, err := c.getCardItemFromUser()
-			if err != nil {
-				c.logger.Err(err).Caller().Msg("unable to get card item from user")
-				return err
-			}
-			if err = c.addCardItem(context.Background(), item, userID); err != nil {
-				c.logger.Err(err).Caller().Msg("unable to add new card item")
-			}
+		if err = c.addTextItem(ctx, item); err != nil {
+			c.logger.Err(err).Caller().Msg("unable to add new text item")
 		}
-		if c.mode.AddTextItem {
-			item, err := c.getTextItemFromUser()
-			if err != nil {
-				c.logger.Err(err).Caller().Msg("unable to get text item from user")
-				return err
-			}
-			if err = c.addTextItem(context.Background(), item, userID); err != nil {
-				c.logger.Err(err).Caller().Msg("unable to add new text item")
-			}
+	}
+	if c.mode.AddBinaryItem {
+		item, err := c.getBinaryItemFromUser(ctx)
+		if err != nil {
+			c.logger.Err(err).Caller().Msg("unable to get binary item from user")
+			return err
 		}
-		if c.mode.AddBinaryItem {
-			item, err := c.getBinaryItemFromUser()
-			if err != nil {
-				c.logger.Err(err).Caller().Msg("unable to get binary item from user")
-				return err
-			}
-			if err = c.addBinaryItem(context.Background(), item, userID); err != nil {
-				c.logger.Err(err).Caller().Msg("unable to add new binary item")
-			}
+		if err = c.addBinaryItem(ctx, item); err != nil {
+			c.logger.Err(err).Caller().Msg("unable to add new binary item")
+		}
+	}
+	if c.mode.EditItem != "" {
+		if err := c.editItem(ctx, c.mode.EditItem); err != nil {
+			c.logger.Err(err).Caller().Msg("unable to edit item")
+			return err
+		}
+		fmt.Printf("item %s was updated\n", c.mode.EditItem)
+	}
+	if c.mode.DeleteItem != "" {
+		if err := c.deleteItem(ctx, c.mode.DeleteItem); err != nil {
+			c.logger.Err(err).Caller().Msg("unable to delete item")
+			return err
+		}
+		fmt.Printf("item %s was deleted\n", c.mode.DeleteItem)
+	}
+	if c.mode.Download != "" {
+		if err := c.downloadBinary(ctx, c.mode.Download, c.mode.Output); err != nil {
+			c.logger.Err(err).Caller().Msg("unable to download file")
+			return err
 		}
 	}
 	return nil
@@ -220,7 +394,20 @@ func (c *Client) signUpUser(ctx context.Context, login, password string) (string
 		Login:    login,
 		Password: password,
 	}
-	resp, err := c.rpc.SignUpUser(ctx, &g.SignUpUserRequest{User: user})
+	vaultKey, wrapped, err := c.newVaultKey(password)
+	if err != nil {
+		c.logger.
+			Err(err).
+			Caller().
+			Msg("unable to generate vault key")
+		return "", err
+	}
+	req := &g.SignUpUserRequest{
+		User:     user,
+		Device:   c.device(),
+		VaultKey: wrapped,
+	}
+	resp, err := c.rpc.SignUpUser(ctx, req)
 	if err != nil {
 		c.logger.
 			Err(err).
@@ -235,7 +422,10 @@ func (c *Client) signUpUser(ctx context.Context, login, password string) (string
 			Msg(resp.Error)
 		return "", errors.New(resp.Error)
 	}
-	return resp.UserID, nil
+	c.accessToken = resp.AccessToken
+	c.refreshToken = resp.RefreshToken
+	c.vaultKey = wrapped
+	return resp.UserID, c.useVaultKey(vaultKey)
 }
 
 // loginUser sends an rpc request to server
@@ -245,7 +435,7 @@ func (c *Client) loginUser(ctx context.Context, login, password string) (string,
 		Login:    login,
 		Password: password,
 	}
-	resp, err := c.rpc.LoginUser(ctx, &g.LoginUserRequest{User: user})
+	resp, err := c.rpc.LoginUser(ctx, &g.LoginUserRequest{User: user, Device: c.device()})
 	if err != nil {
 		c.logger.
 			Err(err).
@@ -260,117 +450,45 @@ func (c *Client) loginUser(ctx context.Context, login, password string) (string,
 			Msg(resp.Error)
 		return "", errors.New(resp.Error)
 	}
+	if resp.Challenge != "" {
+		if resp, err = c.completeLogin(ctx, resp.Challenge); err != nil {
+			return "", err
+		}
+		if resp.Error != "" {
+			c.logger.
+				Error().
+				Caller().
+				Msg(resp.Error)
+			return "", errors.New(resp.Error)
+		}
+	}
+	c.accessToken = resp.AccessToken
+	c.refreshToken = resp.RefreshToken
 	return resp.UserID, nil
 }
 
-// updateItems sends an rpc request to server
-// to get all user's items.
-func (c *Client) updateItems(ctx context.Context, userID string) error {
-	resp, err := c.rpc.UpdateItems(ctx, &g.UpdateItemsRequest{UserID: userID})
-	if err != nil {
-		c.logger.
-			Err(err).
-			Caller().
-			Msg("unable to perform grpc request")
-		return err
-	}
-	if resp.Error != "" {
-		c.logger.
-			Error().
-			Caller().
-			Msg(resp.Error)
-		return errors.New(resp.Error)
-	}
-	c.user = resp.User
-	return nil
+// addLoginItem encrypts new login item
+// and sends it to server.
+func (c *Client) addLoginItem(ctx context.Context, item *g.LoginItem) error {
+	return c.addItem(ctx, g.ItemType_ITEM_TYPE_LOGIN, item)
 }
 
-// addLoginItem sends an rpc request to server
-// to add new login item.
-func (c *Client) addLoginItem(ctx context.Context, item *g.LoginItem, userID string) error {
-	req := &g.AddLoginItemRequest{
-		Item:   item,
-		UserID: userID,
-	}
-	resp, err := c.rpc.AddLoginItem(ctx, req)
-	if err != nil {
-		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
-		return err
-	}
-	if resp.Error != "" {
-		c.logger.
-			Error().
-			Caller().
-			Msg(resp.Error)
-		return errors.New(resp.Error)
-	}
-	return nil
+// addCardItem encrypts new card item
+// and sends it to server.
+func (c *Client) addCardItem(ctx context.Context, item *g.BankCardItem) error {
+	return c.addItem(ctx, g.ItemType_ITEM_TYPE_BANK_CARD, item)
 }
 
-// addCardItem sends an rpc request to server
-// to add new card item.
-func (c *Client) addCardItem(ctx context.Context, item *g.BankCardItem, userID string) error {
-	req := &g.AddBankCardItemRequest{
-		Item:   item,
-		UserID: userID,
-	}
-	resp, err := c.rpc.AddBankCardItem(ctx, req)
-	if err != nil {
-		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
-		return err
-	}
-	if resp.Error != "" {
-		c.logger.
-			Error().
-			Caller().
-			Msg(resp.Error)
-		return errors.New(resp.Error)
-	}
-	return nil
-}
-
-// addTextItem sends an rpc request to server
-// to add new text item.
-func (c *Client) addTextItem(ctx context.Context, item *g.TextItem, userID string) error {
-	req := &g.AddTextItemRequest{
-		Item:   item,
-		UserID: userID,
-	}
-	resp, err := c.rpc.AddTextItem(ctx, req)
-	if err != nil {
-		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
-		return err
-	}
-	if resp.Error != "" {
-		c.logger.
-			Error().
-			Caller().
-			Msg(resp.Error)
-		return errors.New(resp.Error)
-	}
-	return nil
+// addTextItem encrypts new text item
+// and sends it to server.
+func (c *Client) addTextItem(ctx context.Context, item *g.TextItem) error {
+	return c.addItem(ctx, g.ItemType_ITEM_TYPE_TEXT, item)
 }
 
-// addBinaryItem sends an rpc request to server
-// to add new binary item.
-func (c *Client) addBinaryItem(ctx context.Context, item *g.BinaryItem, userID string) error {
-	req := &g.AddBinaryItemRequest{
-		Item:   item,
-		UserID: userID,
-	}
-	resp, err := c.rpc.AddBinaryItem(ctx, req)
-	if err != nil {
-		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
-		return err
-	}
-	if resp.Error != "" {
-		c.logger.
-			Error().
-			Caller().
-			Msg(resp.Error)
-		return errors.New(resp.Error)
-	}
-	return nil
+// addBinaryItem encrypts new binary item
+// and sends it to server.
+func (c *Client) addBinaryItem(ctx context.Context, item *g.BinaryItem) error {
+	return c.addItem(ctx, g.ItemType_ITEM_TYPE_BINARY, item)
 }
 
 // getLoginItemFromUser requests user to enter login item
@@ -392,7 +510,7 @@ func (c *Client) getLoginItemFromUser() (*g.LoginItem, error) {
 		c.logger.Err(sc.Err()).Caller().Msg("unable to scan user input")
 		return nil, sc.Err()
 	}
-	item.Password = c.aesgcm.Seal(nil, c.nonce, []byte(sc.Text()), nil)
+	item.Password = []byte(sc.Text())
 
 	fmt.Println("Meta (leave field empty to stop):")
 	fmt.Println()
@@ -459,7 +577,7 @@ func (c *Client) getCardItemFromUser() (*g.BankCardItem, error) {
 		c.logger.Err(sc.Err()).Caller().Msg("unable to scan user input")
 		return nil, sc.Err()
 	}
-	item.CardSecurityCode = c.aesgcm.Seal(nil, c.nonce, []byte(sc.Text()), nil)
+	item.CardSecurityCode = []byte(sc.Text())
 
 	fmt.Println("Meta (leave field empty to stop):")
 	fmt.Println()
@@ -534,18 +652,21 @@ func (c *Client) getTextItemFromUser() (*g.TextItem, error) {
 	return item, nil
 }
 
-// getBinaryItemFromUser requests user to enter binary item
-// information through stdin.
-func (c *Client) getBinaryItemFromUser() (*g.BinaryItem, error) {
+// getBinaryItemFromUser requests user to enter path to the file
+// and binary item information through stdin, uploading the file.
+func (c *Client) getBinaryItemFromUser(ctx context.Context) (*g.BinaryItem, error) {
 	sc := bufio.NewScanner(os.Stdin)
-	item := &g.BinaryItem{}
-	fmt.Println("Binary:")
+	fmt.Println("Path to file:")
 	sc.Scan()
 	if sc.Err() != nil {
 		c.logger.Err(sc.Err()).Caller().Msg("unable to scan user input")
 		return nil, sc.Err()
 	}
-	item.Value = []byte(sc.Text())
+	item, err := c.uploadBinary(ctx, sc.Text())
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("file %s was uploaded\n", item.Name)
 
 	fmt.Println("Meta (leave field empty to stop):")
 	fmt.Println()
@@ -585,22 +706,25 @@ func (c *Client) displayLoginItems() {
 		return
 	}
 	for _, item := range c.user.Logins {
-		fmt.Printf("Login: %s\n", item.Login)
-		pwd, err := c.aesgcm.Open(nil, c.nonce, item.Password, nil)
-		if err != nil {
-			c.logger.Err(err).Caller().Msg("unable to decypher password")
-			return
-		}
-		fmt.Printf("Password: %s\n", pwd)
-		fmt.Println("Meta:")
-		for k, v := range item.Meta {
-			fmt.Printf("\t%s: %v\n", k, v)
-		}
+		c.printLoginItem(item)
 		fmt.Println("----------------------------------------")
 	}
 	fmt.Println()
 }
 
+// printLoginItem prints single login item to stdout.
+func (c *Client) printLoginItem(item *g.LoginItem) {
+	if item.Id != "" {
+		fmt.Printf("ID: %s\n", item.Id)
+	}
+	fmt.Printf("Login: %s\n", item.Login)
+	fmt.Printf("Password: %s\n", item.Password)
+	fmt.Println("Meta:")
+	for k, v := range item.Meta {
+		fmt.Printf("\t%s: %v\n", k, v)
+	}
+}
+
 // displayCardItems prints all card items to stdout.
 func (c *Client) displayCardItems() {
 	fmt.Println("\n---------------- CARDS ----------------")
@@ -609,24 +733,27 @@ func (c *Client) displayCardItems() {
 		return
 	}
 	for _, item := range c.user.Cards {
-		fmt.Printf("Holder: %s\n", item.Holder)
-		fmt.Printf("Number: %s\n", item.Number)
-		fmt.Printf("Expires: %s\n", item.Expires)
-		code, err := c.aesgcm.Open(nil, c.nonce, item.CardSecurityCode, nil)
-		if err != nil {
-			c.logger.Err(err).Caller().Msg("unable to decypher security code")
-			return
-		}
-		fmt.Printf("Security code: %s\n", code)
-		fmt.Println("Meta:")
-		for k, v := range item.Meta {
-			fmt.Printf("\t%s: %v\n", k, v)
-		}
+		c.printCardItem(item)
 		fmt.Println("---------------------------------------")
 	}
 	fmt.Println()
 }
 
+// printCardItem prints single card item to stdout.
+func (c *Client) printCardItem(item *g.BankCardItem) {
+	if item.Id != "" {
+		fmt.Printf("ID: %s\n", item.Id)
+	}
+	fmt.Printf("Holder: %s\n", item.Holder)
+	fmt.Printf("Number: %s\n", item.Number)
+	fmt.Printf("Expires: %s\n", item.Expires)
+	fmt.Printf("Security code: %s\n", item.CardSecurityCode)
+	fmt.Println("Meta:")
+	for k, v := range item.Meta {
+		fmt.Printf("\t%s: %v\n", k, v)
+	}
+}
+
 // displayTextItems prints all text items to stdout.
 func (c *Client) displayTextItems() {
 	fmt.Println("\n---------------- TEXTS ----------------")
@@ -635,16 +762,24 @@ func (c *Client) displayTextItems() {
 		return
 	}
 	for _, item := range c.user.Texts {
-		fmt.Printf("Text: %s\n", item.Value)
-		fmt.Println("Meta:")
-		for k, v := range item.Meta {
-			fmt.Printf("\t%s: %v\n", k, v)
-		}
+		c.printTextItem(item)
 		fmt.Println("---------------------------------------")
 	}
 	fmt.Println()
 }
 
+// printTextItem prints single text item to stdout.
+func (c *Client) printTextItem(item *g.TextItem) {
+	if item.Id != "" {
+		fmt.Printf("ID: %s\n", item.Id)
+	}
+	fmt.Printf("Text: %s\n", item.Value)
+	fmt.Println("Meta:")
+	for k, v := range item.Meta {
+		fmt.Printf("\t%s: %v\n", k, v)
+	}
+}
+
 // displayBinaryItems prints all binary items to stdout.
 func (c *Client) displayBinaryItems() {
 	fmt.Println("\n---------------- BINARIES ----------------")
@@ -653,12 +788,55 @@ func (c *Client) displayBinaryItems() {
 		return
 	}
 	for _, item := range c.user.Binaries {
-		fmt.Printf("Binary data: %s\n", item.Value)
-		fmt.Println("Meta:")
-		for k, v := range item.Meta {
-			fmt.Printf("\t%s: %v\n", k, v)
-		}
+		c.printBinaryItem(item)
 		fmt.Println("------------------------------------------")
 	}
 	fmt.Println()
 }
+
+// printBinaryItem prints single binary item to stdout.
+func (c *Client) printBinaryItem(item *g.BinaryItem) {
+	if item.Id != "" {
+		fmt.Printf("ID: %s\n", item.Id)
+	}
+	if item.BlobId != "" {
+		fmt.Printf("File: %s (%d bytes)\n", item.Name, item.Size)
+	} else {
+		fmt.Printf("Binary data: %s\n", item.Value)
+	}
+	fmt.Println("Meta:")
+	for k, v := range item.Meta {
+		fmt.Printf("\t%s: %v\n", k, v)
+	}
+}
+
+// authStreamInterceptor attaches access token to every outgoing stream.
+func (c *Client) authStreamInterceptor(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	if c.accessToken != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.accessToken)
+	}
+	return streamer(ctx, desc, cc, method, opts...)
+}
+
+// authInterceptor attaches access token, received on sign up or login,
+// to every outgoing rpc request.
+func (c *Client) authInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if c.accessToken != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.accessToken)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
@@ -0,0 +1,309 @@
+package gokeeperclt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrUnknownItem is raised when user refers to item,
+// which is not in the user's vault.
+var ErrUnknownItem = errors.New("there is no item with such id in your vault")
+
+// itemRef describes item of the user's vault, which can be edited or deleted.
+type itemRef struct {
+	itemType  g.ItemType
+	encrypted bool
+	revision  int64
+}
+
+// addItem serializes item, encrypts it with user's vault key
+// and sends an rpc request to server to add it as opaque payload.
+// While server is unreachable, item is queued in the cache
+// and sent on the next successful connection. Item is given its id
+// by the client, so it isn't duplicated when sent again.
+func (c *Client) addItem(ctx context.Context, itemType g.ItemType, item proto.Message) error {
+	plaintext, err := proto.Marshal(item)
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to serialize item")
+		return err
+	}
+	payload, err := c.seal(plaintext)
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to encrypt item")
+		return err
+	}
+
+	envelope := &g.Item{
+		Id:      uuid.NewString(),
+		Type:    itemType,
+		Payload: payload,
+	}
+	if binary, ok := item.(*g.BinaryItem); ok {
+		envelope.BlobId = binary.BlobId
+	}
+	if c.offline {
+		c.cache.Pending = append(c.cache.Pending, envelope)
+		c.saveCache()
+		fmt.Println("server is unreachable, item will be saved on the next connection")
+		return nil
+	}
+	return c.sendItem(ctx, envelope)
+}
+
+// sendItem sends an rpc request to server to add encrypted item.
+func (c *Client) sendItem(ctx context.Context, item *g.Item) error {
+	resp, err := c.rpc.AddItem(ctx, &g.AddItemRequest{Item: item})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return err
+	}
+	if resp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(resp.Error)
+		return errors.New(resp.Error)
+	}
+	c.logger.Debug().Str("item", resp.Id).Msg("item was added")
+	return nil
+}
+
+// updateItem serializes item, encrypts it with user's vault key
+// and sends an rpc request to server to replace payload of the item.
+// If item was changed on another device since expected revision,
+// its current version is returned.
+func (c *Client) updateItem(ctx context.Context, id string, expectedRevision int64, item proto.Message) (*g.Item, error) {
+	plaintext, err := proto.Marshal(item)
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to serialize item")
+		return nil, err
+	}
+	payload, err := c.seal(plaintext)
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to encrypt item")
+		return nil, err
+	}
+
+	req := &g.UpdateItemRequest{
+		Item: &g.Item{
+			Id:      id,
+			Payload: payload,
+		},
+		ExpectedRevision: expectedRevision,
+	}
+	if binary, ok := item.(*g.BinaryItem); ok {
+		req.Item.BlobId = binary.BlobId
+	}
+	resp, err := c.rpc.UpdateItem(ctx, req)
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return nil, err
+	}
+	if resp.Conflict && resp.Current != nil {
+		c.logger.Debug().Str("item", id).Msg("item was changed on another device")
+		return resp.Current, nil
+	}
+	if resp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(resp.Error)
+		return nil, errors.New(resp.Error)
+	}
+	return nil, nil
+}
+
+// deleteItem sends an rpc request to server
+// to remove item from the user's vault.
+func (c *Client) deleteItem(ctx context.Context, id string) error {
+	if c.offline {
+		return ErrOffline
+	}
+	ref, ok := c.items[id]
+	if !ok {
+		return ErrUnknownItem
+	}
+
+	expectedRevision := ref.revision
+	for {
+		resp, err := c.rpc.DeleteItem(ctx, &g.DeleteItemRequest{Id: id, ExpectedRevision: expectedRevision})
+		if err != nil {
+			c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+			return err
+		}
+		if resp.Conflict {
+			c.logger.Debug().Str("item", id).Msg("item was changed on another device")
+			confirmed, current, err := c.confirmDeletion(resp.Current)
+			if err != nil || !confirmed {
+				return err
+			}
+			expectedRevision = current
+			continue
+		}
+		if resp.Error != "" {
+			c.logger.
+				Error().
+				Caller().
+				Msg(resp.Error)
+			return errors.New(resp.Error)
+		}
+		return nil
+	}
+}
+
+// editItem requests user to enter new information of the item
+// and saves it. Items added before whole payloads were encrypted
+// are replaced with encrypted ones. If item was changed on another
+// device in the meantime, user is asked how to resolve the conflict.
+func (c *Client) editItem(ctx context.Context, id string) error {
+	if c.offline {
+		return ErrOffline
+	}
+	ref, ok := c.items[id]
+	if !ok {
+		return ErrUnknownItem
+	}
+
+	item, err := c.getItemFromUser(ctx, ref.itemType)
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to get item from user")
+		return err
+	}
+
+	if ref.encrypted {
+		current, err := c.updateItem(ctx, id, ref.revision, item)
+		if err != nil || current == nil {
+			return err
+		}
+		return c.resolveConflict(ctx, id, item, current)
+	}
+
+	c.logger.Debug().Str("item", id).Msg("replacing plaintext item with encrypted one")
+	if err := c.addItem(ctx, ref.itemType, item); err != nil {
+		return err
+	}
+	return c.deleteItem(ctx, id)
+}
+
+// getItemFromUser requests user to enter information
+// of the item of provided type through stdin.
+func (c *Client) getItemFromUser(ctx context.Context, itemType g.ItemType) (proto.Message, error) {
+	switch itemType {
+	case g.ItemType_ITEM_TYPE_LOGIN:
+		return c.getLoginItemFromUser()
+	case g.ItemType_ITEM_TYPE_BANK_CARD:
+		return c.getCardItemFromUser()
+	case g.ItemType_ITEM_TYPE_TEXT:
+		return c.getTextItemFromUser()
+	case g.ItemType_ITEM_TYPE_BINARY:
+		return c.getBinaryItemFromUser(ctx)
+	default:
+		return nil, fmt.Errorf("unable to edit item of type %s", itemType)
+	}
+}
+
+// decryptItems decrypts items received from server and merges them
+// with the user's items of corresponding type.
+func (c *Client) decryptItems(items []*g.Item) error {
+	for _, item := range items {
+		decrypted, err := c.openItem(item)
+		if err != nil {
+			return err
+		}
+
+		switch decrypted := decrypted.(type) {
+		case *g.LoginItem:
+			decrypted.Id = item.Id
+			c.user.Logins = append(c.user.Logins, decrypted)
+		case *g.BankCardItem:
+			decrypted.Id = item.Id
+			c.user.Cards = append(c.user.Cards, decrypted)
+		case *g.TextItem:
+			decrypted.Id = item.Id
+			c.user.Texts = append(c.user.Texts, decrypted)
+		case *g.BinaryItem:
+			decrypted.Id = item.Id
+			c.user.Binaries = append(c.user.Binaries, decrypted)
+		default:
+			c.logger.Info().Str("item", item.Id).Str("type", item.Type.String()).Msg("skipping item of unknown type")
+			continue
+		}
+		if item.Id != "" {
+			c.items[item.Id] = itemRef{itemType: item.Type, encrypted: true, revision: item.Revision}
+		}
+	}
+	return nil
+}
+
+// openItem decrypts payload of the item and deserializes it into
+// typed item. Items of unknown type are returned as nil.
+func (c *Client) openItem(item *g.Item) (proto.Message, error) {
+	plaintext, err := c.open(item.Payload)
+	if err != nil {
+		c.logger.Err(err).Caller().Str("item", item.Id).Msg("unable to decrypt item")
+		return nil, err
+	}
+
+	var decrypted proto.Message
+	switch item.Type {
+	case g.ItemType_ITEM_TYPE_LOGIN:
+		decrypted = &g.LoginItem{}
+	case g.ItemType_ITEM_TYPE_BANK_CARD:
+		decrypted = &g.BankCardItem{}
+	case g.ItemType_ITEM_TYPE_TEXT:
+		decrypted = &g.TextItem{}
+	case g.ItemType_ITEM_TYPE_BINARY:
+		decrypted = &g.BinaryItem{}
+	default:
+		return nil, nil
+	}
+	if err := proto.Unmarshal(plaintext, decrypted); err != nil {
+		c.logger.Err(err).Caller().Str("item", item.Id).Msg("unable to deserialize item")
+		return nil, err
+	}
+	return decrypted, nil
+}
+
+// decryptLegacyItems decrypts sensitive fields of items,
+// which were added before whole item payloads were encrypted.
+func (c *Client) decryptLegacyItems() error {
+	for _, item := range c.user.Logins {
+		pwd, err := c.open(item.Password)
+		if err != nil {
+			c.logger.Err(err).Caller().Msg("unable to decypher password")
+			return err
+		}
+		item.Password = pwd
+		c.addLegacyRef(item.Id, g.ItemType_ITEM_TYPE_LOGIN)
+	}
+	for _, item := range c.user.Cards {
+		code, err := c.open(item.CardSecurityCode)
+		if err != nil {
+			c.logger.Err(err).Caller().Msg("unable to decypher security code")
+			return err
+		}
+		item.CardSecurityCode = code
+		c.addLegacyRef(item.Id, g.ItemType_ITEM_TYPE_BANK_CARD)
+	}
+	for _, item := range c.user.Texts {
+		c.addLegacyRef(item.Id, g.ItemType_ITEM_TYPE_TEXT)
+	}
+	for _, item := range c.user.Binaries {
+		c.addLegacyRef(item.Id, g.ItemType_ITEM_TYPE_BINARY)
+	}
+	return nil
+}
+
+// addLegacyRef remembers item with plaintext fields, so it can be edited
+// or deleted. Items stored before they had ids are skipped.
+func (c *Client) addLegacyRef(id string, itemType g.ItemType) {
+	if id == "" {
+		return
+	}
+	c.items[id] = itemRef{itemType: itemType}
+}
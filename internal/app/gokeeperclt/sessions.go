@@ -0,0 +1,179 @@
+package gokeeperclt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+)
+
+// authenticate resumes saved session of the user if there is one,
+// otherwise logs user in with login and password.
+func (c *Client) authenticate(ctx context.Context) error {
+	if token := c.loadRefreshToken(); token != "" {
+		c.logger.Debug().Msg("resuming saved session")
+		if err := c.refreshSession(ctx, token); err == nil {
+			c.saveRefreshToken()
+			fmt.Println("successfully resumed your session")
+			return nil
+		}
+		c.logger.Debug().Msg("saved session can't be resumed, logging in")
+	}
+
+	userID, err := c.loginUser(ctx, c.user.Login, c.user.Password)
+	if err != nil {
+		return err
+	}
+	c.saveRefreshToken()
+	fmt.Printf("successfully logged in, your user id is %s\n", userID)
+	return nil
+}
+
+// refreshSession sends an rpc request to server
+// to exchange refresh token for a new pair of tokens.
+func (c *Client) refreshSession(ctx context.Context, refreshToken string) error {
+	resp, err := c.rpc.RefreshSession(ctx, &g.RefreshSessionRequest{RefreshToken: refreshToken})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to refresh session")
+		return err
+	}
+	if resp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(resp.Error)
+		return errors.New(resp.Error)
+	}
+	c.accessToken = resp.AccessToken
+	c.refreshToken = resp.RefreshToken
+	return nil
+}
+
+// logout sends an rpc request to server
+// to terminate current session and forgets saved refresh token.
+func (c *Client) logout(ctx context.Context) error {
+	resp, err := c.rpc.Logout(ctx, &g.LogoutRequest{})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return err
+	}
+	if resp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(resp.Error)
+		return errors.New(resp.Error)
+	}
+
+	if err := os.Remove(c.sessionPath()); err != nil && !os.IsNotExist(err) {
+		c.logger.Err(err).Caller().Msg("unable to remove saved session")
+	}
+	fmt.Println("successfully logged out")
+	return nil
+}
+
+// revokeSession sends an rpc request to server
+// to terminate one of the user's sessions.
+func (c *Client) revokeSession(ctx context.Context, sessionID string) error {
+	resp, err := c.rpc.RevokeSession(ctx, &g.RevokeSessionRequest{SessionID: sessionID})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return err
+	}
+	if resp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(resp.Error)
+		return errors.New(resp.Error)
+	}
+	return nil
+}
+
+// displaySessions prints all active user's sessions to stdout.
+func (c *Client) displaySessions(ctx context.Context) error {
+	resp, err := c.rpc.ListSessions(ctx, &g.ListSessionsRequest{})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return err
+	}
+	if resp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(resp.Error)
+		return errors.New(resp.Error)
+	}
+
+	fmt.Println("\n---------------- SESSIONS ----------------")
+	for _, session := range resp.Sessions {
+		current := ""
+		if session.Current {
+			current = " (current)"
+		}
+		fmt.Printf("ID: %s%s\n", session.Id, current)
+		fmt.Printf("Device: %s\n", session.Device)
+		fmt.Printf("Started: %s\n", session.CreatedAt.AsTime().Local().Format(time.RFC1123))
+		fmt.Printf("Last seen: %s\n", session.LastSeenAt.AsTime().Local().Format(time.RFC1123))
+		fmt.Println("------------------------------------------")
+	}
+	fmt.Println()
+	return nil
+}
+
+// device returns label of the device client is running on.
+func (c *Client) device() string {
+	if c.cfg.Session.Device != "" {
+		return c.cfg.Session.Device
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	return hostname
+}
+
+// sessionPath returns path of the file user's refresh token is saved to.
+func (c *Client) sessionPath() string {
+	return c.userFilePath(".session")
+}
+
+// userFilePath returns path of the user's file with provided extension
+// in the session directory.
+func (c *Client) userFilePath(ext string) string {
+	login := strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(c.user.Login)
+	return filepath.Join(c.cfg.Session.Dir, login+ext)
+}
+
+// loadRefreshToken returns saved refresh token of the user,
+// or empty string if there is none.
+func (c *Client) loadRefreshToken() string {
+	if c.cfg.Session.Dir == "" {
+		return ""
+	}
+	token, err := os.ReadFile(c.sessionPath())
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(token))
+}
+
+// saveRefreshToken saves current refresh token of the user,
+// so the session can be resumed on the next run.
+func (c *Client) saveRefreshToken() {
+	if c.cfg.Session.Dir == "" || c.refreshToken == "" {
+		return
+	}
+	if err := os.MkdirAll(c.cfg.Session.Dir, 0700); err != nil {
+		c.logger.Err(err).Caller().Msg("unable to create session directory")
+		return
+	}
+	if err := os.WriteFile(c.sessionPath(), []byte(c.refreshToken), 0600); err != nil {
+		c.logger.Err(err).Caller().Msg("unable to save session")
+	}
+}
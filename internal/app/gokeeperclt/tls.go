@@ -0,0 +1,51 @@
+package gokeeperclt
+
+import (
+	"crypto/tls"
+
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/certs"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// transportCredentials returns credentials connection
+// with server is secured with according to configuration.
+func (c *Client) transportCredentials(cfg config.ClientConfig) (credentials.TransportCredentials, error) {
+	if cfg.TLS.Insecure {
+		c.logger.Warn().Msg("TLS is disabled, credentials will be sent in plaintext")
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.TLS.ServerName,
+	}
+	if cfg.TLS.CAFile != "" {
+		c.logger.Debug().Str("ca", cfg.TLS.CAFile).Msg("loading server CAs")
+		pool, err := certs.LoadCertPool(cfg.TLS.CAFile)
+		if err != nil {
+			c.logger.
+				Err(err).
+				Caller().
+				Str("ca", cfg.TLS.CAFile).
+				Msg("unable to load server CAs")
+			return nil, err
+		}
+		tlsCfg.RootCAs = pool
+	}
+	if cfg.TLS.CertFile != "" {
+		c.logger.Debug().Str("cert", cfg.TLS.CertFile).Msg("loading client certificate")
+		cert, err := tls.LoadX509KeyPair(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		if err != nil {
+			c.logger.
+				Err(err).
+				Caller().
+				Str("cert", cfg.TLS.CertFile).
+				Msg("unable to load client certificate")
+			return nil, err
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(tlsCfg), nil
+}
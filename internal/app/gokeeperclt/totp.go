@@ -0,0 +1,105 @@
+package gokeeperclt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+)
+
+// enableTOTP enrolls the user in two-factor authentication: prints
+// secret to be added to authenticator app, asks for the code it generates
+// and prints recovery codes once server accepts it.
+func (c *Client) enableTOTP(ctx context.Context) error {
+	resp, err := c.rpc.EnableTOTP(ctx, &g.EnableTOTPRequest{})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return err
+	}
+	if resp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(resp.Error)
+		return errors.New(resp.Error)
+	}
+
+	fmt.Println("\n------------ TWO-FACTOR AUTH -------------")
+	fmt.Println("Add this account to your authenticator app with the URI:")
+	fmt.Println(resp.Uri)
+	fmt.Printf("or enter the secret manually: %s\n", resp.Secret)
+	fmt.Println("------------------------------------------")
+	code, err := c.askUser("enter the code shown by your authenticator app:")
+	if err != nil {
+		return err
+	}
+
+	confirm, err := c.rpc.ConfirmTOTP(ctx, &g.ConfirmTOTPRequest{Code: code})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return err
+	}
+	if confirm.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(confirm.Error)
+		return errors.New(confirm.Error)
+	}
+
+	fmt.Println("two-factor authentication is on")
+	fmt.Println("Keep these recovery codes safe, each of them can be used once instead of a code:")
+	for _, code := range confirm.RecoveryCodes {
+		fmt.Println(code)
+	}
+	fmt.Println()
+	return nil
+}
+
+// disableTOTP asks for one-time or recovery code
+// and turns user's two-factor authentication off.
+func (c *Client) disableTOTP(ctx context.Context) error {
+	code, err := c.askUser("enter the code shown by your authenticator app or a recovery code:")
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.rpc.DisableTOTP(ctx, &g.DisableTOTPRequest{Code: code})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return err
+	}
+	if resp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(resp.Error)
+		return errors.New(resp.Error)
+	}
+	fmt.Println("two-factor authentication is off")
+	return nil
+}
+
+// completeLogin asks for one-time or recovery code and sends it
+// along with login challenge, returning response with session tokens.
+func (c *Client) completeLogin(ctx context.Context, challenge string) (*g.LoginUserResponse, error) {
+	code, err := c.askUser("enter the code shown by your authenticator app or a recovery code:")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.rpc.LoginUser(ctx, &g.LoginUserRequest{
+		Challenge: challenge,
+		Code:      code,
+		Device:    c.device(),
+	})
+	if err != nil {
+		c.logger.
+			Err(err).
+			Caller().
+			Msg("unable to complete user login")
+		return nil, err
+	}
+	return resp, nil
+}
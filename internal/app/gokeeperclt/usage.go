@@ -0,0 +1,63 @@
+package gokeeperclt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+)
+
+// displayUsage prints storage used by the user along with server quotas.
+func (c *Client) displayUsage(ctx context.Context) error {
+	resp, err := c.rpc.GetUsage(ctx, &g.GetUsageRequest{})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return err
+	}
+	if resp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(resp.Error)
+		return errors.New(resp.Error)
+	}
+
+	fmt.Println("\n----------------- USAGE ------------------")
+	fmt.Printf("Items: %d%s\n", resp.Items, limit(resp.MaxItems, formatCount))
+	fmt.Printf("Storage: %s%s\n", formatBytes(resp.Bytes), limit(resp.MaxBytes, formatBytes))
+	if resp.MaxItemSize > 0 {
+		fmt.Printf("Max item size: %s\n", formatBytes(resp.MaxItemSize))
+	}
+	fmt.Println("------------------------------------------")
+	fmt.Println()
+	return nil
+}
+
+// limit returns formatted quota suffix, or empty string if there is no quota.
+func limit(quota int64, format func(int64) string) string {
+	if quota == 0 {
+		return ""
+	}
+	return " of " + format(quota)
+}
+
+// formatCount returns number of items as is.
+func formatCount(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+// formatBytes returns human readable size.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
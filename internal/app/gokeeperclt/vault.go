@@ -0,0 +1,169 @@
+package gokeeperclt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/encryption"
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+)
+
+// legacyNonce is the nonce items were encrypted with
+// before vault keys were introduced.
+var legacyNonce = []byte("123412341234")
+
+// ErrWrongMasterPassword is raised when vault key
+// can't be unwrapped with provided password.
+var ErrWrongMasterPassword = errors.New("unable to unlock vault: wrong master password")
+
+// newVaultKey generates random vault key of the user and wraps it
+// with the key derived from user's master password.
+func (c *Client) newVaultKey(password string) ([]byte, *g.VaultKey, error) {
+	vaultKey, err := encryption.NewKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	wrapped, err := wrapVaultKey(password, vaultKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vaultKey, wrapped, nil
+}
+
+// wrapVaultKey encrypts vault key with the key derived
+// from user's master password and freshly generated salt.
+func wrapVaultKey(password string, vaultKey []byte) (*g.VaultKey, error) {
+	salt, err := encryption.NewSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	params := encryption.DefaultKDFParams
+	kek, err := encryption.NewSealer(encryption.DeriveKey(password, salt, params))
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := kek.Seal(vaultKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &g.VaultKey{
+		WrappedKey: wrapped,
+		KdfSalt:    salt,
+		KdfTime:    params.Time,
+		KdfMemory:  params.Memory,
+		KdfThreads: uint32(params.Threads),
+	}, nil
+}
+
+// unwrapVaultKey derives key-encryption key from user's master password
+// and decrypts vault key with it. Key derivation parameters come from server,
+// so they are checked to be within bounds before deriving.
+func unwrapVaultKey(password string, key *g.VaultKey) ([]byte, error) {
+	params, err := encryption.NewKDFParams(key.KdfTime, key.KdfMemory, key.KdfThreads)
+	if err != nil {
+		return nil, err
+	}
+	kek, err := encryption.NewSealer(encryption.DeriveKey(password, key.KdfSalt, params))
+	if err != nil {
+		return nil, err
+	}
+	vaultKey, err := kek.Open(key.WrappedKey)
+	if err != nil {
+		return nil, ErrWrongMasterPassword
+	}
+	return vaultKey, nil
+}
+
+// unlockVault gets wrapped vault key of the user from server and unwraps it.
+// If user has no vault key yet, new one is generated and sent to server.
+func (c *Client) unlockVault(ctx context.Context) error {
+	resp, err := c.rpc.GetVaultKey(ctx, &g.GetVaultKeyRequest{})
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+		return err
+	}
+	if resp.Error != "" {
+		c.logger.
+			Error().
+			Caller().
+			Msg(resp.Error)
+		return errors.New(resp.Error)
+	}
+
+	var vaultKey []byte
+	if resp.VaultKey == nil || len(resp.VaultKey.WrappedKey) == 0 {
+		c.logger.Debug().Msg("user has no vault key, generating new one")
+		var wrapped *g.VaultKey
+		vaultKey, wrapped, err = c.newVaultKey(c.user.Password)
+		if err != nil {
+			c.logger.Err(err).Caller().Msg("unable to generate vault key")
+			return err
+		}
+		setResp, err := c.rpc.SetVaultKey(ctx, &g.SetVaultKeyRequest{VaultKey: wrapped})
+		if err != nil {
+			c.logger.Err(err).Caller().Msg("unable to perform rpc request")
+			return err
+		}
+		if setResp.Error != "" {
+			c.logger.
+				Error().
+				Caller().
+				Msg(setResp.Error)
+			return errors.New(setResp.Error)
+		}
+		c.vaultKey = wrapped
+	} else {
+		c.logger.Debug().Msg("unwrapping vault key")
+		vaultKey, err = unwrapVaultKey(c.user.Password, resp.VaultKey)
+		if err != nil {
+			c.logger.Err(err).Caller().Msg("unable to unwrap vault key")
+			return err
+		}
+		c.vaultKey = resp.VaultKey
+	}
+
+	return c.useVaultKey(vaultKey)
+}
+
+// useVaultKey initializes sealer, which encrypts and decrypts user's items.
+func (c *Client) useVaultKey(vaultKey []byte) error {
+	vault, err := encryption.NewSealer(vaultKey)
+	if err != nil {
+		c.logger.Err(err).Caller().Msg("unable to initialize vault")
+		return err
+	}
+	c.vault = vault
+	return nil
+}
+
+// seal encrypts sensitive item data with user's vault key.
+func (c *Client) seal(plaintext []byte) ([]byte, error) {
+	return c.vault.Seal(plaintext)
+}
+
+// open decrypts sensitive item data with user's vault key,
+// falling back to static legacy key for items encrypted before
+// vault keys were introduced.
+func (c *Client) open(ciphertext []byte) ([]byte, error) {
+	plaintext, err := c.vault.Open(ciphertext)
+	if err == nil || c.legacy == nil {
+		return plaintext, err
+	}
+	if plaintext, legacyErr := c.legacy.Open(nil, legacyNonce, ciphertext, nil); legacyErr == nil {
+		return plaintext, nil
+	}
+	return nil, err
+}
+
+// newLegacyCipher initializes cipher with static key from configuration.
+func newLegacyCipher(key string) (cipher.AEAD, error) {
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
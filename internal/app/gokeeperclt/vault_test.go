@@ -0,0 +1,46 @@
+package gokeeperclt
+
+import (
+	"testing"
+
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/encryption"
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUnwrapVaultKey(t *testing.T) {
+	vaultKey, err := encryption.NewKey()
+	require.NoError(t, err)
+	wrapped, err := wrapVaultKey("master", vaultKey)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		unwrapped, err := unwrapVaultKey("master", wrapped)
+		require.NoError(t, err)
+		require.Equal(t, vaultKey, unwrapped)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		_, err := unwrapVaultKey("wrong", wrapped)
+		require.ErrorIs(t, err, ErrWrongMasterPassword)
+	})
+
+	tt := []struct {
+		name   string
+		modify func(key *g.VaultKey)
+	}{
+		{"zero time", func(key *g.VaultKey) { key.KdfTime = 0 }},
+		{"zero threads", func(key *g.VaultKey) { key.KdfThreads = 0 }},
+		{"truncated threads", func(key *g.VaultKey) { key.KdfThreads = 260 }},
+		{"unbounded memory", func(key *g.VaultKey) { key.KdfMemory = 1 << 31 }},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			key := proto.Clone(wrapped).(*g.VaultKey)
+			tc.modify(key)
+			_, err := unwrapVaultKey("master", key)
+			require.ErrorIs(t, err, encryption.ErrInvalidKDFParams)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package gokeepersrv
+
+import (
+	"context"
+	"time"
+)
+
+// blobSweepInterval is how often uploaded blobs,
+// which weren't attached to items in time, are removed.
+const blobSweepInterval = time.Hour
+
+// watchBlobs removes expired blobs until ctx is done.
+func (s *Server) watchBlobs(ctx context.Context) {
+	ticker := time.NewTicker(blobSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		expired, err := s.rpc.ExpireBlobs(ctx)
+		if err == nil {
+			if expired > 0 {
+				s.logger.Info().Int64("blobs", expired).Msg("expired blobs were removed")
+			}
+		} else if ctx.Err() == nil {
+			s.logger.Warn().Err(err).Msg("unable to remove expired blobs")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,58 +2,135 @@
 package gokeepersrv
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
-	"os"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/serjyuriev/yandex-diploma-2/internal/app/handlers"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/metrics"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/certs"
 	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/logging"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/tracing"
 	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
 )
 
+const (
+	// defaultDrainTimeout is how long in-flight requests are awaited
+	// on shutdown when drain timeout isn't configured.
+	defaultDrainTimeout = 30 * time.Second
+	// closeTimeout limits closing the data layer and flushing traces on shutdown,
+	// which are done after draining, when drain timeout may be already exceeded.
+	closeTimeout = 10 * time.Second
+)
+
+// ErrServerClosed is returned by Start when server was shut down before it started serving.
+var ErrServerClosed = errors.New("server is closed")
+
 // Server holds app's server-side related objects.
 type Server struct {
 	cfg    config.ServerConfig
 	rpc    *handlers.RPC
 	logger zerolog.Logger
+
+	health       *health.Server
+	closeData    func(context.Context) error
+	flushTracing func(context.Context) error
+	closeLog     func() error
+
+	mu          sync.Mutex
+	srv         *grpc.Server
+	metricsSrv  *http.Server
+	stopWatches context.CancelFunc
+	closed      bool
 }
 
-// NewServer initializes app's server.
-func NewServer() (*Server, error) {
-	output := zerolog.ConsoleWriter{
-		Out:        os.Stdout,
-		TimeFormat: "02-01-2006 15:04:05 MST",
+// NewServer initializes app's server with provided configuration.
+func NewServer(cfg config.ServerConfig) (*Server, error) {
+	logger, closeLog, err := logging.New(cfg.Log, cfg.IsDebug)
+	if err != nil {
+		return nil, err
 	}
-	logger := zerolog.New(output).With().Timestamp().Logger()
 
 	logger.Debug().Msg("initializing go-keeper server")
 
-	logger.Debug().Msg("getting app's configuration")
-	cfg := config.GetServerConfig()
+	logger.Debug().Msg("initializing tracing")
+	flushTracing, err := tracing.Setup(context.Background(), cfg.Tracing, "gokeeper-server", logger)
+	if err != nil {
+		closeLog()
+		return nil, err
+	}
 
 	logger.Debug().Msg("initializing gRPC layer")
-	rpc, err := handlers.MakeRPC(logger)
+	rpc, err := handlers.MakeRPC(cfg, logger)
 	if err != nil {
 		logger.
 			Err(err).
 			Caller().
 			Msg("unable to initialize gRPC layer")
+		flushTracing(context.Background())
+		closeLog()
 		return nil, err
 	}
 
 	logger.Info().Msg("go-keeper server was successfully initialized")
+	// server isn't ready until database is reachable
+	hs := health.NewServer()
+	hs.SetServingStatus(ReadinessService, healthpb.HealthCheckResponse_NOT_SERVING)
+
 	return &Server{
-		cfg:    cfg,
-		rpc:    rpc,
-		logger: logger,
+		cfg:          cfg,
+		rpc:          rpc,
+		logger:       logger,
+		health:       hs,
+		closeData:    rpc.Close,
+		flushTracing: flushTracing,
+		closeLog:     closeLog,
 	}, nil
 }
 
-// Start launches app's server.
+// Run starts the server and gracefully shuts it down once ctx is done,
+// awaiting in-flight requests at most for configured drain timeout.
+func (s *Server) Run(ctx context.Context) error {
+	started := make(chan error, 1)
+	go func() {
+		started <- s.Start()
+	}()
+
+	select {
+	case err := <-started:
+		s.Close()
+		return err
+	case <-ctx.Done():
+	}
+
+	timeout := s.cfg.Shutdown.DrainTimeout
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+	s.logger.Info().Dur("drain_timeout", timeout).Msg("shutting down go-keeper server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := s.Shutdown(shutdownCtx)
+	if startErr := <-started; startErr != nil && !errors.Is(startErr, ErrServerClosed) && err == nil {
+		err = startErr
+	}
+	return err
+}
+
+// Start launches app's server. It blocks until the server is shut down.
 func (s *Server) Start() error {
 	fullAddress := fmt.Sprintf("%s:%d", s.cfg.Listen.Address, s.cfg.Listen.Port)
 	listen, err := net.Listen("tcp", fullAddress)
@@ -64,12 +141,62 @@ func (s *Server) Start() error {
 			Msgf("unable to listen on %s", fullAddress)
 		return err
 	}
-	srv := grpc.NewServer(grpc.KeepaliveParams(
-		keepalive.ServerParameters{
-			MaxConnectionIdle: 5 * time.Minute,
-		},
-	))
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveParams(
+			keepalive.ServerParameters{
+				MaxConnectionIdle: 5 * time.Minute,
+			},
+		),
+		grpc.ChainUnaryInterceptor(
+			otelgrpc.UnaryServerInterceptor(),
+			s.rpc.RequestIDInterceptor,
+			metrics.UnaryServerInterceptor,
+			s.rpc.ErrorInterceptor,
+			s.rpc.AuthInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			otelgrpc.StreamServerInterceptor(),
+			s.rpc.RequestIDStreamInterceptor,
+			metrics.StreamServerInterceptor,
+			s.rpc.ErrorStreamInterceptor,
+			s.rpc.AuthStreamInterceptor,
+		),
+	}
+	creds, err := s.transportCredentials()
+	if err != nil {
+		listen.Close()
+		return err
+	}
+	if creds != nil {
+		opts = append(opts, grpc.Creds(creds))
+	}
+	srv := grpc.NewServer(opts...)
 	g.RegisterGokeeperServer(srv, s.rpc)
+	healthpb.RegisterHealthServer(srv, s.health)
+
+	metricsSrv, err := s.startMetrics()
+	if err != nil {
+		listen.Close()
+		return err
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		listen.Close()
+		if metricsSrv != nil {
+			metricsSrv.Close()
+		}
+		return ErrServerClosed
+	}
+	s.srv = srv
+	s.metricsSrv = metricsSrv
+	watchCtx, stopWatches := context.WithCancel(context.Background())
+	s.stopWatches = stopWatches
+	s.mu.Unlock()
+	go s.watchDatabase(watchCtx)
+	go s.watchStats(watchCtx)
+	go s.watchBlobs(watchCtx)
 
 	s.logger.Info().Msgf("go-keeper server listening on tcp %s", fullAddress)
 	if err := srv.Serve(listen); err != nil {
@@ -81,3 +208,108 @@ func (s *Server) Start() error {
 	}
 	return nil
 }
+
+// transportCredentials returns TLS credentials configured for the server,
+// or nil if TLS is disabled.
+func (s *Server) transportCredentials() (credentials.TransportCredentials, error) {
+	if s.cfg.TLS.CertFile == "" {
+		s.logger.Warn().Msg("TLS is disabled, credentials will be sent in plaintext")
+		return nil, nil
+	}
+
+	s.logger.Debug().Str("cert", s.cfg.TLS.CertFile).Msg("loading TLS certificate")
+	reloader, err := certs.NewReloader(
+		s.cfg.TLS.CertFile,
+		s.cfg.TLS.KeyFile,
+		s.cfg.TLS.ClientCAFile,
+		s.cfg.TLS.ReloadInterval,
+		s.logger,
+	)
+	if err != nil {
+		s.logger.
+			Err(err).
+			Caller().
+			Msg("unable to load TLS certificate")
+		return nil, err
+	}
+	tlsCfg, err := reloader.ServerConfig(s.cfg.TLS.RequireClientCert)
+	if err != nil {
+		return nil, err
+	}
+	if s.cfg.TLS.RequireClientCert {
+		s.logger.Info().Msg("mutual TLS is enabled")
+	}
+	return credentials.NewTLS(tlsCfg), nil
+}
+
+// Shutdown gracefully stops the server: it stops accepting new connections
+// and awaits in-flight requests until ctx is done, then closes remaining
+// connections and the data layer, which is given closeTimeout of its own.
+// Repeated calls do nothing.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	srv, metricsSrv, stopWatches, closed := s.srv, s.metricsSrv, s.stopWatches, s.closed
+	s.closed = true
+	s.mu.Unlock()
+	if closed {
+		return nil
+	}
+
+	// probes see server going away while in-flight requests are drained
+	s.health.Shutdown()
+	if stopWatches != nil {
+		stopWatches()
+	}
+
+	if srv != nil {
+		s.logger.Debug().Msg("awaiting in-flight requests")
+		stopped := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			s.logger.Warn().Msg("drain timeout exceeded, closing remaining connections")
+			srv.Stop()
+			<-stopped
+		}
+	}
+
+	if metricsSrv != nil {
+		// metrics are served until the very end to cover drained requests
+		if err := metricsSrv.Shutdown(ctx); err != nil {
+			metricsSrv.Close()
+		}
+	}
+
+	// ctx may be already done after draining, yet data layer is still closed gracefully
+	closeCtx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+	if err := s.closeData(closeCtx); err != nil {
+		s.logger.
+			Err(err).
+			Caller().
+			Msg("unable to close gRPC layer")
+		return err
+	}
+	if s.flushTracing != nil {
+		if err := s.flushTracing(closeCtx); err != nil {
+			// losing spans must not fail the shutdown
+			s.logger.Warn().Err(err).Msg("unable to flush traces")
+		}
+	}
+	s.logger.Info().Msg("go-keeper server was shut down")
+	if s.closeLog != nil {
+		s.closeLog()
+	}
+	return nil
+}
+
+// Close immediately stops the server, closing all connections and the data layer.
+func (s *Server) Close() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return s.Shutdown(ctx)
+}
@@ -0,0 +1,72 @@
+package gokeepersrv
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestShutdown(t *testing.T) {
+	t.Run("drain timeout exceeded", func(t *testing.T) {
+		listen, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		hs := health.NewServer()
+		srv := grpc.NewServer()
+		healthpb.RegisterHealthServer(srv, hs)
+		go srv.Serve(listen)
+
+		var closeErr, flushErr error
+		s := &Server{
+			logger: zerolog.Nop(),
+			health: hs,
+			srv:    srv,
+			closeData: func(ctx context.Context) error {
+				closeErr = ctx.Err()
+				return closeErr
+			},
+			flushTracing: func(ctx context.Context) error {
+				flushErr = ctx.Err()
+				return flushErr
+			},
+		}
+
+		// watch stream stays open until server is stopped, so it is never drained
+		conn, err := grpc.Dial(listen.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		defer conn.Close()
+		stream, err := healthpb.NewHealthClient(conn).Watch(context.Background(), &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		require.NoError(t, s.Shutdown(ctx))
+		require.Error(t, ctx.Err())
+		require.NoError(t, closeErr)
+		require.NoError(t, flushErr)
+	})
+
+	t.Run("repeated", func(t *testing.T) {
+		var closed int
+		s := &Server{
+			logger: zerolog.Nop(),
+			health: health.NewServer(),
+			closeData: func(context.Context) error {
+				closed++
+				return nil
+			},
+		}
+		require.NoError(t, s.Close())
+		require.NoError(t, s.Close())
+		require.Equal(t, 1, closed)
+	})
+}
@@ -0,0 +1,233 @@
+package gokeepersrv
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/certs"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// LivenessService is the health service name reporting that server is up.
+	LivenessService = ""
+	// ReadinessService is the health service name reporting
+	// that server is able to serve requests, which requires database.
+	ReadinessService = "proto.server.Gokeeper"
+)
+
+const (
+	// defaultPingInterval is how often database is pinged
+	// when ping interval isn't configured.
+	defaultPingInterval = 10 * time.Second
+	// defaultPingTimeout limits database ping when ping timeout isn't configured.
+	defaultPingTimeout = 2 * time.Second
+	// healthCheckTimeout limits health check made by healthcheck command.
+	healthCheckTimeout = 5 * time.Second
+)
+
+var (
+	// ErrNotServing is returned by CheckHealth when server reports that it isn't serving.
+	ErrNotServing = errors.New("server is not serving")
+	// ErrNoProbeCert is returned by CheckHealth when server requires client
+	// certificate, but there is none probe could present.
+	ErrNoProbeCert = errors.New("server requires client certificate, set health.probe_cert_file and health.probe_key_file")
+	// ErrUntrustedServer is returned by CheckHealth when server
+	// presents certificate probe can't verify.
+	ErrUntrustedServer = errors.New("server certificate is not trusted")
+)
+
+// watchDatabase pings database until ctx is done,
+// reporting readiness according to ping results.
+func (s *Server) watchDatabase(ctx context.Context) {
+	interval := s.cfg.Health.PingInterval
+	if interval <= 0 {
+		interval = defaultPingInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ready := false
+	for {
+		ready = s.checkDatabase(ctx, ready)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkDatabase pings database once and updates readiness status,
+// logging its changes. It returns whether server is ready.
+func (s *Server) checkDatabase(ctx context.Context, wasReady bool) bool {
+	timeout := s.cfg.Health.PingTimeout
+	if timeout <= 0 {
+		timeout = defaultPingTimeout
+	}
+	pingCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := s.rpc.Ping(pingCtx)
+	if ctx.Err() != nil {
+		// server is shutting down, status is already reported
+		return wasReady
+	}
+	if err != nil {
+		if wasReady {
+			s.logger.Warn().Err(err).Msg("database became unreachable, server is not ready")
+		}
+		s.health.SetServingStatus(ReadinessService, healthpb.HealthCheckResponse_NOT_SERVING)
+		return false
+	}
+	if !wasReady {
+		s.logger.Info().Msg("database is reachable, server is ready")
+	}
+	s.health.SetServingStatus(ReadinessService, healthpb.HealthCheckResponse_SERVING)
+	return true
+}
+
+// CheckHealth asks server listening according to configuration
+// whether provided health service is serving.
+func CheckHealth(cfg config.ServerConfig, service string) error {
+	host := cfg.Listen.Address
+	switch host {
+	case "", "0.0.0.0", "::":
+		host = "localhost"
+	}
+
+	creds := insecure.NewCredentials()
+	if cfg.TLS.CertFile != "" {
+		tlsCfg, err := probeTLSConfig(cfg)
+		if err != nil {
+			return err
+		}
+		creds = credentials.NewTLS(tlsCfg)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+	conn, err := grpc.DialContext(
+		ctx,
+		fmt.Sprintf("%s:%d", host, cfg.Listen.Port),
+		grpc.WithTransportCredentials(creds),
+		grpc.WithBlock(),
+		// report why connection failed, e.g. untrusted server, not just timeout
+		grpc.WithReturnConnectionError(),
+	)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return err
+	}
+	if res.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("%w: %s", ErrNotServing, res.Status)
+	}
+	return nil
+}
+
+// probeTLSConfig returns TLS configuration of health probe. Probe presents
+// configured probe certificate, or server one if it allows client auth.
+// Server certificate is verified against probe CAs, or compared with
+// the configured one when there are none.
+func probeTLSConfig(cfg config.ServerConfig) (*tls.Config, error) {
+	serverCert, err := tls.LoadX509KeyPair(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load server certificate: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(serverCert.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse server certificate: %w", err)
+	}
+	var roots *x509.CertPool
+	if cfg.Health.ProbeCAFile != "" {
+		if roots, err = certs.LoadCertPool(cfg.Health.ProbeCAFile); err != nil {
+			return nil, fmt.Errorf("unable to load probe CAs: %w", err)
+		}
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// probe connects to the server it runs next to, so server certificate
+		// isn't checked against host name, only verified by verifyServer
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyServer(rawCerts, leaf, roots)
+		},
+	}
+	switch {
+	case cfg.Health.ProbeCertFile != "":
+		probeCert, err := tls.LoadX509KeyPair(cfg.Health.ProbeCertFile, cfg.Health.ProbeKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load probe certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{probeCert}
+	case allowsClientAuth(leaf):
+		tlsCfg.Certificates = []tls.Certificate{serverCert}
+	case cfg.TLS.RequireClientCert:
+		return nil, ErrNoProbeCert
+	}
+	return tlsCfg, nil
+}
+
+// verifyServer checks that certificate chain presented by server is issued
+// by one of roots or, if there are no roots, that it is the configured one.
+func verifyServer(rawCerts [][]byte, configured *x509.Certificate, roots *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return ErrUntrustedServer
+	}
+	if roots == nil {
+		if !bytes.Equal(rawCerts[0], configured.Raw) {
+			return ErrUntrustedServer
+		}
+		return nil
+	}
+
+	chain := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrUntrustedServer, err)
+		}
+		chain = append(chain, cert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := chain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUntrustedServer, err)
+	}
+	return nil
+}
+
+// allowsClientAuth reports whether certificate can be used for client auth.
+func allowsClientAuth(cert *x509.Certificate) bool {
+	if len(cert.ExtKeyUsage) == 0 {
+		return true
+	}
+	for _, usage := range cert.ExtKeyUsage {
+		if usage == x509.ExtKeyUsageClientAuth || usage == x509.ExtKeyUsageAny {
+			return true
+		}
+	}
+	return false
+}
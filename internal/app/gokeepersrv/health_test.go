@@ -0,0 +1,185 @@
+package gokeepersrv
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestCheckHealth(t *testing.T) {
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	hs := health.NewServer()
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	go srv.Serve(listen)
+	defer srv.Stop()
+
+	cfg := config.ServerConfig{}
+	cfg.Listen.Address = "127.0.0.1"
+	cfg.Listen.Port = listen.Addr().(*net.TCPAddr).Port
+
+	hs.SetServingStatus(ReadinessService, healthpb.HealthCheckResponse_NOT_SERVING)
+	require.NoError(t, CheckHealth(cfg, LivenessService))
+	require.ErrorIs(t, CheckHealth(cfg, ReadinessService), ErrNotServing)
+
+	hs.SetServingStatus(ReadinessService, healthpb.HealthCheckResponse_SERVING)
+	require.NoError(t, CheckHealth(cfg, ReadinessService))
+
+	hs.Shutdown()
+	require.ErrorIs(t, CheckHealth(cfg, LivenessService), ErrNotServing)
+}
+
+// testCA issues certificates for tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newTestCA(t *testing.T, dir string, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	file := filepath.Join(dir, name+".pem")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	return &testCA{cert: cert, key: key, file: file}
+}
+
+// issue writes certificate with provided usages and its key to dir,
+// returning names of the files.
+func (ca *testCA) issue(t *testing.T, dir string, name string, usage ...x509.ExtKeyUsage) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"gokeeper.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  usage,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certFile, keyFile := filepath.Join(dir, name+".pem"), filepath.Join(dir, name+"-key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+// serveTLSHealth starts serving health service with certificate
+// from cfg, requiring client certificates issued by clientCA.
+func serveTLSHealth(t *testing.T, cfg *config.ServerConfig, clientCA *testCA) {
+	cert, err := tls.LoadX509KeyPair(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCA.cert)
+	creds := credentials.NewTLS(&tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})
+
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer(grpc.Creds(creds))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(listen)
+	t.Cleanup(srv.Stop)
+
+	cfg.Listen.Address = "127.0.0.1"
+	cfg.Listen.Port = listen.Addr().(*net.TCPAddr).Port
+	cfg.TLS.RequireClientCert = true
+}
+
+func TestCheckHealthTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, dir, "ca")
+	otherCA := newTestCA(t, dir, "other-ca")
+	serverCert, serverKey := ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+	dualCert, dualKey := ca.issue(t, dir, "dual", x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth)
+	probeCert, probeKey := ca.issue(t, dir, "probe", x509.ExtKeyUsageClientAuth)
+
+	t.Run("probe certificate", func(t *testing.T) {
+		t.Parallel()
+		var cfg config.ServerConfig
+		cfg.TLS.CertFile, cfg.TLS.KeyFile = serverCert, serverKey
+		serveTLSHealth(t, &cfg, ca)
+
+		cfg.Health.ProbeCertFile, cfg.Health.ProbeKeyFile = probeCert, probeKey
+		cfg.Health.ProbeCAFile = ca.file
+		require.NoError(t, CheckHealth(cfg, LivenessService))
+	})
+
+	t.Run("server certificate allows client auth", func(t *testing.T) {
+		t.Parallel()
+		var cfg config.ServerConfig
+		cfg.TLS.CertFile, cfg.TLS.KeyFile = dualCert, dualKey
+		serveTLSHealth(t, &cfg, ca)
+
+		require.NoError(t, CheckHealth(cfg, LivenessService))
+	})
+
+	t.Run("no client certificate", func(t *testing.T) {
+		t.Parallel()
+		var cfg config.ServerConfig
+		cfg.TLS.CertFile, cfg.TLS.KeyFile = serverCert, serverKey
+		serveTLSHealth(t, &cfg, ca)
+
+		require.ErrorIs(t, CheckHealth(cfg, LivenessService), ErrNoProbeCert)
+	})
+
+	t.Run("server issued by other CA", func(t *testing.T) {
+		t.Parallel()
+		var cfg config.ServerConfig
+		cfg.TLS.CertFile, cfg.TLS.KeyFile = dualCert, dualKey
+		serveTLSHealth(t, &cfg, ca)
+
+		cfg.Health.ProbeCAFile = otherCA.file
+		require.ErrorContains(t, CheckHealth(cfg, LivenessService), ErrUntrustedServer.Error())
+	})
+
+	t.Run("server presents other certificate", func(t *testing.T) {
+		t.Parallel()
+		var cfg config.ServerConfig
+		cfg.TLS.CertFile, cfg.TLS.KeyFile = dualCert, dualKey
+		serveTLSHealth(t, &cfg, ca)
+
+		// probe expects certificate server was configured with
+		cfg.TLS.CertFile, cfg.TLS.KeyFile = probeCert, probeKey
+		require.ErrorContains(t, CheckHealth(cfg, LivenessService), ErrUntrustedServer.Error())
+	})
+}
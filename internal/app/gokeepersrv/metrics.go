@@ -0,0 +1,80 @@
+package gokeepersrv
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/metrics"
+)
+
+const (
+	// defaultStatsInterval is how often users and items are counted
+	// when stats interval isn't configured.
+	defaultStatsInterval = time.Minute
+	// metricsReadHeaderTimeout limits reading of metrics request headers.
+	metricsReadHeaderTimeout = 5 * time.Second
+)
+
+// startMetrics starts serving /metrics HTTP endpoint on configured
+// address, returning nil server if the endpoint is disabled.
+func (s *Server) startMetrics() (*http.Server, error) {
+	if s.cfg.Metrics.Address == "" {
+		s.logger.Debug().Msg("metrics endpoint is disabled")
+		return nil, nil
+	}
+
+	listen, err := net.Listen("tcp", s.cfg.Metrics.Address)
+	if err != nil {
+		s.logger.
+			Err(err).
+			Caller().
+			Msgf("unable to listen on %s", s.cfg.Metrics.Address)
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: metricsReadHeaderTimeout,
+	}
+
+	s.logger.Info().Msgf("metrics endpoint listening on http %s", s.cfg.Metrics.Address)
+	go func() {
+		if err := srv.Serve(listen); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.
+				Err(err).
+				Caller().
+				Msg("metrics endpoint stopped unexpectedly")
+		}
+	}()
+	return srv, nil
+}
+
+// watchStats counts users and items until ctx is done, updating their gauges.
+func (s *Server) watchStats(ctx context.Context) {
+	interval := s.cfg.Metrics.StatsInterval
+	if interval <= 0 {
+		interval = defaultStatsInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		stats, err := s.rpc.ReadStats(ctx)
+		if err == nil {
+			metrics.Users.Set(float64(stats.Users))
+			metrics.Items.Set(float64(stats.Items))
+		} else if ctx.Err() == nil {
+			s.logger.Warn().Err(err).Msg("unable to update storage stats")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+)
+
+// ChangePassword replaces user's password and vault key wrapped with it,
+// logging user out of all sessions except the one the request was made within.
+func (r *RPC) ChangePassword(ctx context.Context, in *g.ChangePasswordRequest) (*g.ChangePasswordResponse, error) {
+	if in == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.ChangePasswordResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+	if err := checkVaultKey(in.VaultKey); err != nil {
+		r.log(ctx).Err(err).Msg("vault key has invalid key derivation parameters")
+		return &g.ChangePasswordResponse{Error: err.Error()}, err
+	}
+
+	claims, err := claimsFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.ChangePasswordResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", claims.UserID.String()).Msg("received password change request")
+	res := new(g.ChangePasswordResponse)
+
+	r.log(ctx).Debug().Str("user", claims.UserID.String()).Msg("passing user's credentials to service layer")
+	if err := r.svc.ChangePassword(
+		ctx,
+		claims.UserID,
+		claims.SessionID,
+		in.OldPassword,
+		in.NewPassword,
+		vaultKeyToModel(in.VaultKey),
+	); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", claims.UserID.String()).
+			Msg("unable to change user's password")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Info().Str("user", claims.UserID.String()).Msg("user's password was successfully changed")
+	res.Error = ""
+	return res, nil
+}
+
+// DeleteAccount removes user's account along with all user's data.
+func (r *RPC) DeleteAccount(ctx context.Context, in *g.DeleteAccountRequest) (*g.DeleteAccountResponse, error) {
+	if in == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.DeleteAccountResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.DeleteAccountResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("received account deletion request")
+	res := new(g.DeleteAccountResponse)
+
+	if err := r.svc.DeleteAccount(ctx, userID, in.Password); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to delete user's account")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("user's account was successfully deleted")
+	res.Error = ""
+	return res, nil
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/auth"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/mocks"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/service"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/encryption"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangePassword(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	ms := mocks.NewMockService(ctrl)
+	rpc := &RPC{
+		logger: logger,
+		svc:    ms,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		uid, sid := uuid.New(), uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid, SessionID: sid})
+		change := ms.EXPECT().
+			ChangePassword(
+				ctx,
+				gomock.Eq(uid),
+				gomock.Eq(sid),
+				"oldpwd",
+				"newpwd",
+				gomock.Eq(&models.VaultKey{
+					WrappedKey: []byte("wrapped"),
+					KDFSalt:    []byte("salt"),
+					KDFTime:    3,
+					KDFMemory:  65536,
+					KDFThreads: 4,
+				}),
+			).
+			Return(nil)
+		gomock.InOrder(change)
+
+		out, err := rpc.ChangePassword(ctx, &g.ChangePasswordRequest{
+			OldPassword: "oldpwd",
+			NewPassword: "newpwd",
+			VaultKey: &g.VaultKey{
+				WrappedKey: []byte("wrapped"),
+				KdfSalt:    []byte("salt"),
+				KdfTime:    3,
+				KdfMemory:  65536,
+				KdfThreads: 4,
+			},
+		})
+		require.NoError(t, err)
+		require.Empty(t, out.Error)
+	})
+
+	t.Run("invalid kdf params", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		_, err := rpc.ChangePassword(ctx, &g.ChangePasswordRequest{
+			OldPassword: "oldpwd",
+			NewPassword: "newpwd",
+			VaultKey:    &g.VaultKey{WrappedKey: []byte("wrapped"), KdfSalt: []byte("salt")},
+		})
+		require.ErrorIs(t, err, encryption.ErrInvalidKDFParams)
+	})
+
+	t.Run("vault key required", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		change := ms.EXPECT().
+			ChangePassword(ctx, gomock.Eq(uid), gomock.Any(), "oldpwd", "newpwd", nil).
+			Return(service.ErrVaultKeyRequired)
+		gomock.InOrder(change)
+
+		out, err := rpc.ChangePassword(ctx, &g.ChangePasswordRequest{OldPassword: "oldpwd", NewPassword: "newpwd"})
+		require.ErrorIs(t, err, service.ErrVaultKeyRequired)
+		require.Equal(t, service.ErrVaultKeyRequired.Error(), out.Error)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		_, err := rpc.ChangePassword(context.Background(), &g.ChangePasswordRequest{})
+		require.ErrorIs(t, err, ErrUnauthenticated)
+	})
+}
+
+func TestDeleteAccount(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	ms := mocks.NewMockService(ctrl)
+	rpc := &RPC{
+		logger: logger,
+		svc:    ms,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		remove := ms.EXPECT().
+			DeleteAccount(ctx, gomock.Eq(uid), "somepwd").
+			Return(nil)
+		gomock.InOrder(remove)
+
+		out, err := rpc.DeleteAccount(ctx, &g.DeleteAccountRequest{Password: "somepwd"})
+		require.NoError(t, err)
+		require.Empty(t, out.Error)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		remove := ms.EXPECT().
+			DeleteAccount(ctx, gomock.Eq(uid), "wrongpwd").
+			Return(service.ErrInvalidCredentials)
+		gomock.InOrder(remove)
+
+		_, err := rpc.DeleteAccount(ctx, &g.DeleteAccountRequest{Password: "wrongpwd"})
+		require.ErrorIs(t, err, service.ErrInvalidCredentials)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		_, err := rpc.DeleteAccount(context.Background(), &g.DeleteAccountRequest{})
+		require.ErrorIs(t, err, ErrUnauthenticated)
+	})
+}
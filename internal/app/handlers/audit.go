@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultAuditPageSize is the number of audit events returned
+	// when client doesn't request specific page size.
+	defaultAuditPageSize = 50
+	// maxAuditPageSize limits number of audit events returned at once.
+	maxAuditPageSize = 500
+)
+
+// ErrInvalidPageToken is raised when client sends page token,
+// which wasn't issued by the server.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// ListAuditEvents returns the page of the user's audit log, the newest events first.
+func (r *RPC) ListAuditEvents(ctx context.Context, in *g.ListAuditEventsRequest) (*g.ListAuditEventsResponse, error) {
+	if in == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.ListAuditEventsResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.ListAuditEventsResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("received audit log request")
+	res := new(g.ListAuditEventsResponse)
+
+	var after *models.AuditEvent
+	if in.PageToken != "" {
+		after, err = parsePageToken(in.PageToken)
+		if err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("user", userID.String()).
+				Msg("unable to parse page token")
+			res.Error = err.Error()
+			return res, err
+		}
+	}
+
+	size := int64(in.PageSize)
+	switch {
+	case size <= 0:
+		size = defaultAuditPageSize
+	case size > maxAuditPageSize:
+		size = maxAuditPageSize
+	}
+
+	// one more event is requested to find out whether there is the next page
+	events, err := r.svc.ListAuditEvents(ctx, userID, after, size+1)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to list user's audit events")
+		res.Error = err.Error()
+		return res, err
+	}
+	if int64(len(events)) > size {
+		events = events[:size]
+		res.NextPageToken = pageToken(events[len(events)-1])
+	}
+
+	res.Events = make([]*g.AuditEvent, len(events))
+	for i, event := range events {
+		res.Events[i] = &g.AuditEvent{
+			Id:       event.ID.String(),
+			Type:     string(event.Type),
+			Time:     timestamppb.New(event.Time),
+			ClientIp: event.ClientIP,
+			Details:  event.Details,
+		}
+	}
+	res.Error = ""
+	return res, nil
+}
+
+// pageToken returns token of the audit log page, which follows provided event.
+func pageToken(last *models.AuditEvent) string {
+	token := fmt.Sprintf("%d.%s", last.Time.UnixMilli(), last.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(token))
+}
+
+// parsePageToken returns event, which precedes the page with provided token.
+func parsePageToken(token string) (*models.AuditEvent, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	millis, id, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return nil, ErrInvalidPageToken
+	}
+	ms, err := strconv.ParseInt(millis, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	eventID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	return &models.AuditEvent{ID: eventID, Time: time.UnixMilli(ms).UTC()}, nil
+}
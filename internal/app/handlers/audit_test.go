@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/auth"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/mocks"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAuditEvents(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	ms := mocks.NewMockService(ctrl)
+
+	// events returns n events of the user, the newest first
+	events := func(uid uuid.UUID, n int) []*models.AuditEvent {
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		out := make([]*models.AuditEvent, n)
+		for i := range out {
+			out[i] = &models.AuditEvent{
+				ID:       uuid.New(),
+				UserID:   uid,
+				Type:     models.AuditLoginSucceeded,
+				Time:     now.Add(-time.Duration(i) * time.Minute),
+				ClientIP: "192.0.2.10",
+			}
+		}
+		return out
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		all := events(uid, 3)
+
+		first := ms.EXPECT().
+			ListAuditEvents(ctx, gomock.Eq(uid), gomock.Nil(), int64(3)).
+			Return(all, nil)
+		second := ms.EXPECT().
+			ListAuditEvents(ctx, gomock.Eq(uid), gomock.Any(), int64(3)).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, after *models.AuditEvent, _ int64) ([]*models.AuditEvent, error) {
+				require.Equal(t, all[1].ID, after.ID)
+				require.True(t, all[1].Time.Equal(after.Time))
+				return all[2:], nil
+			})
+		gomock.InOrder(first, second)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		out, err := rpc.ListAuditEvents(ctx, &g.ListAuditEventsRequest{PageSize: 2})
+		require.NoError(t, err)
+		require.Len(t, out.Events, 2)
+		require.Equal(t, all[0].ID.String(), out.Events[0].Id)
+		require.Equal(t, string(models.AuditLoginSucceeded), out.Events[0].Type)
+		require.Equal(t, "192.0.2.10", out.Events[0].ClientIp)
+		require.NotEmpty(t, out.NextPageToken)
+
+		out, err = rpc.ListAuditEvents(ctx, &g.ListAuditEventsRequest{PageSize: 2, PageToken: out.NextPageToken})
+		require.NoError(t, err)
+		require.Len(t, out.Events, 1)
+		require.Empty(t, out.NextPageToken)
+	})
+
+	t.Run("default page size", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		list := ms.EXPECT().
+			ListAuditEvents(ctx, gomock.Eq(uid), gomock.Nil(), int64(defaultAuditPageSize+1)).
+			Return(nil, nil)
+		gomock.InOrder(list)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		out, err := rpc.ListAuditEvents(ctx, &g.ListAuditEventsRequest{})
+		require.NoError(t, err)
+		require.Empty(t, out.Events)
+	})
+
+	t.Run("invalid page token", func(t *testing.T) {
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uuid.New()})
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		_, err := rpc.ListAuditEvents(ctx, &g.ListAuditEventsRequest{PageToken: "not a token"})
+		require.ErrorIs(t, err, ErrInvalidPageToken)
+	})
+
+	t.Run("service err", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		list := ms.EXPECT().
+			ListAuditEvents(ctx, gomock.Eq(uid), gomock.Nil(), gomock.Any()).
+			Return(nil, fmt.Errorf("some err"))
+		gomock.InOrder(list)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		_, err := rpc.ListAuditEvents(ctx, &g.ListAuditEventsRequest{})
+		require.Error(t, err)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		_, err := rpc.ListAuditEvents(context.Background(), &g.ListAuditEventsRequest{})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"context"
+	"hash/crc32"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/repository"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+)
+
+// downloadChunkSize is the size of chunks blobs are sent to clients in.
+const downloadChunkSize = 512 << 10
+
+// castagnoli is the CRC-32C table chunks of the blobs are checksummed with.
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// UploadBinary receives encrypted binary content of the user's item
+// as a stream of chunks. Chunks, which were already received, are skipped,
+// so client can resume interrupted upload from the offset returned by GetUploadOffset.
+func (r *RPC) UploadBinary(stream g.Gokeeper_UploadBinaryServer) error {
+	ctx := stream.Context()
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("receiving blob")
+	var blob *models.Blob
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("user", userID.String()).
+				Msg("unable to receive chunk")
+			return err
+		}
+
+		blobID, err := parseID(in.BlobId)
+		if err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("user", userID.String()).
+				Msg("unable to parse blob uuid")
+			return err
+		}
+		if blob != nil && blob.ID != blobID {
+			r.log(ctx).Info().Str("user", userID.String()).Msg("chunks of several blobs were sent in one stream")
+			return ErrNilArgument
+		}
+		if crc32.Checksum(in.Data, castagnoli) != in.Checksum {
+			r.log(ctx).
+				Info().
+				Str("user", userID.String()).
+				Str("blob", in.BlobId).
+				Int64("offset", in.Offset).
+				Msg("chunk doesn't match its checksum")
+			return ErrChecksumMismatch
+		}
+
+		chunk := &models.BlobChunk{
+			UserID:   userID,
+			BlobID:   blobID,
+			Offset:   in.Offset,
+			Data:     in.Data,
+			Checksum: in.Checksum,
+		}
+		if err := r.svc.ReserveBlobChunk(ctx, userID, chunk); err != nil {
+			return err
+		}
+
+		var appended bool
+		blob, appended, err = r.repo.AppendBlobChunk(ctx, chunk, in.Last)
+		if !appended {
+			r.svc.ReleaseBlobChunk(ctx, userID, chunk)
+		}
+		if err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("user", userID.String()).
+				Str("blob", in.BlobId).
+				Msg("unable to save chunk")
+			return err
+		}
+	}
+
+	if blob == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("user", userID.String()).Msg("no chunks were sent")
+		return ErrNilArgument
+	}
+
+	r.log(ctx).
+		Info().
+		Str("user", userID.String()).
+		Str("blob", blob.ID.String()).
+		Int64("size", blob.Size).
+		Bool("complete", blob.Complete).
+		Msg("blob was received")
+	return stream.SendAndClose(&g.UploadBinaryResponse{
+		BlobId: blob.ID.String(),
+		Size:   blob.Size,
+	})
+}
+
+// GetUploadOffset returns size of the already received part of the user's blob.
+// Offset of the blob, which wasn't uploaded at all, is zero.
+func (r *RPC) GetUploadOffset(ctx context.Context, in *g.GetUploadOffsetRequest) (*g.GetUploadOffsetResponse, error) {
+	if in == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.GetUploadOffsetResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.GetUploadOffsetResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Str("blob", in.BlobId).Msg("received upload offset request")
+	res := new(g.GetUploadOffsetResponse)
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("parsing blob uuid")
+	blobID, err := parseID(in.BlobId)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to parse blob uuid")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	blob, err := r.repo.ReadBlob(ctx, userID, blobID)
+	if err != nil {
+		if err == repository.ErrNoBlob {
+			return res, nil
+		}
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to read blob")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	res.Offset = blob.Size
+	res.Complete = blob.Complete
+	res.Error = ""
+	return res, nil
+}
+
+// DownloadBinary sends encrypted binary content of the user's item
+// as a stream of chunks starting at requested offset.
+func (r *RPC) DownloadBinary(in *g.DownloadBinaryRequest, stream g.Gokeeper_DownloadBinaryServer) error {
+	if in == nil {
+		r.logger.Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return ErrNilArgument
+	}
+
+	ctx := stream.Context()
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Str("blob", in.BlobId).Int64("offset", in.Offset).Msg("sending blob")
+	blob, err := r.completeBlob(ctx, userID, in.BlobId)
+	if err != nil {
+		return err
+	}
+	if in.Offset < 0 || in.Offset > blob.Size {
+		r.log(ctx).
+			Err(ErrInvalidOffset).
+			Str("user", userID.String()).
+			Str("blob", in.BlobId).
+			Int64("offset", in.Offset).
+			Int64("size", blob.Size).
+			Msg("requested offset is outside of the blob")
+		return ErrInvalidOffset
+	}
+
+	content, err := r.repo.OpenBlob(ctx, userID, blob.ID, in.Offset)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Str("blob", in.BlobId).
+			Msg("unable to open blob")
+		return err
+	}
+	defer content.Close()
+
+	buf := make([]byte, downloadChunkSize)
+	for offset := in.Offset; ; {
+		n, err := io.ReadFull(content, buf)
+		if n > 0 {
+			if err := stream.Send(&g.DownloadBinaryResponse{
+				Offset:   offset,
+				Data:     buf[:n],
+				Checksum: crc32.Checksum(buf[:n], castagnoli),
+				Size:     blob.Size,
+			}); err != nil {
+				return err
+			}
+			offset += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("user", userID.String()).
+				Str("blob", in.BlobId).
+				Msg("unable to read blob")
+			return err
+		}
+	}
+}
+
+// completeBlob reads the user's blob with provided uuid
+// and checks that it was completely uploaded.
+func (r *RPC) completeBlob(ctx context.Context, userID uuid.UUID, id string) (*models.Blob, error) {
+	blobID, err := parseID(id)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to parse blob uuid")
+		return nil, err
+	}
+
+	blob, err := r.repo.ReadBlob(ctx, userID, blobID)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Str("blob", id).
+			Msg("unable to read blob")
+		return nil, err
+	}
+	if !blob.Complete {
+		r.log(ctx).Info().Str("user", userID.String()).Str("blob", id).Msg("blob wasn't completely uploaded")
+		return nil, ErrIncompleteBlob
+	}
+	return blob, nil
+}
@@ -0,0 +1,389 @@
+package handlers
+
+import (
+	"context"
+	"hash/crc32"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/auth"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/mocks"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/repository"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/service"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// uploadStream feeds prepared chunks to UploadBinary.
+type uploadStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*g.UploadBinaryRequest
+	res    *g.UploadBinaryResponse
+}
+
+func (s *uploadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *uploadStream) Recv() (*g.UploadBinaryRequest, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return chunk, nil
+}
+
+func (s *uploadStream) SendAndClose(res *g.UploadBinaryResponse) error {
+	s.res = res
+	return nil
+}
+
+// downloadStream collects chunks sent by DownloadBinary.
+type downloadStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*g.DownloadBinaryResponse
+}
+
+func (s *downloadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *downloadStream) Send(res *g.DownloadBinaryResponse) error {
+	s.chunks = append(s.chunks, res)
+	return nil
+}
+
+func TestUploadBinary(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+	ms := mocks.NewMockService(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		blobID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		stream := &uploadStream{
+			ctx: ctx,
+			chunks: []*g.UploadBinaryRequest{
+				{BlobId: blobID.String(), Offset: 0, Data: []byte("one"), Checksum: checksum("one")},
+				{BlobId: blobID.String(), Offset: 3, Data: []byte("two"), Checksum: checksum("two"), Last: true},
+			},
+		}
+
+		ms.EXPECT().ReserveBlobChunk(ctx, gomock.Eq(uid), gomock.Any()).Return(nil).Times(2)
+		first := mr.EXPECT().
+			AppendBlobChunk(ctx, gomock.Any(), gomock.Eq(false)).
+			Return(&models.Blob{ID: blobID, Size: 3}, true, nil)
+		second := mr.EXPECT().
+			AppendBlobChunk(ctx, gomock.Any(), gomock.Eq(true)).
+			DoAndReturn(func(_ context.Context, chunk *models.BlobChunk, _ bool) (*models.Blob, bool, error) {
+				require.Equal(t, uid, chunk.UserID)
+				require.Equal(t, int64(3), chunk.Offset)
+				return &models.Blob{ID: blobID, Size: 6, Complete: true}, true, nil
+			})
+		gomock.InOrder(first, second)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		err := rpc.UploadBinary(stream)
+		require.NoError(t, err)
+		require.Equal(t, blobID.String(), stream.res.BlobId)
+		require.Equal(t, int64(6), stream.res.Size)
+	})
+
+	t.Run("already received", func(t *testing.T) {
+		uid := uuid.New()
+		blobID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		stream := &uploadStream{
+			ctx: ctx,
+			chunks: []*g.UploadBinaryRequest{
+				{BlobId: blobID.String(), Offset: 0, Data: []byte("one"), Checksum: checksum("one")},
+			},
+		}
+
+		reserve := ms.EXPECT().ReserveBlobChunk(ctx, gomock.Eq(uid), gomock.Any()).Return(nil)
+		appendChunk := mr.EXPECT().
+			AppendBlobChunk(ctx, gomock.Any(), gomock.Any()).
+			Return(&models.Blob{ID: blobID, Size: 6}, false, nil)
+		release := ms.EXPECT().ReleaseBlobChunk(ctx, gomock.Eq(uid), gomock.Any())
+		gomock.InOrder(reserve, appendChunk, release)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		err := rpc.UploadBinary(stream)
+		require.NoError(t, err)
+		require.Equal(t, int64(6), stream.res.Size)
+	})
+
+	t.Run("quota exceeded", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		stream := &uploadStream{
+			ctx: ctx,
+			chunks: []*g.UploadBinaryRequest{
+				{BlobId: uuid.New().String(), Data: []byte("one"), Checksum: checksum("one")},
+			},
+		}
+
+		ms.EXPECT().
+			ReserveBlobChunk(ctx, gomock.Eq(uid), gomock.Any()).
+			Return(service.ErrResourceExhausted)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		err := rpc.UploadBinary(stream)
+		require.ErrorIs(t, err, service.ErrResourceExhausted)
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uuid.New()})
+		stream := &uploadStream{
+			ctx: ctx,
+			chunks: []*g.UploadBinaryRequest{
+				{BlobId: uuid.New().String(), Data: []byte("one"), Checksum: checksum("two")},
+			},
+		}
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		err := rpc.UploadBinary(stream)
+		require.ErrorIs(t, err, ErrChecksumMismatch)
+	})
+
+	t.Run("offset mismatch", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		stream := &uploadStream{
+			ctx: ctx,
+			chunks: []*g.UploadBinaryRequest{
+				{BlobId: uuid.New().String(), Offset: 3, Data: []byte("one"), Checksum: checksum("one")},
+			},
+		}
+
+		reserve := ms.EXPECT().ReserveBlobChunk(ctx, gomock.Eq(uid), gomock.Any()).Return(nil)
+		appendChunk := mr.EXPECT().
+			AppendBlobChunk(ctx, gomock.Any(), gomock.Any()).
+			Return(nil, false, repository.ErrBlobOffset)
+		release := ms.EXPECT().ReleaseBlobChunk(ctx, gomock.Eq(uid), gomock.Any())
+		gomock.InOrder(reserve, appendChunk, release)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		err := rpc.UploadBinary(stream)
+		require.ErrorIs(t, err, repository.ErrBlobOffset)
+	})
+
+	t.Run("no chunks", func(t *testing.T) {
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uuid.New()})
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		err := rpc.UploadBinary(&uploadStream{ctx: ctx})
+		require.ErrorIs(t, err, ErrNilArgument)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		err := rpc.UploadBinary(&uploadStream{ctx: context.Background()})
+		require.ErrorIs(t, err, ErrUnauthenticated)
+	})
+}
+
+func TestGetUploadOffset(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		blobID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		read := mr.EXPECT().
+			ReadBlob(ctx, gomock.Eq(uid), gomock.Eq(blobID)).
+			Return(&models.Blob{ID: blobID, Size: 42}, nil)
+		gomock.InOrder(read)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		out, err := rpc.GetUploadOffset(ctx, &g.GetUploadOffsetRequest{BlobId: blobID.String()})
+		require.NoError(t, err)
+		require.Equal(t, int64(42), out.Offset)
+		require.False(t, out.Complete)
+	})
+
+	t.Run("new blob", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		read := mr.EXPECT().
+			ReadBlob(ctx, gomock.Eq(uid), gomock.Any()).
+			Return(nil, repository.ErrNoBlob)
+		gomock.InOrder(read)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		out, err := rpc.GetUploadOffset(ctx, &g.GetUploadOffsetRequest{BlobId: uuid.New().String()})
+		require.NoError(t, err)
+		require.Equal(t, int64(0), out.Offset)
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		_, err := rpc.GetUploadOffset(context.Background(), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestDownloadBinary(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		blobID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		stream := &downloadStream{ctx: ctx}
+
+		read := mr.EXPECT().
+			ReadBlob(ctx, gomock.Eq(uid), gomock.Eq(blobID)).
+			Return(&models.Blob{ID: blobID, Size: 6, Complete: true}, nil)
+		openBlob := mr.EXPECT().
+			OpenBlob(ctx, gomock.Eq(uid), gomock.Eq(blobID), gomock.Eq(int64(4))).
+			Return(io.NopCloser(strings.NewReader("wo")), nil)
+		gomock.InOrder(read, openBlob)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		err := rpc.DownloadBinary(&g.DownloadBinaryRequest{BlobId: blobID.String(), Offset: 4}, stream)
+		require.NoError(t, err)
+		require.Len(t, stream.chunks, 1)
+		require.Equal(t, int64(4), stream.chunks[0].Offset)
+		require.Equal(t, []byte("wo"), stream.chunks[0].Data)
+		require.Equal(t, checksum("wo"), stream.chunks[0].Checksum)
+		require.Equal(t, int64(6), stream.chunks[0].Size)
+	})
+
+	t.Run("incomplete blob", func(t *testing.T) {
+		uid := uuid.New()
+		blobID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		read := mr.EXPECT().
+			ReadBlob(ctx, gomock.Eq(uid), gomock.Eq(blobID)).
+			Return(&models.Blob{ID: blobID, Size: 3}, nil)
+		gomock.InOrder(read)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		err := rpc.DownloadBinary(&g.DownloadBinaryRequest{BlobId: blobID.String()}, &downloadStream{ctx: ctx})
+		require.ErrorIs(t, err, ErrIncompleteBlob)
+	})
+
+	t.Run("negative offset", func(t *testing.T) {
+		uid := uuid.New()
+		blobID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		read := mr.EXPECT().
+			ReadBlob(ctx, gomock.Eq(uid), gomock.Eq(blobID)).
+			Return(&models.Blob{ID: blobID, Size: 6, Complete: true}, nil)
+		gomock.InOrder(read)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		err := rpc.DownloadBinary(&g.DownloadBinaryRequest{BlobId: blobID.String(), Offset: -1}, &downloadStream{ctx: ctx})
+		require.ErrorIs(t, err, ErrInvalidOffset)
+	})
+
+	t.Run("offset past end", func(t *testing.T) {
+		uid := uuid.New()
+		blobID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		read := mr.EXPECT().
+			ReadBlob(ctx, gomock.Eq(uid), gomock.Eq(blobID)).
+			Return(&models.Blob{ID: blobID, Size: 6, Complete: true}, nil)
+		gomock.InOrder(read)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		err := rpc.DownloadBinary(&g.DownloadBinaryRequest{BlobId: blobID.String(), Offset: 7}, &downloadStream{ctx: ctx})
+		require.ErrorIs(t, err, ErrInvalidOffset)
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		err := rpc.DownloadBinary(nil, &downloadStream{ctx: context.Background()})
+		require.Error(t, err)
+	})
+}
+
+func checksum(data string) uint32 {
+	return crc32.Checksum([]byte(data), castagnoli)
+}
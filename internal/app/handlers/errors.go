@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/auth"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/repository"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/service"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/encryption"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain is the domain of the error details reported to clients.
+const errorDomain = "gokeeper"
+
+// retryDelay is the delay clients are advised to retry after
+// when the database is unavailable.
+const retryDelay = time.Second
+
+// Reasons of the errors reported to clients in error details.
+const (
+	ReasonInvalidArgument     = "INVALID_ARGUMENT"
+	ReasonInvalidCredentials  = "INVALID_CREDENTIALS"
+	ReasonInvalidSession      = "INVALID_SESSION"
+	ReasonUserExists          = "USER_EXISTS"
+	ReasonUserNotFound        = "USER_NOT_FOUND"
+	ReasonSessionNotFound     = "SESSION_NOT_FOUND"
+	ReasonVaultKeyExists      = "VAULT_KEY_EXISTS"
+	ReasonItemNotFound        = "ITEM_NOT_FOUND"
+	ReasonItemExists          = "ITEM_EXISTS"
+	ReasonRevisionConflict    = "REVISION_CONFLICT"
+	ReasonBlobNotFound        = "BLOB_NOT_FOUND"
+	ReasonBlobOffset          = "BLOB_OFFSET_MISMATCH"
+	ReasonBlobComplete        = "BLOB_COMPLETE"
+	ReasonBlobCompleting      = "BLOB_COMPLETING"
+	ReasonIncompleteBlob      = "INCOMPLETE_BLOB"
+	ReasonBlobAttached        = "BLOB_ATTACHED"
+	ReasonChecksumMismatch    = "CHECKSUM_MISMATCH"
+	ReasonPlaintextItems      = "PLAINTEXT_ITEMS_DISABLED"
+	ReasonUnknownItemType     = "UNKNOWN_ITEM_TYPE"
+	ReasonQuotaExceeded       = "QUOTA_EXCEEDED"
+	ReasonTooManyAttempts     = "TOO_MANY_ATTEMPTS"
+	ReasonInvalidCode         = "INVALID_CODE"
+	ReasonInvalidChallenge    = "INVALID_CHALLENGE"
+	ReasonTOTPEnabled         = "TOTP_ENABLED"
+	ReasonTOTPNotEnabled      = "TOTP_NOT_ENABLED"
+	ReasonTOTPNotPending      = "TOTP_NOT_PENDING"
+	ReasonVaultKeyRequired    = "VAULT_KEY_REQUIRED"
+	ReasonDatabaseUnavailable = "DATABASE_UNAVAILABLE"
+)
+
+// ErrInvalidID is raised when client sends malformed uuid.
+var ErrInvalidID = errors.New("invalid id")
+
+// errorCodes maps sentinel errors of the app's layers
+// to gRPC codes and reasons they are reported with.
+var errorCodes = []struct {
+	err    error
+	code   codes.Code
+	reason string
+}{
+	{ErrNilArgument, codes.InvalidArgument, ReasonInvalidArgument},
+	{ErrInvalidID, codes.InvalidArgument, ReasonInvalidArgument},
+	{ErrInvalidPageToken, codes.InvalidArgument, ReasonInvalidArgument},
+	{ErrUnauthenticated, codes.Unauthenticated, ReasonInvalidSession},
+	{ErrPlaintextItems, codes.FailedPrecondition, ReasonPlaintextItems},
+	{ErrUnknownItemType, codes.InvalidArgument, ReasonUnknownItemType},
+	{ErrIncompleteBlob, codes.FailedPrecondition, ReasonIncompleteBlob},
+	{ErrChecksumMismatch, codes.DataLoss, ReasonChecksumMismatch},
+	{ErrInvalidOffset, codes.InvalidArgument, ReasonInvalidArgument},
+	{encryption.ErrInvalidKDFParams, codes.InvalidArgument, ReasonInvalidArgument},
+	{service.ErrNilArgument, codes.InvalidArgument, ReasonInvalidArgument},
+	{service.ErrInvalidCredentials, codes.Unauthenticated, ReasonInvalidCredentials},
+	{service.ErrTooManyAttempts, codes.ResourceExhausted, ReasonTooManyAttempts},
+	{service.ErrInvalidCode, codes.Unauthenticated, ReasonInvalidCode},
+	{service.ErrTOTPEnabled, codes.FailedPrecondition, ReasonTOTPEnabled},
+	{service.ErrTOTPNotEnabled, codes.FailedPrecondition, ReasonTOTPNotEnabled},
+	{service.ErrTOTPNotPending, codes.FailedPrecondition, ReasonTOTPNotPending},
+	{service.ErrVaultKeyRequired, codes.FailedPrecondition, ReasonVaultKeyRequired},
+	{service.ErrInvalidSession, codes.Unauthenticated, ReasonInvalidSession},
+	{service.ErrResourceExhausted, codes.ResourceExhausted, ReasonQuotaExceeded},
+	{repository.ErrNilArgument, codes.InvalidArgument, ReasonInvalidArgument},
+	{repository.ErrNoUser, codes.NotFound, ReasonUserNotFound},
+	{repository.ErrUserExists, codes.AlreadyExists, ReasonUserExists},
+	{repository.ErrNoSession, codes.NotFound, ReasonSessionNotFound},
+	{repository.ErrVaultKeyExists, codes.AlreadyExists, ReasonVaultKeyExists},
+	{repository.ErrNoItem, codes.NotFound, ReasonItemNotFound},
+	{repository.ErrItemExists, codes.AlreadyExists, ReasonItemExists},
+	{repository.ErrRevisionConflict, codes.Aborted, ReasonRevisionConflict},
+	{repository.ErrNoBlob, codes.NotFound, ReasonBlobNotFound},
+	{repository.ErrBlobOffset, codes.FailedPrecondition, ReasonBlobOffset},
+	{repository.ErrBlobComplete, codes.FailedPrecondition, ReasonBlobComplete},
+	{repository.ErrBlobCompleting, codes.Aborted, ReasonBlobCompleting},
+	{repository.ErrBlobAttached, codes.FailedPrecondition, ReasonBlobAttached},
+	{auth.ErrInvalidToken, codes.Unauthenticated, ReasonInvalidSession},
+	{auth.ErrInvalidRefreshToken, codes.Unauthenticated, ReasonInvalidSession},
+	{auth.ErrInvalidChallenge, codes.Unauthenticated, ReasonInvalidChallenge},
+}
+
+// statusError converts error to gRPC status with error details. Sentinel errors
+// are reported with their codes and messages, unexpected errors are reported
+// as internal ones without revealing their messages.
+func (r *RPC) statusError(ctx context.Context, method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var (
+		st      *status.Status
+		details []protoiface.MessageV1
+	)
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case mongo.IsNetworkError(err) || mongo.IsTimeout(err):
+		st = status.New(codes.Unavailable, "storage is temporarily unavailable")
+		details = append(details,
+			errorInfo(ReasonDatabaseUnavailable),
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)},
+		)
+	default:
+		for _, e := range errorCodes {
+			if errors.Is(err, e.err) {
+				st = status.New(e.code, err.Error())
+				details = append(details, errorInfo(e.reason))
+				break
+			}
+		}
+	}
+	if st == nil {
+		r.log(ctx).Debug().Str("method", method).Err(err).Msg("reporting unexpected error as internal")
+		return status.Error(codes.Internal, "internal server error")
+	}
+	if errors.Is(err, service.ErrResourceExhausted) {
+		details = append(details, &errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{Description: err.Error()}},
+		})
+	}
+	var attemptsErr *service.AttemptsError
+	if errors.As(err, &attemptsErr) {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(attemptsErr.RetryAfter)})
+	}
+
+	detailed, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		r.log(ctx).Err(detailsErr).Caller().Msg("unable to attach error details")
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// errorInfo returns error details with provided reason.
+func errorInfo(reason string) *errdetails.ErrorInfo {
+	return &errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}
+}
+
+// parseID parses uuid sent by client, wrapping parsing error with ErrInvalidID.
+func parseID(id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+	return parsed, nil
+}
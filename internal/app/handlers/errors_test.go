@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/repository"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/service"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/encryption"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorInterceptor(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	rpc := &RPC{logger: logger}
+	info := &grpc.UnaryServerInfo{FullMethod: "/proto.server.Gokeeper/AddItem"}
+
+	tests := []struct {
+		name   string
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{"user exists", repository.ErrUserExists, codes.AlreadyExists, ReasonUserExists},
+		{"user not exists", repository.ErrNoUser, codes.NotFound, ReasonUserNotFound},
+		{"invalid credentials", service.ErrInvalidCredentials, codes.Unauthenticated, ReasonInvalidCredentials},
+		{"nil argument", ErrNilArgument, codes.InvalidArgument, ReasonInvalidArgument},
+		{"invalid id", fmt.Errorf("%w: invalid UUID length: 3", ErrInvalidID), codes.InvalidArgument, ReasonInvalidArgument},
+		{"no item", repository.ErrNoItem, codes.NotFound, ReasonItemNotFound},
+		{"checksum mismatch", ErrChecksumMismatch, codes.DataLoss, ReasonChecksumMismatch},
+		{"invalid offset", ErrInvalidOffset, codes.InvalidArgument, ReasonInvalidArgument},
+		{"invalid kdf params", encryption.ErrInvalidKDFParams, codes.InvalidArgument, ReasonInvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := rpc.ErrorInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, tt.err
+			})
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			require.Equal(t, tt.code, st.Code())
+			require.Equal(t, tt.err.Error(), st.Message())
+			require.Len(t, st.Details(), 1)
+			errInfo, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			require.True(t, ok)
+			require.Equal(t, tt.reason, errInfo.Reason)
+			require.Equal(t, errorDomain, errInfo.Domain)
+		})
+	}
+
+	t.Run("quota exceeded", func(t *testing.T) {
+		quotaErr := fmt.Errorf("%w: number of items is limited to 1", service.ErrResourceExhausted)
+		_, err := rpc.ErrorInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, quotaErr
+		})
+		st := status.Convert(err)
+		require.Equal(t, codes.ResourceExhausted, st.Code())
+		require.Len(t, st.Details(), 2)
+		failure, ok := st.Details()[1].(*errdetails.QuotaFailure)
+		require.True(t, ok)
+		require.Equal(t, quotaErr.Error(), failure.Violations[0].Description)
+	})
+
+	t.Run("too many attempts", func(t *testing.T) {
+		_, err := rpc.ErrorInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, &service.AttemptsError{RetryAfter: 4 * time.Second}
+		})
+		st := status.Convert(err)
+		require.Equal(t, codes.ResourceExhausted, st.Code())
+		require.Len(t, st.Details(), 2)
+		errInfo, ok := st.Details()[0].(*errdetails.ErrorInfo)
+		require.True(t, ok)
+		require.Equal(t, ReasonTooManyAttempts, errInfo.Reason)
+		retry, ok := st.Details()[1].(*errdetails.RetryInfo)
+		require.True(t, ok)
+		require.Equal(t, 4*time.Second, retry.RetryDelay.AsDuration())
+	})
+
+	t.Run("unexpected error", func(t *testing.T) {
+		_, err := rpc.ErrorInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.New("connection string contains password")
+		})
+		st := status.Convert(err)
+		require.Equal(t, codes.Internal, st.Code())
+		require.NotContains(t, st.Message(), "password")
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		_, err := rpc.ErrorInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, context.Canceled
+		})
+		require.Equal(t, codes.Canceled, status.Code(err))
+	})
+
+	t.Run("status passes through", func(t *testing.T) {
+		_, err := rpc.ErrorInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.PermissionDenied, "denied")
+		})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		resp, err := rpc.ErrorInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "ok", nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "ok", resp)
+	})
+}
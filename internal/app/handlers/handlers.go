@@ -2,24 +2,50 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/auth"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/metrics"
 	"github.com/serjyuriev/yandex-diploma-2/internal/app/repository"
 	"github.com/serjyuriev/yandex-diploma-2/internal/app/service"
 	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/encryption"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/logging"
 	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
 	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
 	// ErrNilArgument is raised when client makes a call for a method without
 	// providing enough information.
 	ErrNilArgument = errors.New("argument can't be empty")
+	// ErrUnauthenticated is raised when client calls a method,
+	// which requires authentication, without valid access token.
+	ErrUnauthenticated = errors.New("user is not authenticated")
+	// ErrPlaintextItems is raised when client calls deprecated method,
+	// which accepts item with plaintext fields, in zero-knowledge mode.
+	ErrPlaintextItems = errors.New("plaintext items are not accepted, use encrypted items")
+	// ErrUnknownItemType is raised when client sends item of unsupported type.
+	ErrUnknownItemType = errors.New("unknown item type")
+	// ErrIncompleteBlob is raised when client refers to blob,
+	// which wasn't completely uploaded yet.
+	ErrIncompleteBlob = errors.New("blob wasn't completely uploaded")
+	// ErrChecksumMismatch is raised when chunk of the blob
+	// doesn't match its checksum.
+	ErrChecksumMismatch = errors.New("chunk checksum mismatch")
+	// ErrInvalidOffset is raised when client asks for content
+	// of the blob from offset outside of it.
+	ErrInvalidOffset = errors.New("offset is outside of the blob")
 )
 
 // RPC holds objects for grpc implementation.
@@ -29,16 +55,20 @@ type RPC struct {
 	cfg    config.ServerConfig
 	repo   repository.Repository
 	svc    service.Service
+	tokens auth.Manager
 	logger zerolog.Logger
 }
 
-// MakeRPC initializes app's grpc service.
-func MakeRPC(logger zerolog.Logger) (*RPC, error) {
-	logger.Debug().Str("module", "gRPC").Msg("getting app's configuration")
-	cfg := config.GetServerConfig()
+// log returns logger of the request ctx belongs to,
+// falling back to gRPC layer's logger.
+func (r *RPC) log(ctx context.Context) *zerolog.Logger {
+	return logging.FromContext(ctx, &r.logger)
+}
 
+// MakeRPC initializes app's grpc service.
+func MakeRPC(cfg config.ServerConfig, logger zerolog.Logger) (*RPC, error) {
 	logger.Debug().Str("module", "gRPC").Msg("initializing data layer")
-	repo, err := repository.NewRepository(logger)
+	repo, err := repository.NewRepository(cfg, logger)
 	if err != nil {
 		logger.
 			Err(err).
@@ -47,8 +77,17 @@ func MakeRPC(logger zerolog.Logger) (*RPC, error) {
 		return nil, err
 	}
 
+	logger.Debug().Str("module", "gRPC").Msg("migrating database")
+	if err := repo.Migrate(context.Background()); err != nil {
+		logger.
+			Err(err).
+			Caller().
+			Msg("unable to migrate database")
+		return nil, err
+	}
+
 	logger.Debug().Str("module", "gRPC").Msg("initializing service layer")
-	svc, err := service.NewService(logger, repo)
+	svc, err := service.NewService(cfg, logger, repo)
 	if err != nil {
 		logger.
 			Err(err).
@@ -57,37 +96,70 @@ func MakeRPC(logger zerolog.Logger) (*RPC, error) {
 		return nil, err
 	}
 
+	logger.Debug().Str("module", "gRPC").Msg("initializing access tokens manager")
+	tokens, err := auth.NewManager(cfg, logger)
+	if err != nil {
+		logger.
+			Err(err).
+			Caller().
+			Msg("unable to initialize access tokens manager")
+		return nil, err
+	}
+
 	logger.Info().Msg("gRPC layer was successfully initialized")
 	return &RPC{
 		cfg:    cfg,
 		repo:   repo,
 		svc:    svc,
+		tokens: tokens,
 		logger: logger,
 	}, nil
 }
 
+// Ping checks that the data layer is able to serve requests.
+func (r *RPC) Ping(ctx context.Context) error {
+	return r.repo.Ping(ctx)
+}
+
+// ReadStats returns totals of the whole storage.
+func (r *RPC) ReadStats(ctx context.Context) (*models.Stats, error) {
+	return r.repo.ReadStats(ctx)
+}
+
+// ExpireBlobs removes uploaded blobs, which weren't attached to items in time.
+func (r *RPC) ExpireBlobs(ctx context.Context) (int64, error) {
+	return r.repo.ExpireBlobs(ctx)
+}
+
+// Close releases resources held by the gRPC layer, closing the data layer.
+func (r *RPC) Close(ctx context.Context) error {
+	r.log(ctx).Debug().Str("module", "gRPC").Msg("closing data layer")
+	return r.repo.Close(ctx)
+}
+
 // SignUpUser signs new user up.
 func (r *RPC) SignUpUser(ctx context.Context, in *g.SignUpUserRequest) (*g.SignUpUserResponse, error) {
 	if in == nil {
-		r.logger.Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
 		return &g.SignUpUserResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
 	}
 
-	r.logger.Info().Str("user", in.User.Login).Msg("received new user sign up request")
+	r.log(ctx).Info().Str("user", in.User.Login).Msg("received new user sign up request")
+	if err := checkVaultKey(in.VaultKey); err != nil {
+		r.log(ctx).Err(err).Str("user", in.User.Login).Msg("vault key has invalid key derivation parameters")
+		return &g.SignUpUserResponse{Error: err.Error()}, err
+	}
 	user := &models.User{
-		Login:     in.User.Login,
-		Password:  in.User.Password,
-		Logins:    make([]*models.LoginPasswordItem, 0),
-		BankCards: make([]*models.BankCardItem, 0),
-		Texts:     make([]*models.TextItem, 0),
-		Binaries:  make([]*models.BinaryItem, 0),
+		Login:    in.User.Login,
+		Password: in.User.Password,
+		VaultKey: vaultKeyToModel(in.VaultKey),
 	}
 	res := new(g.SignUpUserResponse)
 
-	r.logger.Debug().Str("user", in.User.Login).Msg("passing user's info to service layer")
+	r.log(ctx).Debug().Str("user", in.User.Login).Msg("passing user's info to service layer")
 	userID, err := r.svc.SignUpUser(ctx, user)
 	if err != nil {
-		r.logger.
+		r.log(ctx).
 			Err(err).
 			Caller().
 			Str("user", user.Login).
@@ -97,8 +169,23 @@ func (r *RPC) SignUpUser(ctx context.Context, in *g.SignUpUserRequest) (*g.SignU
 		return res, err
 	}
 
-	r.logger.Info().Str("user", in.User.Login).Msg("new user was successfully signed up")
+	r.log(ctx).Debug().Str("user", in.User.Login).Msg("starting new session")
+	token, expiresAt, refreshToken, err := r.startSession(ctx, userID, in.Device)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", user.Login).
+			Msg("unable to start new session")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Info().Str("user", in.User.Login).Msg("new user was successfully signed up")
 	res.UserID = userID
+	res.AccessToken = token
+	res.ExpiresAt = expiresAt
+	res.RefreshToken = refreshToken
 	res.Error = ""
 	return res, nil
 }
@@ -106,21 +193,49 @@ func (r *RPC) SignUpUser(ctx context.Context, in *g.SignUpUserRequest) (*g.SignU
 // LoginUser logins existing user.
 func (r *RPC) LoginUser(ctx context.Context, in *g.LoginUserRequest) (*g.LoginUserResponse, error) {
 	if in == nil {
-		r.logger.Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
 		return &g.LoginUserResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
 	}
+	if in.Challenge != "" {
+		return r.completeLogin(ctx, in)
+	}
 
-	r.logger.Info().Str("user", in.User.Login).Msg("received user login request")
+	r.log(ctx).Info().Str("user", in.User.Login).Msg("received user login request")
 	user := &models.User{
 		Login:    in.User.Login,
 		Password: in.User.Password,
 	}
 	res := new(g.LoginUserResponse)
 
-	r.logger.Debug().Str("user", in.User.Login).Msg("passing user's info to service layer")
+	r.log(ctx).Debug().Str("user", in.User.Login).Msg("passing user's info to service layer")
 	userID, err := r.svc.LoginUser(ctx, user)
+	if errors.Is(err, service.ErrTOTPRequired) {
+		r.log(ctx).Debug().Str("user", in.User.Login).Msg("issuing login challenge")
+		challenge, err := r.issueChallenge(userID)
+		if err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("user", user.Login).
+				Msg("unable to issue login challenge")
+			metrics.Logins.WithLabelValues(metrics.LoginError).Inc()
+			res.Error = err.Error()
+			return res, err
+		}
+		r.log(ctx).Info().Str("user", in.User.Login).Msg("one-time code is required to complete login")
+		res.Challenge = challenge
+		return res, nil
+	}
 	if err != nil {
-		r.logger.
+		switch {
+		case errors.Is(err, service.ErrInvalidCredentials):
+			metrics.Logins.WithLabelValues(metrics.LoginFailure).Inc()
+		case errors.Is(err, service.ErrTooManyAttempts):
+			metrics.Logins.WithLabelValues(metrics.LoginBlocked).Inc()
+		default:
+			metrics.Logins.WithLabelValues(metrics.LoginError).Inc()
+		}
+		r.log(ctx).
 			Err(err).
 			Caller().
 			Str("user", user.Login).
@@ -130,8 +245,260 @@ func (r *RPC) LoginUser(ctx context.Context, in *g.LoginUserRequest) (*g.LoginUs
 		return res, err
 	}
 
-	r.logger.Info().Str("user", in.User.Login).Msg("user was successfully logged in")
+	r.log(ctx).Debug().Str("user", in.User.Login).Msg("starting new session")
+	token, expiresAt, refreshToken, err := r.startSession(ctx, userID, in.Device)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", user.Login).
+			Msg("unable to start new session")
+		metrics.Logins.WithLabelValues(metrics.LoginError).Inc()
+		res.Error = err.Error()
+		return res, err
+	}
+
+	metrics.Logins.WithLabelValues(metrics.LoginSuccess).Inc()
+	r.log(ctx).Info().Str("user", in.User.Login).Msg("user was successfully logged in")
 	res.UserID = userID
+	res.AccessToken = token
+	res.ExpiresAt = expiresAt
+	res.RefreshToken = refreshToken
+	res.Error = ""
+	return res, nil
+}
+
+// RefreshSession exchanges refresh token for a new pair
+// of access and refresh tokens.
+func (r *RPC) RefreshSession(ctx context.Context, in *g.RefreshSessionRequest) (*g.RefreshSessionResponse, error) {
+	if in == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.RefreshSessionResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	r.log(ctx).Info().Msg("received session refresh request")
+	res := new(g.RefreshSessionResponse)
+
+	r.log(ctx).Debug().Msg("passing refresh token to service layer")
+	session, refreshToken, err := r.svc.RefreshSession(ctx, in.RefreshToken)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to refresh session")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Debug().Str("user", session.UserID.String()).Msg("issuing access token")
+	token, expiresAt, err := r.tokens.IssueAccessToken(auth.Claims{UserID: session.UserID, SessionID: session.ID})
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", session.UserID.String()).
+			Msg("unable to issue access token")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Info().Str("user", session.UserID.String()).Msg("session was successfully refreshed")
+	res.AccessToken = token
+	res.ExpiresAt = timestamppb.New(expiresAt)
+	res.RefreshToken = refreshToken
+	res.Error = ""
+	return res, nil
+}
+
+// Logout terminates session the request was made within.
+func (r *RPC) Logout(ctx context.Context, in *g.LogoutRequest) (*g.LogoutResponse, error) {
+	if in == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.LogoutResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	claims, err := claimsFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.LogoutResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", claims.UserID.String()).Msg("received logout request")
+	res := new(g.LogoutResponse)
+
+	r.log(ctx).Debug().Str("user", claims.UserID.String()).Msg("passing session's info to service layer")
+	if err := r.svc.RevokeSession(ctx, claims.UserID, claims.SessionID); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", claims.UserID.String()).
+			Msg("unable to log user out")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Info().Str("user", claims.UserID.String()).Msg("user was successfully logged out")
+	res.Error = ""
+	return res, nil
+}
+
+// ListSessions returns all active sessions of the user.
+func (r *RPC) ListSessions(ctx context.Context, in *g.ListSessionsRequest) (*g.ListSessionsResponse, error) {
+	if in == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.ListSessionsResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	claims, err := claimsFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.ListSessionsResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", claims.UserID.String()).Msg("received sessions list request")
+	res := new(g.ListSessionsResponse)
+
+	r.log(ctx).Debug().Str("user", claims.UserID.String()).Msg("getting sessions from service layer")
+	sessions, err := r.svc.ListSessions(ctx, claims.UserID)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", claims.UserID.String()).
+			Msg("unable to list user's sessions")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	res.Sessions = make([]*g.Session, len(sessions))
+	for i, session := range sessions {
+		res.Sessions[i] = &g.Session{
+			Id:         session.ID.String(),
+			Device:     session.Device,
+			CreatedAt:  timestamppb.New(session.CreatedAt),
+			LastSeenAt: timestamppb.New(session.LastSeenAt),
+			ExpiresAt:  timestamppb.New(session.ExpiresAt),
+			Current:    session.ID == claims.SessionID,
+		}
+	}
+
+	r.log(ctx).Info().Str("user", claims.UserID.String()).Msg("sessions were successfully listed")
+	res.Error = ""
+	return res, nil
+}
+
+// RevokeSession terminates one of the user's sessions.
+func (r *RPC) RevokeSession(ctx context.Context, in *g.RevokeSessionRequest) (*g.RevokeSessionResponse, error) {
+	if in == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.RevokeSessionResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.RevokeSessionResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("received session revocation request")
+	res := new(g.RevokeSessionResponse)
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("parsing session uuid")
+	sessionID, err := parseID(in.SessionID)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to parse session uuid")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("passing session's info to service layer")
+	if err := r.svc.RevokeSession(ctx, userID, sessionID); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to revoke session")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("session was successfully revoked")
+	res.Error = ""
+	return res, nil
+}
+
+// GetVaultKey returns wrapped vault key of the user,
+// or empty key if user hasn't set it yet.
+func (r *RPC) GetVaultKey(ctx context.Context, in *g.GetVaultKeyRequest) (*g.GetVaultKeyResponse, error) {
+	if in == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.GetVaultKeyResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.GetVaultKeyResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("received vault key request")
+	res := new(g.GetVaultKeyResponse)
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("reading user's vault key")
+	user, err := r.repo.ReadUserByID(ctx, userID)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to read user's vault key")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("vault key was successfully returned")
+	res.VaultKey = vaultKeyFromModel(user.VaultKey)
+	res.Error = ""
+	return res, nil
+}
+
+// SetVaultKey sets wrapped vault key of the user,
+// which signed up before vault keys were introduced.
+func (r *RPC) SetVaultKey(ctx context.Context, in *g.SetVaultKeyRequest) (*g.SetVaultKeyResponse, error) {
+	if in == nil || in.VaultKey == nil || len(in.VaultKey.WrappedKey) == 0 || len(in.VaultKey.KdfSalt) == 0 {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.SetVaultKeyResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+	if err := checkVaultKey(in.VaultKey); err != nil {
+		r.log(ctx).Err(err).Msg("vault key has invalid key derivation parameters")
+		return &g.SetVaultKeyResponse{Error: err.Error()}, err
+	}
+
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.SetVaultKeyResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("received set vault key request")
+	res := new(g.SetVaultKeyResponse)
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("passing vault key to data layer")
+	if err := r.repo.CreateVaultKey(ctx, userID, vaultKeyToModel(in.VaultKey)); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to set user's vault key")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("vault key was successfully set")
 	res.Error = ""
 	return res, nil
 }
@@ -140,82 +507,48 @@ func (r *RPC) LoginUser(ctx context.Context, in *g.LoginUserRequest) (*g.LoginUs
 // including all available items.
 func (r *RPC) UpdateItems(ctx context.Context, in *g.UpdateItemsRequest) (*g.UpdateItemsResponse, error) {
 	if in == nil {
-		r.logger.Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
 		return &g.UpdateItemsResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
 	}
 
-	r.logger.Info().Str("user", in.UserID).Msg("received update request")
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.UpdateItemsResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("received update request")
 	res := new(g.UpdateItemsResponse)
-	uid, err := uuid.Parse(in.UserID)
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("updating items")
+	user, err := r.repo.ReadUserByID(ctx, userID)
 	if err != nil {
-		r.logger.
+		r.log(ctx).
 			Err(err).
 			Caller().
-			Str("user", in.UserID).
-			Msg("unable to parse user uuid")
+			Str("user", userID.String()).
+			Msg("unable to update items")
 		res.Error = err.Error()
 		return res, err
 	}
 
-	r.logger.Debug().Str("user", in.UserID).Msg("updating items")
-	user, err := r.repo.ReadUserByID(ctx, uid)
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("reading user's items")
+	vault, err := r.repo.ReadItems(ctx, userID)
 	if err != nil {
-		r.logger.
+		r.log(ctx).
 			Err(err).
 			Caller().
-			Str("user", in.UserID).
-			Msg("unable to update items")
+			Str("user", userID.String()).
+			Msg("unable to read user's items")
+		res.Error = err.Error()
+		return res, err
+	}
+	if err := r.loadBinaryValues(ctx, userID, vault.Binaries); err != nil {
 		res.Error = err.Error()
 		return res, err
 	}
 
-	logins := make([]*g.LoginItem, len(user.Logins))
-	cards := make([]*g.BankCardItem, len(user.BankCards))
-	texts := make([]*g.TextItem, len(user.Texts))
-	binaries := make([]*g.BinaryItem, len(user.Binaries))
-	wg := sync.WaitGroup{}
-	wg.Add(4)
-	go func() {
-		for i, item := range user.Logins {
-			logins[i] = &g.LoginItem{
-				Login:    item.Login,
-				Password: item.Password,
-				Meta:     item.Meta,
-			}
-		}
-		wg.Done()
-	}()
-	go func() {
-		for i, item := range user.BankCards {
-			cards[i] = &g.BankCardItem{
-				Number:           item.Number,
-				Holder:           item.Holder,
-				Expires:          item.Expires,
-				CardSecurityCode: item.CardSecurityCode,
-				Meta:             item.Meta,
-			}
-		}
-		wg.Done()
-	}()
-	go func() {
-		for i, item := range user.Texts {
-			texts[i] = &g.TextItem{
-				Value: item.Value,
-				Meta:  item.Meta,
-			}
-		}
-		wg.Done()
-	}()
-	go func() {
-		for i, item := range user.Binaries {
-			binaries[i] = &g.BinaryItem{
-				Value: item.Value,
-				Meta:  item.Meta,
-			}
-		}
-		wg.Done()
-	}()
-	wg.Wait()
+	logins, cards, texts, binaries, items := vaultFromModel(vault)
 	res.User = &g.User{
 		Login:    user.Login,
 		Logins:   logins,
@@ -223,51 +556,371 @@ func (r *RPC) UpdateItems(ctx context.Context, in *g.UpdateItemsRequest) (*g.Upd
 		Texts:    texts,
 		Binaries: binaries,
 	}
+	res.Items = items
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("user info was updated")
+	res.Error = ""
+	return res, nil
+}
+
+// Sync returns items of the user's vault, which were added, changed
+// or deleted after provided revision.
+func (r *RPC) Sync(ctx context.Context, in *g.SyncRequest) (*g.SyncResponse, error) {
+	if in == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.SyncResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.SyncResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Int64("since", in.SinceRevision).Msg("received sync request")
+	res := new(g.SyncResponse)
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("reading changed items")
+	vault, err := r.repo.ReadItemsSince(ctx, userID, in.SinceRevision)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to read changed items")
+		res.Error = err.Error()
+		return res, err
+	}
+	if err := r.loadBinaryValues(ctx, userID, vault.Binaries); err != nil {
+		res.Error = err.Error()
+		return res, err
+	}
+
+	res.Logins, res.Cards, res.Texts, res.Binaries, res.Items = vaultFromModel(vault)
+	res.Deleted = make([]string, len(vault.Deleted))
+	for i, id := range vault.Deleted {
+		res.Deleted[i] = id.String()
+	}
+	res.Revision = vault.Revision
+
+	r.log(ctx).Info().Str("user", userID.String()).Int64("revision", vault.Revision).Msg("vault was synced")
+	res.Error = ""
+	return res, nil
+}
+
+// AddItem adds new encrypted item in the user's vault.
+// Item can be given id by the client, so the request can be safely retried:
+// item, which was already added with the same id, is reported as added.
+func (r *RPC) AddItem(ctx context.Context, in *g.AddItemRequest) (*g.AddItemResponse, error) {
+	if in == nil || in.Item == nil || len(in.Item.Payload) == 0 {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.AddItemResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.AddItemResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("received new item")
+	res := new(g.AddItemResponse)
+
+	itemType, err := itemTypeToModel(in.Item.Type)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Str("type", in.Item.Type.String()).
+			Msg("unable to add item")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	itemID := uuid.New()
+	if in.Item.Id != "" {
+		r.log(ctx).Debug().Str("user", userID.String()).Msg("parsing item uuid")
+		itemID, err = parseID(in.Item.Id)
+		if err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("user", userID.String()).
+				Msg("unable to parse item uuid")
+			res.Error = err.Error()
+			return res, err
+		}
+	}
+
+	now := time.Now().UTC()
+	item := &models.Item{
+		ID:        itemID,
+		Type:      itemType,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Payload:   in.Item.Payload,
+	}
+
+	if in.Item.BlobId != "" {
+		r.log(ctx).Debug().Str("user", userID.String()).Str("blob", in.Item.BlobId).Msg("checking item's blob")
+		blob, err := r.completeBlob(ctx, userID, in.Item.BlobId)
+		if err != nil {
+			res.Error = err.Error()
+			return res, err
+		}
+		item.BlobID = blob.ID
+		item.BlobSize = blob.Size
+		item.BlobHash = blob.Hash
+	}
+
+	payload := int64(len(item.Payload))
+	if err := r.svc.ReserveItem(ctx, userID, payload, item.BlobSize); err != nil {
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("passing new item to data layer")
+	if err := r.repo.CreateItem(ctx, item, "items", userID, payload+item.BlobSize); err != nil {
+		r.svc.ReleaseItem(ctx, userID, payload)
+		if errors.Is(err, repository.ErrItemExists) {
+			r.log(ctx).Info().Str("user", userID.String()).Str("item", item.ID.String()).Msg("item was already added")
+			res.Id = item.ID.String()
+			res.Error = ""
+			return res, nil
+		}
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to create new item")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Str("item", item.ID.String()).Msg("item was successfully added")
+	r.svc.Audit(ctx, userID, models.AuditItemCreated, itemDetails(item.ID, item.Type))
+	res.Id = item.ID.String()
+	res.Error = ""
+	return res, nil
+}
+
+// UpdateItem replaces encrypted payload of the item in the user's vault.
+// Conflicts with changes made on other devices are reported in the response
+// along with current version of the item, so the client can resolve them.
+func (r *RPC) UpdateItem(ctx context.Context, in *g.UpdateItemRequest) (*g.UpdateItemResponse, error) {
+	if in == nil || in.Item == nil || len(in.Item.Payload) == 0 {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.UpdateItemResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.UpdateItemResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Str("item", in.Item.Id).Msg("received item update")
+	res := new(g.UpdateItemResponse)
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("parsing item uuid")
+	id, err := parseID(in.Item.Id)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to parse item uuid")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	item := &models.Item{
+		ID:        id,
+		UpdatedAt: time.Now().UTC(),
+		Payload:   in.Item.Payload,
+	}
+
+	if in.Item.BlobId != "" {
+		r.log(ctx).Debug().Str("user", userID.String()).Str("blob", in.Item.BlobId).Msg("checking item's blob")
+		blob, err := r.completeBlob(ctx, userID, in.Item.BlobId)
+		if err != nil {
+			res.Error = err.Error()
+			return res, err
+		}
+		item.BlobID = blob.ID
+		item.BlobSize = blob.Size
+		item.BlobHash = blob.Hash
+	}
 
-	r.logger.Info().Str("user", in.UserID).Msg("user info was updated")
+	reserved, err := r.svc.ReserveItemUpdate(ctx, userID, item)
+	if err != nil {
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("passing item to data layer")
+	released, err := r.repo.UpdateItem(ctx, userID, item, int64(len(item.Payload)), in.ExpectedRevision)
+	if err != nil {
+		r.svc.CancelItemUpdate(ctx, userID, reserved)
+		if errors.Is(err, repository.ErrRevisionConflict) {
+			res.Conflict = true
+			res.Current = r.currentItem(ctx, userID, id)
+			res.Error = err.Error()
+			return res, nil
+		}
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to update item")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.svc.SettleItemUpdate(ctx, userID, item, reserved, released)
+
+	r.log(ctx).Info().Str("user", userID.String()).Str("item", in.Item.Id).Msg("item was successfully updated")
+	r.svc.Audit(ctx, userID, models.AuditItemUpdated, itemDetails(id, ""))
+	res.Revision = item.Revision
 	res.Error = ""
 	return res, nil
 }
 
+// DeleteItem removes item of any type from the user's vault.
+// Conflicts are reported the same way UpdateItem does.
+func (r *RPC) DeleteItem(ctx context.Context, in *g.DeleteItemRequest) (*g.DeleteItemResponse, error) {
+	if in == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.DeleteItemResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.DeleteItemResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Str("item", in.Id).Msg("received item deletion request")
+	res := new(g.DeleteItemResponse)
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("parsing item uuid")
+	id, err := parseID(in.Id)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to parse item uuid")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("passing item to data layer")
+	size, err := r.repo.DeleteItem(ctx, userID, id, in.ExpectedRevision)
+	if err != nil {
+		if errors.Is(err, repository.ErrRevisionConflict) {
+			res.Conflict = true
+			res.Current = r.currentItem(ctx, userID, id)
+			res.Error = err.Error()
+			return res, nil
+		}
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to delete item")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.svc.ReleaseItem(ctx, userID, size)
+
+	r.log(ctx).Info().Str("user", userID.String()).Str("item", in.Id).Msg("item was successfully deleted")
+	r.svc.Audit(ctx, userID, models.AuditItemDeleted, itemDetails(id, ""))
+	res.Error = ""
+	return res, nil
+}
+
+// itemDetails returns details of the audit event which changed item,
+// type is omitted when it is unknown.
+func itemDetails(itemID uuid.UUID, itemType models.ItemType) map[string]string {
+	details := map[string]string{"item": itemID.String()}
+	if itemType != "" {
+		details["type"] = string(itemType)
+	}
+	return details
+}
+
+// currentItem returns current version of the item, which was changed
+// on another device, or nil if it can't be read.
+func (r *RPC) currentItem(ctx context.Context, userID uuid.UUID, itemID uuid.UUID) *g.Item {
+	r.log(ctx).
+		Info().
+		Str("user", userID.String()).
+		Str("item", itemID.String()).
+		Msg("item was changed since expected revision")
+	item, err := r.repo.ReadItem(ctx, userID, itemID)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to read current version of item")
+		return nil
+	}
+	return itemFromModel(item)
+}
+
 // AddLoginItem adds new login entry in the user's vault.
 func (r *RPC) AddLoginItem(ctx context.Context, in *g.AddLoginItemRequest) (*g.AddLoginItemResponse, error) {
 	if in == nil {
-		r.logger.Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
 		return &g.AddLoginItemResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
 	}
+	if r.cfg.ZeroKnowledge {
+		r.log(ctx).Info().Msg("plaintext login item was rejected")
+		return &g.AddLoginItemResponse{Error: ErrPlaintextItems.Error()}, ErrPlaintextItems
+	}
+
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.AddLoginItemResponse{Error: err.Error()}, err
+	}
 
-	r.logger.Info().Str("user", in.UserID).Msg("received new login item")
+	r.log(ctx).Info().Str("user", userID.String()).Msg("received new login item")
+	now := time.Now().UTC()
 	login := &models.LoginPasswordItem{
-		Login:    in.Item.Login,
-		Password: in.Item.Password,
-		Meta:     in.Item.Meta,
+		ID:        uuid.New(),
+		Login:     in.Item.Login,
+		Password:  in.Item.Password,
+		Meta:      in.Item.Meta,
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 	res := new(g.AddLoginItemResponse)
 
-	r.logger.Debug().Str("user", in.UserID).Msg("parsing user uuid")
-	userID, err := uuid.Parse(in.UserID)
-	if err != nil {
-		r.logger.
-			Err(err).
-			Caller().
-			Str("user", in.UserID).
-			Msg("unable to parse user uuid")
+	size := int64(proto.Size(in.Item))
+	if err := r.svc.ReserveItem(ctx, userID, size, 0); err != nil {
 		res.Error = err.Error()
 		return res, err
 	}
 
-	r.logger.Debug().Str("user", in.UserID).Msg("passing new login item to data layer")
-	if err := r.repo.CreateItem(ctx, login, "logins", userID); err != nil {
-		r.logger.
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("passing new login item to data layer")
+	if err := r.repo.CreateItem(ctx, login, "logins", userID, size); err != nil {
+		r.svc.ReleaseItem(ctx, userID, size)
+		r.log(ctx).
 			Err(err).
 			Caller().
-			Str("user", in.UserID).
+			Str("user", userID.String()).
 			Msg("unable to create new login item")
 		res.Error = err.Error()
 		return res, err
 	}
 
-	r.logger.Info().Str("user", in.UserID).Msg("login item was successfully added")
+	r.log(ctx).Info().Str("user", userID.String()).Msg("login item was successfully added")
+	r.svc.Audit(ctx, userID, models.AuditItemCreated, itemDetails(login.ID, models.ItemTypeLogin))
 	res.Error = ""
 	return res, nil
 }
@@ -275,44 +928,54 @@ func (r *RPC) AddLoginItem(ctx context.Context, in *g.AddLoginItemRequest) (*g.A
 // AddBankCardItem adds new bank card entry in the user's vault.
 func (r *RPC) AddBankCardItem(ctx context.Context, in *g.AddBankCardItemRequest) (*g.AddBankCardItemResponse, error) {
 	if in == nil {
-		r.logger.Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
 		return &g.AddBankCardItemResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
 	}
+	if r.cfg.ZeroKnowledge {
+		r.log(ctx).Info().Msg("plaintext bank card item was rejected")
+		return &g.AddBankCardItemResponse{Error: ErrPlaintextItems.Error()}, ErrPlaintextItems
+	}
 
-	r.logger.Info().Str("user", in.UserID).Msg("received new bank card item")
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.AddBankCardItemResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("received new bank card item")
+	now := time.Now().UTC()
 	card := &models.BankCardItem{
+		ID:               uuid.New(),
 		Number:           in.Item.Number,
 		Holder:           in.Item.Holder,
 		Expires:          in.Item.Expires,
 		CardSecurityCode: in.Item.CardSecurityCode,
 		Meta:             in.Item.Meta,
+		CreatedAt:        now,
+		UpdatedAt:        now,
 	}
 	res := new(g.AddBankCardItemResponse)
 
-	r.logger.Debug().Str("user", in.UserID).Msg("parsing user uuid")
-	userID, err := uuid.Parse(in.UserID)
-	if err != nil {
-		r.logger.
-			Err(err).
-			Caller().
-			Str("user", in.UserID).
-			Msg("unable to parse user uuid")
+	size := int64(proto.Size(in.Item))
+	if err := r.svc.ReserveItem(ctx, userID, size, 0); err != nil {
 		res.Error = err.Error()
 		return res, err
 	}
 
-	r.logger.Debug().Str("user", in.UserID).Msg("passing new bank card item to data layer")
-	if err := r.repo.CreateItem(ctx, card, "cards", userID); err != nil {
-		r.logger.
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("passing new bank card item to data layer")
+	if err := r.repo.CreateItem(ctx, card, "cards", userID, size); err != nil {
+		r.svc.ReleaseItem(ctx, userID, size)
+		r.log(ctx).
 			Err(err).
 			Caller().
-			Str("user", in.UserID).
+			Str("user", userID.String()).
 			Msg("unable to create new bank card item")
 		res.Error = err.Error()
 		return res, err
 	}
 
-	r.logger.Info().Str("user", in.UserID).Msg("bank card item was successfully added")
+	r.log(ctx).Info().Str("user", userID.String()).Msg("bank card item was successfully added")
+	r.svc.Audit(ctx, userID, models.AuditItemCreated, itemDetails(card.ID, models.ItemTypeBankCard))
 	res.Error = ""
 	return res, nil
 }
@@ -320,41 +983,51 @@ func (r *RPC) AddBankCardItem(ctx context.Context, in *g.AddBankCardItemRequest)
 // AddTextItem adds new text entry in the user's vault.
 func (r *RPC) AddTextItem(ctx context.Context, in *g.AddTextItemRequest) (*g.AddTextItemResponse, error) {
 	if in == nil {
-		r.logger.Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
 		return &g.AddTextItemResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
 	}
+	if r.cfg.ZeroKnowledge {
+		r.log(ctx).Info().Msg("plaintext text item was rejected")
+		return &g.AddTextItemResponse{Error: ErrPlaintextItems.Error()}, ErrPlaintextItems
+	}
 
-	r.logger.Info().Str("user", in.UserID).Msg("received new text item")
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.AddTextItemResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("received new text item")
+	now := time.Now().UTC()
 	text := &models.TextItem{
-		Value: in.Item.Value,
-		Meta:  in.Item.Meta,
+		ID:        uuid.New(),
+		Value:     in.Item.Value,
+		Meta:      in.Item.Meta,
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 	res := new(g.AddTextItemResponse)
 
-	r.logger.Debug().Str("user", in.UserID).Msg("parsing user uuid")
-	userID, err := uuid.Parse(in.UserID)
-	if err != nil {
-		r.logger.
-			Err(err).
-			Caller().
-			Str("user", in.UserID).
-			Msg("unable to parse user uuid")
+	size := int64(proto.Size(in.Item))
+	if err := r.svc.ReserveItem(ctx, userID, size, 0); err != nil {
 		res.Error = err.Error()
 		return res, err
 	}
 
-	r.logger.Debug().Str("user", in.UserID).Msg("passing new text item to data layer")
-	if err := r.repo.CreateItem(ctx, text, "texts", userID); err != nil {
-		r.logger.
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("passing new text item to data layer")
+	if err := r.repo.CreateItem(ctx, text, "texts", userID, size); err != nil {
+		r.svc.ReleaseItem(ctx, userID, size)
+		r.log(ctx).
 			Err(err).
 			Caller().
-			Str("user", in.UserID).
+			Str("user", userID.String()).
 			Msg("unable to create new text item")
 		res.Error = err.Error()
 		return res, err
 	}
 
-	r.logger.Info().Str("user", in.UserID).Msg("text item was successfully added")
+	r.log(ctx).Info().Str("user", userID.String()).Msg("text item was successfully added")
+	r.svc.Audit(ctx, userID, models.AuditItemCreated, itemDetails(text.ID, models.ItemTypeText))
 	res.Error = ""
 	return res, nil
 }
@@ -362,41 +1035,298 @@ func (r *RPC) AddTextItem(ctx context.Context, in *g.AddTextItemRequest) (*g.Add
 // AddBinaryItem adds new binary entry in the user's vault.
 func (r *RPC) AddBinaryItem(ctx context.Context, in *g.AddBinaryItemRequest) (*g.AddBinaryItemResponse, error) {
 	if in == nil {
-		r.logger.Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
 		return &g.AddBinaryItemResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
 	}
+	if r.cfg.ZeroKnowledge {
+		r.log(ctx).Info().Msg("plaintext binary item was rejected")
+		return &g.AddBinaryItemResponse{Error: ErrPlaintextItems.Error()}, ErrPlaintextItems
+	}
 
-	r.logger.Info().Str("user", in.UserID).Msg("received new binary item")
-	bin := &models.BinaryItem{
-		Value: in.Item.Value,
-		Meta:  in.Item.Meta,
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.AddBinaryItemResponse{Error: err.Error()}, err
 	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("received new binary item")
 	res := new(g.AddBinaryItemResponse)
 
-	r.logger.Debug().Str("user", in.UserID).Msg("parsing user uuid")
-	userID, err := uuid.Parse(in.UserID)
+	size := int64(proto.Size(in.Item))
+	if err := r.svc.ReserveItem(ctx, userID, size, 0); err != nil {
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("storing binary item's value")
+	blob, err := r.repo.StoreBlob(ctx, userID, bytes.NewReader(in.Item.Value))
 	if err != nil {
-		r.logger.
+		r.svc.ReleaseItem(ctx, userID, size)
+		r.log(ctx).
 			Err(err).
 			Caller().
-			Str("user", in.UserID).
-			Msg("unable to parse user uuid")
+			Str("user", userID.String()).
+			Msg("unable to store binary item's value")
 		res.Error = err.Error()
 		return res, err
 	}
 
-	r.logger.Debug().Str("user", in.UserID).Msg("passing new binary item to data layer")
-	if err := r.repo.CreateItem(ctx, bin, "binaries", userID); err != nil {
-		r.logger.
+	now := time.Now().UTC()
+	bin := &models.BinaryItem{
+		ID:        uuid.New(),
+		BlobID:    blob.ID,
+		Size:      blob.Size,
+		Hash:      blob.Hash,
+		Meta:      in.Item.Meta,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("passing new binary item to data layer")
+	if err := r.repo.CreateItem(ctx, bin, "binaries", userID, size); err != nil {
+		// stored value stays counted until it expires
+		r.svc.ReleaseItem(ctx, userID, size)
+		r.svc.CountBlob(ctx, userID, blob)
+		r.log(ctx).
 			Err(err).
 			Caller().
-			Str("user", in.UserID).
+			Str("user", userID.String()).
 			Msg("unable to create new binary item")
 		res.Error = err.Error()
 		return res, err
 	}
 
-	r.logger.Info().Str("user", in.UserID).Msg("binary item was successfully added")
+	r.log(ctx).Info().Str("user", userID.String()).Msg("binary item was successfully added")
+	r.svc.Audit(ctx, userID, models.AuditItemCreated, itemDetails(bin.ID, models.ItemTypeBinary))
 	res.Error = ""
 	return res, nil
 }
+
+// loadBinaryValues reads values of the user's binary items
+// from the blob store. Items, which still keep their value inline,
+// are left intact.
+func (r *RPC) loadBinaryValues(ctx context.Context, userID uuid.UUID, binaries []*models.BinaryItem) error {
+	for _, item := range binaries {
+		if item.BlobID == uuid.Nil {
+			continue
+		}
+
+		r.log(ctx).Debug().Str("user", userID.String()).Str("blob", item.BlobID.String()).Msg("reading binary item's value")
+		content, err := r.repo.OpenBlob(ctx, userID, item.BlobID, 0)
+		if err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("user", userID.String()).
+				Str("blob", item.BlobID.String()).
+				Msg("unable to open binary item's value")
+			return err
+		}
+		item.Value, err = io.ReadAll(content)
+		content.Close()
+		if err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("user", userID.String()).
+				Str("blob", item.BlobID.String()).
+				Msg("unable to read binary item's value")
+			return err
+		}
+	}
+	return nil
+}
+
+// startSession starts new session of the user with provided uuid,
+// returning access token, the moment it expires at and refresh token.
+func (r *RPC) startSession(ctx context.Context, userID string, device string) (string, *timestamppb.Timestamp, string, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	session, refreshToken, err := r.svc.CreateSession(ctx, uid, device)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	token, expiresAt, err := r.tokens.IssueAccessToken(auth.Claims{UserID: uid, SessionID: session.ID})
+	if err != nil {
+		return "", nil, "", err
+	}
+	return token, timestamppb.New(expiresAt), refreshToken, nil
+}
+
+// issueChallenge returns login challenge of the user with provided id.
+func (r *RPC) issueChallenge(userID string) (string, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return "", err
+	}
+	return r.tokens.IssueChallenge(uid)
+}
+
+// itemTypes maps gRPC item types to their data layer representation.
+var itemTypes = map[g.ItemType]models.ItemType{
+	g.ItemType_ITEM_TYPE_LOGIN:     models.ItemTypeLogin,
+	g.ItemType_ITEM_TYPE_BANK_CARD: models.ItemTypeBankCard,
+	g.ItemType_ITEM_TYPE_TEXT:      models.ItemTypeText,
+	g.ItemType_ITEM_TYPE_BINARY:    models.ItemTypeBinary,
+}
+
+// itemTypeToModel converts gRPC item type to its data layer representation.
+func itemTypeToModel(itemType g.ItemType) (models.ItemType, error) {
+	t, ok := itemTypes[itemType]
+	if !ok {
+		return "", ErrUnknownItemType
+	}
+	return t, nil
+}
+
+// itemFromModel converts stored item to its gRPC representation.
+func itemFromModel(item *models.Item) *g.Item {
+	itemType := g.ItemType_ITEM_TYPE_UNSPECIFIED
+	for k, v := range itemTypes {
+		if v == item.Type {
+			itemType = k
+		}
+	}
+	return &g.Item{
+		Id:        item.ID.String(),
+		Type:      itemType,
+		Revision:  item.Revision,
+		CreatedAt: timestamppb.New(item.CreatedAt),
+		UpdatedAt: timestamppb.New(item.UpdatedAt),
+		Payload:   item.Payload,
+		BlobId:    itemID(item.BlobID),
+		BlobSize:  item.BlobSize,
+		BlobHash:  item.BlobHash,
+	}
+}
+
+// vaultFromModel converts user's items to their gRPC representation.
+func vaultFromModel(vault *models.Vault) ([]*g.LoginItem, []*g.BankCardItem, []*g.TextItem, []*g.BinaryItem, []*g.Item) {
+	logins := make([]*g.LoginItem, len(vault.Logins))
+	cards := make([]*g.BankCardItem, len(vault.BankCards))
+	texts := make([]*g.TextItem, len(vault.Texts))
+	binaries := make([]*g.BinaryItem, len(vault.Binaries))
+	items := make([]*g.Item, len(vault.Items))
+	wg := sync.WaitGroup{}
+	wg.Add(5)
+	go func() {
+		for i, item := range vault.Logins {
+			logins[i] = &g.LoginItem{
+				Id:       itemID(item.ID),
+				Login:    item.Login,
+				Password: item.Password,
+				Meta:     item.Meta,
+			}
+		}
+		wg.Done()
+	}()
+	go func() {
+		for i, item := range vault.BankCards {
+			cards[i] = &g.BankCardItem{
+				Id:               itemID(item.ID),
+				Number:           item.Number,
+				Holder:           item.Holder,
+				Expires:          item.Expires,
+				CardSecurityCode: item.CardSecurityCode,
+				Meta:             item.Meta,
+			}
+		}
+		wg.Done()
+	}()
+	go func() {
+		for i, item := range vault.Texts {
+			texts[i] = &g.TextItem{
+				Id:    itemID(item.ID),
+				Value: item.Value,
+				Meta:  item.Meta,
+			}
+		}
+		wg.Done()
+	}()
+	go func() {
+		for i, item := range vault.Binaries {
+			binaries[i] = &g.BinaryItem{
+				Id:    itemID(item.ID),
+				Value: item.Value,
+				Meta:  item.Meta,
+			}
+		}
+		wg.Done()
+	}()
+	go func() {
+		for i, item := range vault.Items {
+			items[i] = itemFromModel(item)
+		}
+		wg.Done()
+	}()
+	wg.Wait()
+	return logins, cards, texts, binaries, items
+}
+
+// itemID returns string representation of item's uuid,
+// or empty string for items stored before they had one.
+func itemID(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
+
+// checkVaultKey checks that vault key received from client is wrapped
+// with key derivation parameters clients are able to unwrap it with.
+func checkVaultKey(key *g.VaultKey) error {
+	if key == nil || len(key.WrappedKey) == 0 {
+		return nil
+	}
+	_, err := encryption.NewKDFParams(key.KdfTime, key.KdfMemory, key.KdfThreads)
+	return err
+}
+
+// vaultKeyToModel converts wrapped vault key received from client
+// to its data layer representation.
+func vaultKeyToModel(key *g.VaultKey) *models.VaultKey {
+	if key == nil || len(key.WrappedKey) == 0 {
+		return nil
+	}
+	return &models.VaultKey{
+		WrappedKey: key.WrappedKey,
+		KDFSalt:    key.KdfSalt,
+		KDFTime:    key.KdfTime,
+		KDFMemory:  key.KdfMemory,
+		KDFThreads: key.KdfThreads,
+	}
+}
+
+// vaultKeyFromModel converts stored wrapped vault key
+// to its gRPC representation.
+func vaultKeyFromModel(key *models.VaultKey) *g.VaultKey {
+	if key == nil {
+		return nil
+	}
+	return &g.VaultKey{
+		WrappedKey: key.WrappedKey,
+		KdfSalt:    key.KDFSalt,
+		KdfTime:    key.KDFTime,
+		KdfMemory:  key.KDFMemory,
+		KdfThreads: key.KDFThreads,
+	}
+}
+
+// userFromContext returns uuid of the user authenticated by AuthInterceptor.
+func userFromContext(ctx context.Context) (uuid.UUID, error) {
+	claims, err := claimsFromContext(ctx)
+	return claims.UserID, err
+}
+
+// claimsFromContext returns claims of the user authenticated by AuthInterceptor.
+func claimsFromContext(ctx context.Context) (auth.Claims, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return auth.Claims{}, ErrUnauthenticated
+	}
+	return claims, nil
+}
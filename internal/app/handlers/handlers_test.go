@@ -3,27 +3,52 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/auth"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/metrics"
 	"github.com/serjyuriev/yandex-diploma-2/internal/app/mocks"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/repository"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/service"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/encryption"
 	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
 	g "github.com/serjyuriev/yandex-diploma-2/proto"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestMakeRPC(t *testing.T) {
-	os.Args = []string{"test", "-c", "A:\\dev\\yandex\\yandex-diploma-2\\dev_srv_config.yaml"}
 	output := zerolog.ConsoleWriter{
 		Out:        os.Stdout,
 		TimeFormat: "02-01-2006 15:04:05 MST",
 	}
 	logger := zerolog.New(output).With().Timestamp().Logger()
-	_, err := MakeRPC(logger)
-	require.NoError(t, err)
+	cfg := config.DefaultServerConfig()
+	cfg.Database.Address = "mongodb://127.0.0.1:1/?serverSelectionTimeoutMS=100"
+	cfg.Blobs.Store = "fs"
+	cfg.Blobs.Dir = t.TempDir()
+
+	t.Run("database unreachable", func(t *testing.T) {
+		_, err := MakeRPC(cfg, logger)
+		require.Error(t, err)
+	})
+
+	t.Run("unknown blob store", func(t *testing.T) {
+		cfg := cfg
+		cfg.Blobs.Store = "s3"
+		_, err := MakeRPC(cfg, logger)
+		require.ErrorIs(t, err, repository.ErrUnknownBlobStore)
+	})
 }
 
 func TestSignUpUser(t *testing.T) {
@@ -34,6 +59,7 @@ func TestSignUpUser(t *testing.T) {
 	logger := zerolog.New(output).With().Timestamp().Logger()
 	ctrl := gomock.NewController(t)
 	ms := mocks.NewMockService(ctrl)
+	mt := mocks.NewMockManager(ctrl)
 
 	t.Run("success", func(t *testing.T) {
 		in := &g.SignUpUserRequest{
@@ -41,20 +67,89 @@ func TestSignUpUser(t *testing.T) {
 				Login:    "test",
 				Password: "somepwd",
 			},
+			Device: "laptop",
 		}
+		uid := uuid.New()
+		session := &models.Session{ID: uuid.New(), UserID: uid}
+		expiresAt := time.Now().Add(time.Minute)
 
 		sign := ms.EXPECT().
 			SignUpUser(context.Background(), gomock.Any()).
-			Return("uid", nil)
-		gomock.InOrder(sign)
+			Return(uid.String(), nil)
+		create := ms.EXPECT().
+			CreateSession(context.Background(), gomock.Eq(uid), "laptop").
+			Return(session, "refresh", nil)
+		issue := mt.EXPECT().
+			IssueAccessToken(gomock.Eq(auth.Claims{UserID: uid, SessionID: session.ID})).
+			Return("token", expiresAt, nil)
+		gomock.InOrder(sign, create, issue)
 
 		rpc := &RPC{
 			logger: logger,
 			svc:    ms,
+			tokens: mt,
 		}
 		out, err := rpc.SignUpUser(context.Background(), in)
 		require.NoError(t, err)
-		require.Equal(t, "uid", out.UserID)
+		require.Equal(t, uid.String(), out.UserID)
+		require.Equal(t, "token", out.AccessToken)
+		require.Equal(t, "refresh", out.RefreshToken)
+		require.Equal(t, expiresAt.Unix(), out.ExpiresAt.AsTime().Unix())
+	})
+
+	t.Run("invalid kdf params", func(t *testing.T) {
+		in := &g.SignUpUserRequest{
+			User: &g.User{
+				Login:    "test",
+				Password: "somepwd",
+			},
+			VaultKey: &g.VaultKey{
+				WrappedKey: []byte("wrapped"),
+				KdfSalt:    []byte("salt"),
+				KdfTime:    3,
+				KdfMemory:  64 * 1024 * 1024,
+				KdfThreads: 4,
+			},
+		}
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+			tokens: mt,
+		}
+		_, err := rpc.SignUpUser(context.Background(), in)
+		require.ErrorIs(t, err, encryption.ErrInvalidKDFParams)
+	})
+
+	t.Run("token err", func(t *testing.T) {
+		in := &g.SignUpUserRequest{
+			User: &g.User{
+				Login:    "test",
+				Password: "somepwd",
+			},
+			Device: "laptop",
+		}
+		uid := uuid.New()
+		session := &models.Session{ID: uuid.New(), UserID: uid}
+
+		sign := ms.EXPECT().
+			SignUpUser(context.Background(), gomock.Any()).
+			Return(uid.String(), nil)
+		create := ms.EXPECT().
+			CreateSession(context.Background(), gomock.Eq(uid), "laptop").
+			Return(session, "refresh", nil)
+		issue := mt.EXPECT().
+			IssueAccessToken(gomock.Any()).
+			Return("", time.Time{}, fmt.Errorf("some err"))
+		gomock.InOrder(sign, create, issue)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+			tokens: mt,
+		}
+		_, err := rpc.SignUpUser(context.Background(), in)
+		require.Error(t, err)
 	})
 
 	t.Run("sign err", func(t *testing.T) {
@@ -74,83 +169,1154 @@ func TestSignUpUser(t *testing.T) {
 			logger: logger,
 			svc:    ms,
 		}
-		_, err := rpc.SignUpUser(context.Background(), in)
-		require.Error(t, err)
+		_, err := rpc.SignUpUser(context.Background(), in)
+		require.Error(t, err)
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		_, err := rpc.SignUpUser(context.Background(), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestLoginUser(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	ms := mocks.NewMockService(ctrl)
+	mt := mocks.NewMockManager(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		in := &g.LoginUserRequest{
+			User: &g.User{
+				Login:    "test",
+				Password: "somepwd",
+			},
+			Device: "laptop",
+		}
+		uid := uuid.New()
+		session := &models.Session{ID: uuid.New(), UserID: uid}
+		expiresAt := time.Now().Add(time.Minute)
+
+		login := ms.EXPECT().
+			LoginUser(context.Background(), gomock.Any()).
+			Return(uid.String(), nil)
+		create := ms.EXPECT().
+			CreateSession(context.Background(), gomock.Eq(uid), "laptop").
+			Return(session, "refresh", nil)
+		issue := mt.EXPECT().
+			IssueAccessToken(gomock.Eq(auth.Claims{UserID: uid, SessionID: session.ID})).
+			Return("token", expiresAt, nil)
+		gomock.InOrder(login, create, issue)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+			tokens: mt,
+		}
+		out, err := rpc.LoginUser(context.Background(), in)
+		require.NoError(t, err)
+		require.Equal(t, uid.String(), out.UserID)
+		require.Equal(t, "token", out.AccessToken)
+		require.Equal(t, "refresh", out.RefreshToken)
+		require.Equal(t, expiresAt.Unix(), out.ExpiresAt.AsTime().Unix())
+	})
+
+	t.Run("token err", func(t *testing.T) {
+		in := &g.LoginUserRequest{
+			User: &g.User{
+				Login:    "test",
+				Password: "somepwd",
+			},
+			Device: "laptop",
+		}
+		uid := uuid.New()
+		session := &models.Session{ID: uuid.New(), UserID: uid}
+
+		login := ms.EXPECT().
+			LoginUser(context.Background(), gomock.Any()).
+			Return(uid.String(), nil)
+		create := ms.EXPECT().
+			CreateSession(context.Background(), gomock.Eq(uid), "laptop").
+			Return(session, "refresh", nil)
+		issue := mt.EXPECT().
+			IssueAccessToken(gomock.Any()).
+			Return("", time.Time{}, fmt.Errorf("some err"))
+		gomock.InOrder(login, create, issue)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+			tokens: mt,
+		}
+		_, err := rpc.LoginUser(context.Background(), in)
+		require.Error(t, err)
+	})
+
+	t.Run("login err", func(t *testing.T) {
+		in := &g.LoginUserRequest{
+			User: &g.User{
+				Login:    "test",
+				Password: "somepwd",
+			},
+		}
+
+		login := ms.EXPECT().
+			LoginUser(context.Background(), gomock.Any()).
+			Return("", fmt.Errorf("some err"))
+		gomock.InOrder(login)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		before := testutil.ToFloat64(metrics.Logins.WithLabelValues(metrics.LoginError))
+		_, err := rpc.LoginUser(context.Background(), in)
+		require.Error(t, err)
+		require.Equal(t, before+1, testutil.ToFloat64(metrics.Logins.WithLabelValues(metrics.LoginError)))
+	})
+
+	t.Run("wrong credentials", func(t *testing.T) {
+		in := &g.LoginUserRequest{
+			User: &g.User{
+				Login:    "test",
+				Password: "wrongpwd",
+			},
+		}
+
+		login := ms.EXPECT().
+			LoginUser(context.Background(), gomock.Any()).
+			Return("", service.ErrInvalidCredentials)
+		gomock.InOrder(login)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		before := testutil.ToFloat64(metrics.Logins.WithLabelValues(metrics.LoginFailure))
+		_, err := rpc.LoginUser(context.Background(), in)
+		require.ErrorIs(t, err, service.ErrInvalidCredentials)
+		require.Equal(t, before+1, testutil.ToFloat64(metrics.Logins.WithLabelValues(metrics.LoginFailure)))
+	})
+
+	t.Run("blocked", func(t *testing.T) {
+		in := &g.LoginUserRequest{
+			User: &g.User{
+				Login:    "test",
+				Password: "somepwd",
+			},
+		}
+
+		login := ms.EXPECT().
+			LoginUser(context.Background(), gomock.Any()).
+			Return("", &service.AttemptsError{RetryAfter: time.Second})
+		gomock.InOrder(login)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		before := testutil.ToFloat64(metrics.Logins.WithLabelValues(metrics.LoginBlocked))
+		_, err := rpc.LoginUser(context.Background(), in)
+		require.ErrorIs(t, err, service.ErrTooManyAttempts)
+		require.Equal(t, before+1, testutil.ToFloat64(metrics.Logins.WithLabelValues(metrics.LoginBlocked)))
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		_, err := rpc.LoginUser(context.Background(), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestRefreshSession(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	ms := mocks.NewMockService(ctrl)
+	mt := mocks.NewMockManager(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		in := &g.RefreshSessionRequest{RefreshToken: "old"}
+		session := &models.Session{ID: uuid.New(), UserID: uuid.New()}
+		expiresAt := time.Now().Add(time.Minute)
+
+		refresh := ms.EXPECT().
+			RefreshSession(context.Background(), "old").
+			Return(session, "new", nil)
+		issue := mt.EXPECT().
+			IssueAccessToken(gomock.Eq(auth.Claims{UserID: session.UserID, SessionID: session.ID})).
+			Return("token", expiresAt, nil)
+		gomock.InOrder(refresh, issue)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+			tokens: mt,
+		}
+		out, err := rpc.RefreshSession(context.Background(), in)
+		require.NoError(t, err)
+		require.Equal(t, "token", out.AccessToken)
+		require.Equal(t, "new", out.RefreshToken)
+	})
+
+	t.Run("refresh err", func(t *testing.T) {
+		in := &g.RefreshSessionRequest{RefreshToken: "old"}
+
+		refresh := ms.EXPECT().
+			RefreshSession(context.Background(), "old").
+			Return(nil, "", service.ErrInvalidSession)
+		gomock.InOrder(refresh)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+			tokens: mt,
+		}
+		_, err := rpc.RefreshSession(context.Background(), in)
+		require.ErrorIs(t, err, service.ErrInvalidSession)
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+			tokens: mt,
+		}
+		_, err := rpc.RefreshSession(context.Background(), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	ms := mocks.NewMockService(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		claims := auth.Claims{UserID: uuid.New(), SessionID: uuid.New()}
+		ctx := auth.ContextWithClaims(context.Background(), claims)
+
+		revoke := ms.EXPECT().
+			RevokeSession(ctx, gomock.Eq(claims.UserID), gomock.Eq(claims.SessionID)).
+			Return(nil)
+		gomock.InOrder(revoke)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		out, err := rpc.Logout(ctx, &g.LogoutRequest{})
+		require.NoError(t, err)
+		require.Equal(t, "", out.Error)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		_, err := rpc.Logout(context.Background(), &g.LogoutRequest{})
+		require.ErrorIs(t, err, ErrUnauthenticated)
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		_, err := rpc.Logout(context.Background(), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestListSessions(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	ms := mocks.NewMockService(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		claims := auth.Claims{UserID: uuid.New(), SessionID: uuid.New()}
+		ctx := auth.ContextWithClaims(context.Background(), claims)
+		sessions := []*models.Session{
+			{ID: claims.SessionID, UserID: claims.UserID, Device: "laptop"},
+			{ID: uuid.New(), UserID: claims.UserID, Device: "phone"},
+		}
+
+		list := ms.EXPECT().
+			ListSessions(ctx, gomock.Eq(claims.UserID)).
+			Return(sessions, nil)
+		gomock.InOrder(list)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		out, err := rpc.ListSessions(ctx, &g.ListSessionsRequest{})
+		require.NoError(t, err)
+		require.Len(t, out.Sessions, 2)
+		require.True(t, out.Sessions[0].Current)
+		require.False(t, out.Sessions[1].Current)
+		require.Equal(t, "phone", out.Sessions[1].Device)
+	})
+
+	t.Run("list err", func(t *testing.T) {
+		claims := auth.Claims{UserID: uuid.New(), SessionID: uuid.New()}
+		ctx := auth.ContextWithClaims(context.Background(), claims)
+
+		list := ms.EXPECT().
+			ListSessions(ctx, gomock.Eq(claims.UserID)).
+			Return(nil, fmt.Errorf("some err"))
+		gomock.InOrder(list)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		_, err := rpc.ListSessions(ctx, &g.ListSessionsRequest{})
+		require.Error(t, err)
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		_, err := rpc.ListSessions(context.Background(), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	ms := mocks.NewMockService(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		sid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		revoke := ms.EXPECT().
+			RevokeSession(ctx, gomock.Eq(uid), gomock.Eq(sid)).
+			Return(nil)
+		gomock.InOrder(revoke)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		out, err := rpc.RevokeSession(ctx, &g.RevokeSessionRequest{SessionID: sid.String()})
+		require.NoError(t, err)
+		require.Equal(t, "", out.Error)
+	})
+
+	t.Run("wrong uuid", func(t *testing.T) {
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uuid.New()})
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		_, err := rpc.RevokeSession(ctx, &g.RevokeSessionRequest{SessionID: "k3j4n kj"})
+		require.Error(t, err)
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		_, err := rpc.RevokeSession(context.Background(), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestGetVaultKey(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		read := mr.EXPECT().
+			ReadUserByID(ctx, gomock.Eq(uid)).
+			Return(&models.User{
+				ID: uid,
+				VaultKey: &models.VaultKey{
+					WrappedKey: []byte("wrapped"),
+					KDFSalt:    []byte("salt"),
+					KDFTime:    3,
+					KDFMemory:  65536,
+					KDFThreads: 4,
+				},
+			}, nil)
+		gomock.InOrder(read)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		out, err := rpc.GetVaultKey(ctx, &g.GetVaultKeyRequest{})
+		require.NoError(t, err)
+		require.Equal(t, "", out.Error)
+		require.Equal(t, []byte("wrapped"), out.VaultKey.WrappedKey)
+		require.Equal(t, []byte("salt"), out.VaultKey.KdfSalt)
+		require.Equal(t, uint32(65536), out.VaultKey.KdfMemory)
+	})
+
+	t.Run("no key", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		read := mr.EXPECT().
+			ReadUserByID(ctx, gomock.Eq(uid)).
+			Return(&models.User{ID: uid}, nil)
+		gomock.InOrder(read)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		out, err := rpc.GetVaultKey(ctx, &g.GetVaultKeyRequest{})
+		require.NoError(t, err)
+		require.Nil(t, out.VaultKey)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		_, err := rpc.GetVaultKey(context.Background(), &g.GetVaultKeyRequest{})
+		require.ErrorIs(t, err, ErrUnauthenticated)
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		_, err := rpc.GetVaultKey(context.Background(), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestSetVaultKey(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	in := &g.SetVaultKeyRequest{
+		VaultKey: &g.VaultKey{
+			WrappedKey: []byte("wrapped"),
+			KdfSalt:    []byte("salt"),
+			KdfTime:    3,
+			KdfMemory:  65536,
+			KdfThreads: 4,
+		},
+	}
+	key := &models.VaultKey{
+		WrappedKey: []byte("wrapped"),
+		KDFSalt:    []byte("salt"),
+		KDFTime:    3,
+		KDFMemory:  65536,
+		KDFThreads: 4,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		create := mr.EXPECT().
+			CreateVaultKey(ctx, gomock.Eq(uid), gomock.Eq(key)).
+			Return(nil)
+		gomock.InOrder(create)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		out, err := rpc.SetVaultKey(ctx, in)
+		require.NoError(t, err)
+		require.Equal(t, "", out.Error)
+	})
+
+	t.Run("key exists", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		create := mr.EXPECT().
+			CreateVaultKey(ctx, gomock.Eq(uid), gomock.Eq(key)).
+			Return(repository.ErrVaultKeyExists)
+		gomock.InOrder(create)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		out, err := rpc.SetVaultKey(ctx, in)
+		require.ErrorIs(t, err, repository.ErrVaultKeyExists)
+		require.Equal(t, repository.ErrVaultKeyExists.Error(), out.Error)
+	})
+
+	t.Run("empty key", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		_, err := rpc.SetVaultKey(context.Background(), &g.SetVaultKeyRequest{VaultKey: &g.VaultKey{}})
+		require.ErrorIs(t, err, ErrNilArgument)
+	})
+
+	t.Run("invalid kdf params", func(t *testing.T) {
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uuid.New()})
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		_, err := rpc.SetVaultKey(ctx, &g.SetVaultKeyRequest{VaultKey: &g.VaultKey{
+			WrappedKey: []byte("wrapped"),
+			KdfSalt:    []byte("salt"),
+			KdfTime:    3,
+			KdfMemory:  65536,
+			KdfThreads: 256,
+		}})
+		require.ErrorIs(t, err, encryption.ErrInvalidKDFParams)
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		_, err := rpc.SetVaultKey(context.Background(), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestUpdateItems(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		itemID := uuid.New()
+		blobID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		in := &g.UpdateItemsRequest{}
+		expectedUser := &g.User{
+			Login: "test",
+			Logins: []*g.LoginItem{
+				{
+					Login:    "one",
+					Password: []byte("two"),
+					Meta:     nil,
+				},
+			},
+			Cards: []*g.BankCardItem{
+				{
+					Number:           "",
+					Holder:           "",
+					Expires:          "",
+					CardSecurityCode: []byte("123"),
+					Meta:             nil,
+				},
+			},
+			Texts: []*g.TextItem{
+				{
+					Value: "some text",
+					Meta:  nil,
+				},
+			},
+			Binaries: []*g.BinaryItem{
+				{
+					Value: []byte("some bins"),
+					Meta:  nil,
+				},
+				{
+					Value: []byte("stored bins"),
+					Meta:  nil,
+				},
+			},
+		}
+		dbUser := &models.User{
+			Login: "test",
+		}
+		dbVault := &models.Vault{
+			Logins: []*models.LoginPasswordItem{
+				{
+					Login:    "one",
+					Password: []byte("two"),
+					Meta:     nil,
+				},
+			},
+			BankCards: []*models.BankCardItem{
+				{
+					Number:           "",
+					Holder:           "",
+					Expires:          "",
+					CardSecurityCode: []byte("123"),
+					Meta:             nil,
+				},
+			},
+			Texts: []*models.TextItem{
+				{
+					Value: "some text",
+					Meta:  nil,
+				},
+			},
+			Binaries: []*models.BinaryItem{
+				{
+					Value: []byte("some bins"),
+					Meta:  nil,
+				},
+				{
+					BlobID: blobID,
+					Size:   11,
+					Meta:   nil,
+				},
+			},
+			Items: []*models.Item{
+				{
+					ID:       itemID,
+					Type:     models.ItemTypeText,
+					Revision: 1,
+					Payload:  []byte("ciphertext"),
+				},
+			},
+		}
+
+		read := mr.EXPECT().
+			ReadUserByID(
+				ctx,
+				gomock.Eq(uid),
+			).Return(dbUser, nil)
+		readItems := mr.EXPECT().
+			ReadItems(
+				ctx,
+				gomock.Eq(uid),
+			).Return(dbVault, nil)
+		openBlob := mr.EXPECT().
+			OpenBlob(ctx, gomock.Eq(uid), gomock.Eq(blobID), gomock.Eq(int64(0))).
+			Return(io.NopCloser(strings.NewReader("stored bins")), nil)
+		gomock.InOrder(read, readItems, openBlob)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		out, err := rpc.UpdateItems(ctx, in)
+		require.NoError(t, err)
+		require.Equal(t, "", out.Error)
+		require.Equal(t, expectedUser, out.User)
+		require.Len(t, out.Items, 1)
+		require.Equal(t, itemID.String(), out.Items[0].Id)
+		require.Equal(t, g.ItemType_ITEM_TYPE_TEXT, out.Items[0].Type)
+		require.Equal(t, []byte("ciphertext"), out.Items[0].Payload)
+	})
+
+	t.Run("read err", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		in := &g.UpdateItemsRequest{}
+
+		read := mr.EXPECT().
+			ReadUserByID(
+				ctx,
+				gomock.Eq(uid),
+			).Return(nil, fmt.Errorf("some err"))
+		gomock.InOrder(read)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		_, err := rpc.UpdateItems(ctx, in)
+		require.Error(t, err)
+	})
+
+	t.Run("read items err", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		in := &g.UpdateItemsRequest{}
+
+		read := mr.EXPECT().
+			ReadUserByID(
+				ctx,
+				gomock.Eq(uid),
+			).Return(&models.User{Login: "test"}, nil)
+		readItems := mr.EXPECT().
+			ReadItems(
+				ctx,
+				gomock.Eq(uid),
+			).Return(nil, fmt.Errorf("some err"))
+		gomock.InOrder(read, readItems)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		_, err := rpc.UpdateItems(ctx, in)
+		require.Error(t, err)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		in := &g.UpdateItemsRequest{}
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		_, err := rpc.UpdateItems(context.Background(), in)
+		require.ErrorIs(t, err, ErrUnauthenticated)
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		_, err := rpc.UpdateItems(context.Background(), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestSync(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		itemID := uuid.New()
+		deletedID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		read := mr.EXPECT().
+			ReadItemsSince(ctx, gomock.Eq(uid), int64(3)).
+			Return(&models.Vault{
+				Revision: 5,
+				Items: []*models.Item{
+					{
+						ID:       itemID,
+						Type:     models.ItemTypeLogin,
+						Revision: 4,
+						Payload:  []byte("ciphertext"),
+					},
+				},
+				Deleted: []uuid.UUID{deletedID},
+			}, nil)
+		gomock.InOrder(read)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		out, err := rpc.Sync(ctx, &g.SyncRequest{SinceRevision: 3})
+		require.NoError(t, err)
+		require.Equal(t, "", out.Error)
+		require.Equal(t, int64(5), out.Revision)
+		require.Len(t, out.Items, 1)
+		require.Equal(t, itemID.String(), out.Items[0].Id)
+		require.Equal(t, int64(4), out.Items[0].Revision)
+		require.Equal(t, []string{deletedID.String()}, out.Deleted)
+	})
+
+	t.Run("read err", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		read := mr.EXPECT().
+			ReadItemsSince(ctx, gomock.Eq(uid), int64(0)).
+			Return(nil, fmt.Errorf("some err"))
+		gomock.InOrder(read)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		_, err := rpc.Sync(ctx, &g.SyncRequest{})
+		require.Error(t, err)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		_, err := rpc.Sync(context.Background(), &g.SyncRequest{})
+		require.ErrorIs(t, err, ErrUnauthenticated)
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+		}
+		_, err := rpc.Sync(context.Background(), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestAddItem(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+	ms := mocks.NewMockService(ctrl)
+	ms.EXPECT().ReserveItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	ms.EXPECT().ReleaseItem(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		in := &g.AddItemRequest{
+			Item: &g.Item{
+				Type:    g.ItemType_ITEM_TYPE_LOGIN,
+				Payload: []byte("ciphertext"),
+			},
+		}
+
+		var stored *models.Item
+		create := mr.EXPECT().
+			CreateItem(
+				ctx,
+				gomock.Any(),
+				"items",
+				gomock.Eq(uid),
+				gomock.Any(),
+			).DoAndReturn(func(_ context.Context, item interface{}, _ string, _ uuid.UUID, _ int64) error {
+			stored = item.(*models.Item)
+			return nil
+		})
+		audit := ms.EXPECT().Audit(ctx, gomock.Eq(uid), models.AuditItemCreated, gomock.Any())
+		gomock.InOrder(create, audit)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		out, err := rpc.AddItem(ctx, in)
+		require.NoError(t, err)
+		require.Equal(t, "", out.Error)
+		require.Equal(t, stored.ID.String(), out.Id)
+		require.Equal(t, models.ItemTypeLogin, stored.Type)
+		require.Equal(t, []byte("ciphertext"), stored.Payload)
+	})
+
+	t.Run("repo err", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		in := &g.AddItemRequest{
+			Item: &g.Item{
+				Type:    g.ItemType_ITEM_TYPE_TEXT,
+				Payload: []byte("ciphertext"),
+			},
+		}
+
+		create := mr.EXPECT().
+			CreateItem(
+				ctx,
+				gomock.Any(),
+				"items",
+				gomock.Eq(uid),
+				gomock.Any(),
+			).Return(fmt.Errorf("some err"))
+		gomock.InOrder(create)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		_, err := rpc.AddItem(ctx, in)
+		require.Error(t, err)
+	})
+
+	t.Run("already added", func(t *testing.T) {
+		uid := uuid.New()
+		itemID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		in := &g.AddItemRequest{
+			Item: &g.Item{
+				Id:      itemID.String(),
+				Type:    g.ItemType_ITEM_TYPE_TEXT,
+				Payload: []byte("ciphertext"),
+			},
+		}
+
+		create := mr.EXPECT().
+			CreateItem(ctx, gomock.Any(), "items", gomock.Eq(uid), gomock.Any()).
+			DoAndReturn(func(_ context.Context, item interface{}, _ string, _ uuid.UUID, _ int64) error {
+				require.Equal(t, itemID, item.(*models.Item).ID)
+				return repository.ErrItemExists
+			})
+		gomock.InOrder(create)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		out, err := rpc.AddItem(ctx, in)
+		require.NoError(t, err)
+		require.Equal(t, itemID.String(), out.Id)
+	})
+
+	t.Run("wrong uuid", func(t *testing.T) {
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uuid.New()})
+		in := &g.AddItemRequest{
+			Item: &g.Item{
+				Id:      "k3j4n kj",
+				Type:    g.ItemType_ITEM_TYPE_TEXT,
+				Payload: []byte("ciphertext"),
+			},
+		}
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		_, err := rpc.AddItem(ctx, in)
+		require.ErrorIs(t, err, ErrInvalidID)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uuid.New()})
+		in := &g.AddItemRequest{
+			Item: &g.Item{
+				Payload: []byte("ciphertext"),
+			},
+		}
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		_, err := rpc.AddItem(ctx, in)
+		require.ErrorIs(t, err, ErrUnknownItemType)
+	})
+
+	t.Run("empty payload", func(t *testing.T) {
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uuid.New()})
+		in := &g.AddItemRequest{
+			Item: &g.Item{
+				Type: g.ItemType_ITEM_TYPE_TEXT,
+			},
+		}
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		_, err := rpc.AddItem(ctx, in)
+		require.ErrorIs(t, err, ErrNilArgument)
 	})
 
 	t.Run("nil request", func(t *testing.T) {
 		rpc := &RPC{
 			logger: logger,
+			repo:   mr,
 			svc:    ms,
 		}
-		_, err := rpc.SignUpUser(context.Background(), nil)
+		_, err := rpc.AddItem(context.Background(), nil)
 		require.Error(t, err)
 	})
 }
 
-func TestLoginUser(t *testing.T) {
+func TestUpdateItem(t *testing.T) {
 	output := zerolog.ConsoleWriter{
 		Out:        os.Stdout,
 		TimeFormat: "02-01-2006 15:04:05 MST",
 	}
 	logger := zerolog.New(output).With().Timestamp().Logger()
 	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
 	ms := mocks.NewMockService(ctrl)
+	ms.EXPECT().ReserveItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	ms.EXPECT().ReleaseItem(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	ms.EXPECT().ReserveItemUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil).AnyTimes()
+	ms.EXPECT().SettleItemUpdate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	ms.EXPECT().CancelItemUpdate(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 
 	t.Run("success", func(t *testing.T) {
-		in := &g.LoginUserRequest{
-			User: &g.User{
-				Login:    "test",
-				Password: "somepwd",
+		uid := uuid.New()
+		itemID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		in := &g.UpdateItemRequest{
+			Item: &g.Item{
+				Id:      itemID.String(),
+				Payload: []byte("ciphertext"),
 			},
+			ExpectedRevision: 4,
 		}
 
-		login := ms.EXPECT().
-			LoginUser(context.Background(), gomock.Any()).
-			Return("uid", nil)
-		gomock.InOrder(login)
+		update := mr.EXPECT().
+			UpdateItem(ctx, gomock.Eq(uid), gomock.Any(), gomock.Eq(int64(10)), gomock.Eq(int64(4))).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, item *models.Item, _ int64, _ int64) (int64, error) {
+				require.Equal(t, itemID, item.ID)
+				require.Equal(t, []byte("ciphertext"), item.Payload)
+				item.Revision = 5
+				return 3, nil
+			})
+		audit := ms.EXPECT().Audit(ctx, gomock.Eq(uid), models.AuditItemUpdated, gomock.Any())
+		gomock.InOrder(update, audit)
 
 		rpc := &RPC{
 			logger: logger,
+			repo:   mr,
 			svc:    ms,
 		}
-		out, err := rpc.LoginUser(context.Background(), in)
+		out, err := rpc.UpdateItem(ctx, in)
 		require.NoError(t, err)
-		require.Equal(t, "uid", out.UserID)
+		require.Equal(t, "", out.Error)
+		require.Equal(t, int64(5), out.Revision)
 	})
 
-	t.Run("login err", func(t *testing.T) {
-		in := &g.LoginUserRequest{
-			User: &g.User{
-				Login:    "test",
-				Password: "somepwd",
+	t.Run("conflict", func(t *testing.T) {
+		uid := uuid.New()
+		itemID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		in := &g.UpdateItemRequest{
+			Item: &g.Item{
+				Id:      itemID.String(),
+				Payload: []byte("ciphertext"),
 			},
+			ExpectedRevision: 4,
 		}
 
-		login := ms.EXPECT().
-			LoginUser(context.Background(), gomock.Any()).
-			Return("", fmt.Errorf("some err"))
-		gomock.InOrder(login)
+		update := mr.EXPECT().
+			UpdateItem(ctx, gomock.Eq(uid), gomock.Any(), gomock.Any(), gomock.Eq(int64(4))).
+			Return(int64(0), repository.ErrRevisionConflict)
+		read := mr.EXPECT().
+			ReadItem(ctx, gomock.Eq(uid), gomock.Eq(itemID)).
+			Return(&models.Item{
+				ID:       itemID,
+				Type:     models.ItemTypeText,
+				Revision: 6,
+				Payload:  []byte("theirs"),
+			}, nil)
+		gomock.InOrder(update, read)
 
 		rpc := &RPC{
 			logger: logger,
+			repo:   mr,
 			svc:    ms,
 		}
-		_, err := rpc.LoginUser(context.Background(), in)
+		out, err := rpc.UpdateItem(ctx, in)
+		require.NoError(t, err)
+		require.True(t, out.Conflict)
+		require.Equal(t, repository.ErrRevisionConflict.Error(), out.Error)
+		require.Equal(t, int64(6), out.Current.Revision)
+		require.Equal(t, []byte("theirs"), out.Current.Payload)
+	})
+
+	t.Run("no item", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		in := &g.UpdateItemRequest{
+			Item: &g.Item{
+				Id:      uuid.New().String(),
+				Payload: []byte("ciphertext"),
+			},
+		}
+
+		mr.EXPECT().
+			UpdateItem(ctx, gomock.Eq(uid), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(int64(0), repository.ErrNoItem)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		out, err := rpc.UpdateItem(ctx, in)
+		require.ErrorIs(t, err, repository.ErrNoItem)
+		require.Equal(t, repository.ErrNoItem.Error(), out.Error)
+	})
+
+	t.Run("wrong uuid", func(t *testing.T) {
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uuid.New()})
+		in := &g.UpdateItemRequest{
+			Item: &g.Item{
+				Id:      "k3j4n kj",
+				Payload: []byte("ciphertext"),
+			},
+		}
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		_, err := rpc.UpdateItem(ctx, in)
 		require.Error(t, err)
 	})
 
 	t.Run("nil request", func(t *testing.T) {
 		rpc := &RPC{
 			logger: logger,
+			repo:   mr,
 			svc:    ms,
 		}
-		_, err := rpc.LoginUser(context.Background(), nil)
+		_, err := rpc.UpdateItem(context.Background(), nil)
 		require.Error(t, err)
 	})
 }
 
-func TestUpdateItems(t *testing.T) {
+func TestDeleteItem(t *testing.T) {
 	output := zerolog.ConsoleWriter{
 		Out:        os.Stdout,
 		TimeFormat: "02-01-2006 15:04:05 MST",
@@ -158,123 +1324,56 @@ func TestUpdateItems(t *testing.T) {
 	logger := zerolog.New(output).With().Timestamp().Logger()
 	ctrl := gomock.NewController(t)
 	mr := mocks.NewMockRepository(ctrl)
+	ms := mocks.NewMockService(ctrl)
 
 	t.Run("success", func(t *testing.T) {
 		uid := uuid.New()
-		in := &g.UpdateItemsRequest{
-			UserID: uid.String(),
-		}
-		expectedUser := &g.User{
-			Login: "test",
-			Logins: []*g.LoginItem{
-				{
-					Login:    "one",
-					Password: []byte("two"),
-					Meta:     nil,
-				},
-			},
-			Cards: []*g.BankCardItem{
-				{
-					Number:           "",
-					Holder:           "",
-					Expires:          "",
-					CardSecurityCode: []byte("123"),
-					Meta:             nil,
-				},
-			},
-			Texts: []*g.TextItem{
-				{
-					Value: "some text",
-					Meta:  nil,
-				},
-			},
-			Binaries: []*g.BinaryItem{
-				{
-					Value: []byte("some bins"),
-					Meta:  nil,
-				},
-			},
-		}
-		dbUser := &models.User{
-			Login: "test",
-			Logins: []*models.LoginPasswordItem{
-				{
-					Login:    "one",
-					Password: []byte("two"),
-					Meta:     nil,
-				},
-			},
-			BankCards: []*models.BankCardItem{
-				{
-					Number:           "",
-					Holder:           "",
-					Expires:          "",
-					CardSecurityCode: []byte("123"),
-					Meta:             nil,
-				},
-			},
-			Texts: []*models.TextItem{
-				{
-					Value: "some text",
-					Meta:  nil,
-				},
-			},
-			Binaries: []*models.BinaryItem{
-				{
-					Value: []byte("some bins"),
-					Meta:  nil,
-				},
-			},
-		}
+		itemID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
 
-		read := mr.EXPECT().
-			ReadUserByID(
-				context.Background(),
-				gomock.Eq(uid),
-			).Return(dbUser, nil)
-		gomock.InOrder(read)
+		del := mr.EXPECT().
+			DeleteItem(ctx, gomock.Eq(uid), gomock.Eq(itemID), gomock.Any()).
+			Return(int64(42), nil)
+		release := ms.EXPECT().ReleaseItem(ctx, gomock.Eq(uid), gomock.Eq(int64(42)))
+		audit := ms.EXPECT().Audit(ctx, gomock.Eq(uid), models.AuditItemDeleted, gomock.Any())
+		gomock.InOrder(del, release, audit)
 
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
-		out, err := rpc.UpdateItems(context.Background(), in)
+		out, err := rpc.DeleteItem(ctx, &g.DeleteItemRequest{Id: itemID.String()})
 		require.NoError(t, err)
 		require.Equal(t, "", out.Error)
-		require.Equal(t, expectedUser, out.User)
 	})
 
-	t.Run("read err", func(t *testing.T) {
+	t.Run("no item", func(t *testing.T) {
 		uid := uuid.New()
-		in := &g.UpdateItemsRequest{
-			UserID: uid.String(),
-		}
+		itemID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
 
-		read := mr.EXPECT().
-			ReadUserByID(
-				context.Background(),
-				gomock.Eq(uid),
-			).Return(nil, fmt.Errorf("some err"))
-		gomock.InOrder(read)
+		del := mr.EXPECT().
+			DeleteItem(ctx, gomock.Eq(uid), gomock.Eq(itemID), gomock.Any()).
+			Return(int64(0), repository.ErrNoItem)
+		gomock.InOrder(del)
 
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
 		}
-		_, err := rpc.UpdateItems(context.Background(), in)
-		require.Error(t, err)
+		_, err := rpc.DeleteItem(ctx, &g.DeleteItemRequest{Id: itemID.String()})
+		require.ErrorIs(t, err, repository.ErrNoItem)
 	})
 
-	t.Run("wrong uid", func(t *testing.T) {
-		in := &g.UpdateItemsRequest{
-			UserID: "asdkjnfklasj lkmndjkl fna",
-		}
+	t.Run("wrong uuid", func(t *testing.T) {
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uuid.New()})
 
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
 		}
-		_, err := rpc.UpdateItems(context.Background(), in)
+		_, err := rpc.DeleteItem(ctx, &g.DeleteItemRequest{Id: "k3j4n kj"})
 		require.Error(t, err)
 	})
 
@@ -283,7 +1382,7 @@ func TestUpdateItems(t *testing.T) {
 			logger: logger,
 			repo:   mr,
 		}
-		_, err := rpc.UpdateItems(context.Background(), nil)
+		_, err := rpc.DeleteItem(context.Background(), nil)
 		require.Error(t, err)
 	})
 }
@@ -296,9 +1395,13 @@ func TestAddLoginItem(t *testing.T) {
 	logger := zerolog.New(output).With().Timestamp().Logger()
 	ctrl := gomock.NewController(t)
 	mr := mocks.NewMockRepository(ctrl)
+	ms := mocks.NewMockService(ctrl)
+	ms.EXPECT().ReserveItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	ms.EXPECT().ReleaseItem(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 
 	t.Run("success", func(t *testing.T) {
 		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
 		in := &g.AddLoginItemRequest{
 			Item: &g.LoginItem{
 				Login:    "test",
@@ -308,29 +1411,32 @@ func TestAddLoginItem(t *testing.T) {
 					"three": "four",
 				},
 			},
-			UserID: uid.String(),
 		}
 
 		create := mr.EXPECT().
 			CreateItem(
-				context.Background(),
+				ctx,
 				gomock.Any(),
 				"logins",
 				gomock.Eq(uid),
+				gomock.Any(),
 			).Return(nil)
-		gomock.InOrder(create)
+		audit := ms.EXPECT().Audit(ctx, gomock.Eq(uid), models.AuditItemCreated, gomock.Any())
+		gomock.InOrder(create, audit)
 
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
-		out, err := rpc.AddLoginItem(context.Background(), in)
+		out, err := rpc.AddLoginItem(ctx, in)
 		require.NoError(t, err)
 		require.Equal(t, "", out.Error)
 	})
 
 	t.Run("repo err", func(t *testing.T) {
 		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
 		in := &g.AddLoginItemRequest{
 			Item: &g.LoginItem{
 				Login:    "test",
@@ -340,27 +1446,28 @@ func TestAddLoginItem(t *testing.T) {
 					"three": "four",
 				},
 			},
-			UserID: uid.String(),
 		}
 
 		create := mr.EXPECT().
 			CreateItem(
-				context.Background(),
+				ctx,
 				gomock.Any(),
 				"logins",
 				gomock.Eq(uid),
+				gomock.Any(),
 			).Return(fmt.Errorf("some err"))
 		gomock.InOrder(create)
 
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
-		_, err := rpc.AddLoginItem(context.Background(), in)
+		_, err := rpc.AddLoginItem(ctx, in)
 		require.Error(t, err)
 	})
 
-	t.Run("wrong uuid", func(t *testing.T) {
+	t.Run("unauthenticated", func(t *testing.T) {
 		in := &g.AddLoginItemRequest{
 			Item: &g.LoginItem{
 				Login:    "test",
@@ -370,21 +1477,41 @@ func TestAddLoginItem(t *testing.T) {
 					"three": "four",
 				},
 			},
-			UserID: "o413ngklj3 gk j324kg j4 ",
 		}
 
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
 		_, err := rpc.AddLoginItem(context.Background(), in)
-		require.Error(t, err)
+		require.ErrorIs(t, err, ErrUnauthenticated)
+	})
+
+	t.Run("zero knowledge", func(t *testing.T) {
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uuid.New()})
+		in := &g.AddLoginItemRequest{
+			Item: &g.LoginItem{
+				Login:    "test",
+				Password: []byte("somepwd"),
+			},
+		}
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		rpc.cfg.ZeroKnowledge = true
+		_, err := rpc.AddLoginItem(ctx, in)
+		require.ErrorIs(t, err, ErrPlaintextItems)
 	})
 
 	t.Run("nil request", func(t *testing.T) {
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
 		_, err := rpc.AddLoginItem(context.Background(), nil)
 		require.Error(t, err)
@@ -399,9 +1526,13 @@ func TestAddBankCardItem(t *testing.T) {
 	logger := zerolog.New(output).With().Timestamp().Logger()
 	ctrl := gomock.NewController(t)
 	mr := mocks.NewMockRepository(ctrl)
+	ms := mocks.NewMockService(ctrl)
+	ms.EXPECT().ReserveItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	ms.EXPECT().ReleaseItem(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 
 	t.Run("success", func(t *testing.T) {
 		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
 		in := &g.AddBankCardItemRequest{
 			Item: &g.BankCardItem{
 				Number:           "",
@@ -413,29 +1544,32 @@ func TestAddBankCardItem(t *testing.T) {
 					"three": "four",
 				},
 			},
-			UserID: uid.String(),
 		}
 
 		create := mr.EXPECT().
 			CreateItem(
-				context.Background(),
+				ctx,
 				gomock.Any(),
 				"cards",
 				gomock.Eq(uid),
+				gomock.Any(),
 			).Return(nil)
-		gomock.InOrder(create)
+		audit := ms.EXPECT().Audit(ctx, gomock.Eq(uid), models.AuditItemCreated, gomock.Any())
+		gomock.InOrder(create, audit)
 
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
-		out, err := rpc.AddBankCardItem(context.Background(), in)
+		out, err := rpc.AddBankCardItem(ctx, in)
 		require.NoError(t, err)
 		require.Equal(t, "", out.Error)
 	})
 
 	t.Run("repo err", func(t *testing.T) {
 		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
 		in := &g.AddBankCardItemRequest{
 			Item: &g.BankCardItem{
 				Number:           "",
@@ -447,27 +1581,28 @@ func TestAddBankCardItem(t *testing.T) {
 					"three": "four",
 				},
 			},
-			UserID: uid.String(),
 		}
 
 		create := mr.EXPECT().
 			CreateItem(
-				context.Background(),
+				ctx,
 				gomock.Any(),
 				"cards",
 				gomock.Eq(uid),
+				gomock.Any(),
 			).Return(fmt.Errorf("some err"))
 		gomock.InOrder(create)
 
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
-		_, err := rpc.AddBankCardItem(context.Background(), in)
+		_, err := rpc.AddBankCardItem(ctx, in)
 		require.Error(t, err)
 	})
 
-	t.Run("wrong uuid", func(t *testing.T) {
+	t.Run("unauthenticated", func(t *testing.T) {
 		in := &g.AddBankCardItemRequest{
 			Item: &g.BankCardItem{
 				Number:           "",
@@ -479,21 +1614,22 @@ func TestAddBankCardItem(t *testing.T) {
 					"three": "four",
 				},
 			},
-			UserID: "l 23knkl;jn t",
 		}
 
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
 		_, err := rpc.AddBankCardItem(context.Background(), in)
-		require.Error(t, err)
+		require.ErrorIs(t, err, ErrUnauthenticated)
 	})
 
 	t.Run("nil request", func(t *testing.T) {
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
 		_, err := rpc.AddBankCardItem(context.Background(), nil)
 		require.Error(t, err)
@@ -508,9 +1644,13 @@ func TestAddTextItem(t *testing.T) {
 	logger := zerolog.New(output).With().Timestamp().Logger()
 	ctrl := gomock.NewController(t)
 	mr := mocks.NewMockRepository(ctrl)
+	ms := mocks.NewMockService(ctrl)
+	ms.EXPECT().ReserveItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	ms.EXPECT().ReleaseItem(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 
 	t.Run("success", func(t *testing.T) {
 		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
 		in := &g.AddTextItemRequest{
 			Item: &g.TextItem{
 				Value: "some text",
@@ -519,29 +1659,32 @@ func TestAddTextItem(t *testing.T) {
 					"three": "four",
 				},
 			},
-			UserID: uid.String(),
 		}
 
 		create := mr.EXPECT().
 			CreateItem(
-				context.Background(),
+				ctx,
 				gomock.Any(),
 				"texts",
 				gomock.Eq(uid),
+				gomock.Any(),
 			).Return(nil)
-		gomock.InOrder(create)
+		audit := ms.EXPECT().Audit(ctx, gomock.Eq(uid), models.AuditItemCreated, gomock.Any())
+		gomock.InOrder(create, audit)
 
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
-		out, err := rpc.AddTextItem(context.Background(), in)
+		out, err := rpc.AddTextItem(ctx, in)
 		require.NoError(t, err)
 		require.Equal(t, "", out.Error)
 	})
 
 	t.Run("repo err", func(t *testing.T) {
 		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
 		in := &g.AddTextItemRequest{
 			Item: &g.TextItem{
 				Value: "some text",
@@ -550,27 +1693,28 @@ func TestAddTextItem(t *testing.T) {
 					"three": "four",
 				},
 			},
-			UserID: uid.String(),
 		}
 
 		create := mr.EXPECT().
 			CreateItem(
-				context.Background(),
+				ctx,
 				gomock.Any(),
 				"texts",
 				gomock.Eq(uid),
+				gomock.Any(),
 			).Return(fmt.Errorf("some err"))
 		gomock.InOrder(create)
 
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
-		_, err := rpc.AddTextItem(context.Background(), in)
+		_, err := rpc.AddTextItem(ctx, in)
 		require.Error(t, err)
 	})
 
-	t.Run("wrong uuid", func(t *testing.T) {
+	t.Run("unauthenticated", func(t *testing.T) {
 		in := &g.AddTextItemRequest{
 			Item: &g.TextItem{
 				Value: "some text",
@@ -579,21 +1723,22 @@ func TestAddTextItem(t *testing.T) {
 					"three": "four",
 				},
 			},
-			UserID: "3ljnr k2jn3 ",
 		}
 
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
 		_, err := rpc.AddTextItem(context.Background(), in)
-		require.Error(t, err)
+		require.ErrorIs(t, err, ErrUnauthenticated)
 	})
 
 	t.Run("nil request", func(t *testing.T) {
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
 		_, err := rpc.AddTextItem(context.Background(), nil)
 		require.Error(t, err)
@@ -608,9 +1753,14 @@ func TestAddBinaryItem(t *testing.T) {
 	logger := zerolog.New(output).With().Timestamp().Logger()
 	ctrl := gomock.NewController(t)
 	mr := mocks.NewMockRepository(ctrl)
+	ms := mocks.NewMockService(ctrl)
+	ms.EXPECT().ReserveItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	ms.EXPECT().ReleaseItem(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	ms.EXPECT().CountBlob(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 
 	t.Run("success", func(t *testing.T) {
 		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
 		in := &g.AddBinaryItemRequest{
 			Item: &g.BinaryItem{
 				Value: []byte("some text"),
@@ -619,29 +1769,48 @@ func TestAddBinaryItem(t *testing.T) {
 					"three": "four",
 				},
 			},
-			UserID: uid.String(),
 		}
 
+		blobID := uuid.New()
+		store := mr.EXPECT().
+			StoreBlob(ctx, gomock.Eq(uid), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, content io.Reader) (*models.Blob, error) {
+				value, err := io.ReadAll(content)
+				require.NoError(t, err)
+				require.Equal(t, []byte("some text"), value)
+				return &models.Blob{ID: blobID, Size: 9, Hash: "hash", Complete: true}, nil
+			})
 		create := mr.EXPECT().
 			CreateItem(
-				context.Background(),
+				ctx,
 				gomock.Any(),
 				"binaries",
 				gomock.Eq(uid),
-			).Return(nil)
-		gomock.InOrder(create)
+				gomock.Any(),
+			).DoAndReturn(func(_ context.Context, item interface{}, _ string, _ uuid.UUID, _ int64) error {
+			bin := item.(*models.BinaryItem)
+			require.Nil(t, bin.Value)
+			require.Equal(t, blobID, bin.BlobID)
+			require.Equal(t, int64(9), bin.Size)
+			require.Equal(t, "hash", bin.Hash)
+			return nil
+		})
+		audit := ms.EXPECT().Audit(ctx, gomock.Eq(uid), models.AuditItemCreated, gomock.Any())
+		gomock.InOrder(store, create, audit)
 
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
-		out, err := rpc.AddBinaryItem(context.Background(), in)
+		out, err := rpc.AddBinaryItem(ctx, in)
 		require.NoError(t, err)
 		require.Equal(t, "", out.Error)
 	})
 
 	t.Run("repo err", func(t *testing.T) {
 		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
 		in := &g.AddBinaryItemRequest{
 			Item: &g.BinaryItem{
 				Value: []byte("some text"),
@@ -650,27 +1819,31 @@ func TestAddBinaryItem(t *testing.T) {
 					"three": "four",
 				},
 			},
-			UserID: uid.String(),
 		}
 
+		store := mr.EXPECT().
+			StoreBlob(ctx, gomock.Eq(uid), gomock.Any()).
+			Return(&models.Blob{ID: uuid.New(), Complete: true}, nil)
 		create := mr.EXPECT().
 			CreateItem(
-				context.Background(),
+				ctx,
 				gomock.Any(),
 				"binaries",
 				gomock.Eq(uid),
+				gomock.Any(),
 			).Return(fmt.Errorf("some err"))
-		gomock.InOrder(create)
+		gomock.InOrder(store, create)
 
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
-		_, err := rpc.AddBinaryItem(context.Background(), in)
+		_, err := rpc.AddBinaryItem(ctx, in)
 		require.Error(t, err)
 	})
 
-	t.Run("wrong uuid", func(t *testing.T) {
+	t.Run("unauthenticated", func(t *testing.T) {
 		in := &g.AddBinaryItemRequest{
 			Item: &g.BinaryItem{
 				Value: []byte("some text"),
@@ -679,23 +1852,156 @@ func TestAddBinaryItem(t *testing.T) {
 					"three": "four",
 				},
 			},
-			UserID: "uid.String()",
 		}
 
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
 		_, err := rpc.AddBinaryItem(context.Background(), in)
-		require.Error(t, err)
+		require.ErrorIs(t, err, ErrUnauthenticated)
 	})
 
 	t.Run("nil request", func(t *testing.T) {
 		rpc := &RPC{
 			logger: logger,
 			repo:   mr,
+			svc:    ms,
 		}
 		_, err := rpc.AddBinaryItem(context.Background(), nil)
 		require.Error(t, err)
 	})
 }
+
+func TestAuthInterceptor(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mt := mocks.NewMockManager(ctrl)
+	ms := mocks.NewMockService(ctrl)
+	info := &grpc.UnaryServerInfo{FullMethod: "/proto.server.Gokeeper/UpdateItems"}
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := metadata.NewIncomingContext(
+			context.Background(),
+			metadata.Pairs("authorization", "Bearer token"),
+		)
+
+		sid := uuid.New()
+		parse := mt.EXPECT().
+			ParseAccessToken("token").
+			Return(auth.Claims{UserID: uid, SessionID: sid}, nil)
+		validate := ms.EXPECT().
+			ValidateSession(ctx, gomock.Eq(uid), gomock.Eq(sid)).
+			Return(nil)
+		gomock.InOrder(parse, validate)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+			tokens: mt,
+		}
+		_, err := rpc.AuthInterceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			userID, ok := auth.UserIDFromContext(ctx)
+			require.True(t, ok)
+			require.Equal(t, uid, userID)
+			return nil, nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(
+			context.Background(),
+			metadata.Pairs("authorization", "Bearer token"),
+		)
+
+		parse := mt.EXPECT().
+			ParseAccessToken("token").
+			Return(auth.Claims{}, auth.ErrInvalidToken)
+		gomock.InOrder(parse)
+
+		rpc := &RPC{
+			logger: logger,
+			tokens: mt,
+		}
+		_, err := rpc.AuthInterceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			t.Fatal("handler must not be called")
+			return nil, nil
+		})
+		require.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("revoked session", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(
+			context.Background(),
+			metadata.Pairs("authorization", "Bearer token"),
+		)
+		claims := auth.Claims{UserID: uuid.New(), SessionID: uuid.New()}
+
+		parse := mt.EXPECT().
+			ParseAccessToken("token").
+			Return(claims, nil)
+		validate := ms.EXPECT().
+			ValidateSession(ctx, gomock.Eq(claims.UserID), gomock.Eq(claims.SessionID)).
+			Return(service.ErrInvalidSession)
+		gomock.InOrder(parse, validate)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+			tokens: mt,
+		}
+		_, err := rpc.AuthInterceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			t.Fatal("handler must not be called")
+			return nil, nil
+		})
+		require.ErrorIs(t, err, service.ErrInvalidSession)
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			tokens: mt,
+		}
+		_, err := rpc.AuthInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			t.Fatal("handler must not be called")
+			return nil, nil
+		})
+		require.ErrorIs(t, err, ErrUnauthenticated)
+	})
+
+	t.Run("public method", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			tokens: mt,
+		}
+		public := &grpc.UnaryServerInfo{FullMethod: "/proto.server.Gokeeper/LoginUser"}
+		called := false
+		_, err := rpc.AuthInterceptor(context.Background(), nil, public, func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return nil, nil
+		})
+		require.NoError(t, err)
+		require.True(t, called)
+	})
+}
+
+func TestClose(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	ctx := context.Background()
+	mr.EXPECT().Close(ctx).Return(nil)
+
+	rpc := &RPC{
+		logger: zerolog.Nop(),
+		repo:   mr,
+	}
+	require.NoError(t, rpc.Close(ctx))
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/auth"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/service"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/logging"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// authorizationHeader is the metadata key access token is passed with.
+	authorizationHeader = "authorization"
+	// bearerPrefix precedes access token in authorization header.
+	bearerPrefix = "Bearer "
+)
+
+// publicMethods holds gRPC methods which can be called without access token.
+var publicMethods = map[string]struct{}{
+	"/proto.server.Gokeeper/SignUpUser":     {},
+	"/proto.server.Gokeeper/LoginUser":      {},
+	"/proto.server.Gokeeper/RefreshSession": {},
+	"/grpc.health.v1.Health/Check":          {},
+	"/grpc.health.v1.Health/Watch":          {},
+}
+
+// AuthInterceptor validates access token provided in request metadata
+// and session it belongs to, putting authenticated user's claims
+// into the request context.
+func (r *RPC) AuthInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if _, ok := publicMethods[info.FullMethod]; ok {
+		return handler(ctx, req)
+	}
+
+	ctx, err := r.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// AuthStreamInterceptor does the same as AuthInterceptor for streaming methods.
+func (r *RPC) AuthStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if _, ok := publicMethods[info.FullMethod]; ok {
+		return handler(srv, ss)
+	}
+
+	ctx, err := r.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// ErrorInterceptor converts errors returned by handlers to gRPC statuses.
+func (r *RPC) ErrorInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	recordError(ctx, err)
+	return resp, r.statusError(ctx, info.FullMethod, err)
+}
+
+// ErrorStreamInterceptor does the same as ErrorInterceptor for streaming methods.
+func (r *RPC) ErrorStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	err := handler(srv, ss)
+	recordError(ss.Context(), err)
+	return r.statusError(ss.Context(), info.FullMethod, err)
+}
+
+// recordError attaches error returned by handler to RPC's span,
+// as status sent to client may hide its cause.
+func recordError(ctx context.Context, err error) {
+	if err != nil {
+		trace.SpanFromContext(ctx).RecordError(err)
+	}
+}
+
+// contextStream replaces context of the server stream with the one
+// enriched by interceptors, e.g. holding authenticated user's claims.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns context enriched by interceptors.
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate validates access token provided in request metadata
+// and session it belongs to, returning context with user's claims.
+func (r *RPC) authenticate(ctx context.Context, method string) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(authorizationHeader)) == 0 {
+		r.log(ctx).Info().Str("method", method).Msg("access token wasn't provided")
+		return nil, fmt.Errorf("%w: access token is required", ErrUnauthenticated)
+	}
+
+	token := strings.TrimPrefix(md.Get(authorizationHeader)[0], bearerPrefix)
+	claims, err := r.tokens.ParseAccessToken(token)
+	if err != nil {
+		r.log(ctx).Info().Str("method", method).Err(err).Msg("access token was rejected")
+		return nil, err
+	}
+
+	if err := r.svc.ValidateSession(ctx, claims.UserID, claims.SessionID); err != nil {
+		if err == service.ErrInvalidSession {
+			r.log(ctx).Info().Str("user", claims.UserID.String()).Str("method", method).Msg("session is no longer active")
+			return nil, err
+		}
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", claims.UserID.String()).
+			Msg("unable to validate session")
+		return nil, err
+	}
+
+	r.log(ctx).Debug().Str("user", claims.UserID.String()).Str("method", method).Msg("request was authenticated")
+	trace.SpanFromContext(ctx).SetAttributes(semconv.EnduserIDKey.String(claims.UserID.String()))
+	ctx = logging.WithLogger(ctx, r.log(ctx).With().Str("user", claims.UserID.String()).Logger())
+	return auth.ContextWithClaims(ctx, claims), nil
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/logging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDHeader is the metadata key request ID is passed with
+// by client and returned with in response header.
+const RequestIDHeader = "x-request-id"
+
+// validRequestID matches request IDs provided by clients which are accepted,
+// other ones are replaced, so they can't forge log lines.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestIDInterceptor assigns ID to every request, reusing the one provided
+// by client, puts logger carrying it and method name into the request context
+// and returns it to client in response header.
+func (r *RPC) RequestIDInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	ctx, id := r.withRequestID(ctx, info.FullMethod)
+	if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, id)); err != nil {
+		r.log(ctx).Debug().Err(err).Msg("unable to send request id")
+	}
+	return handler(ctx, req)
+}
+
+// RequestIDStreamInterceptor does the same as RequestIDInterceptor for streaming methods.
+func (r *RPC) RequestIDStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, id := r.withRequestID(ss.Context(), info.FullMethod)
+	if err := ss.SetHeader(metadata.Pairs(RequestIDHeader, id)); err != nil {
+		r.log(ctx).Debug().Err(err).Msg("unable to send request id")
+	}
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// withRequestID returns ID of the request and context carrying logger of the request.
+func (r *RPC) withRequestID(ctx context.Context, method string) (context.Context, string) {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDHeader); len(ids) > 0 && validRequestID.MatchString(ids[0]) {
+			id = ids[0]
+		}
+	}
+	if id == "" {
+		id = uuid.NewString()
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("rpc.request_id", id))
+	logger := r.logger.With().Str("request_id", id).Str("method", method).Logger()
+	return logging.WithLogger(ctx, logger), id
+}
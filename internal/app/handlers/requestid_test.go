@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRequestIDInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/proto.server.Gokeeper/GetItems"}
+
+	// call logs one line from handler and returns fields it was logged with
+	call := func(t *testing.T, ctx context.Context) map[string]interface{} {
+		var buf bytes.Buffer
+		rpc := &RPC{logger: zerolog.New(&buf).Level(zerolog.InfoLevel)}
+		_, err := rpc.RequestIDInterceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			rpc.log(ctx).Info().Msg("handling request")
+			return nil, nil
+		})
+		require.NoError(t, err)
+
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &fields))
+		require.Equal(t, info.FullMethod, fields["method"])
+		return fields
+	}
+
+	t.Run("generated", func(t *testing.T) {
+		fields := call(t, context.Background())
+		_, err := uuid.Parse(fields["request_id"].(string))
+		require.NoError(t, err)
+	})
+
+	t.Run("provided by client", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDHeader, "clt-42"))
+		fields := call(t, ctx)
+		require.Equal(t, "clt-42", fields["request_id"])
+	})
+
+	t.Run("invalid id is replaced", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDHeader, "forged\nline"))
+		fields := call(t, ctx)
+		require.NotEqual(t, "forged\nline", fields["request_id"])
+	})
+}
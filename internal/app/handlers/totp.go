@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/metrics"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/service"
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+)
+
+// EnableTOTP starts user's enrollment in two-factor authentication.
+func (r *RPC) EnableTOTP(ctx context.Context, in *g.EnableTOTPRequest) (*g.EnableTOTPResponse, error) {
+	if in == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.EnableTOTPResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.EnableTOTPResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("received two-factor authentication enrollment request")
+	res := new(g.EnableTOTPResponse)
+
+	secret, uri, err := r.svc.EnableTOTP(ctx, userID)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to start two-factor authentication enrollment")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	res.Secret = secret
+	res.Uri = uri
+	return res, nil
+}
+
+// ConfirmTOTP turns user's two-factor authentication on,
+// returning recovery codes.
+func (r *RPC) ConfirmTOTP(ctx context.Context, in *g.ConfirmTOTPRequest) (*g.ConfirmTOTPResponse, error) {
+	if in == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.ConfirmTOTPResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.ConfirmTOTPResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("received two-factor authentication confirmation request")
+	res := new(g.ConfirmTOTPResponse)
+
+	codes, err := r.svc.ConfirmTOTP(ctx, userID, in.Code)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to confirm two-factor authentication")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	res.RecoveryCodes = codes
+	return res, nil
+}
+
+// DisableTOTP turns user's two-factor authentication off.
+func (r *RPC) DisableTOTP(ctx context.Context, in *g.DisableTOTPRequest) (*g.DisableTOTPResponse, error) {
+	if in == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.DisableTOTPResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.DisableTOTPResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("received two-factor authentication disabling request")
+	res := new(g.DisableTOTPResponse)
+
+	if err := r.svc.DisableTOTP(ctx, userID, in.Code); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to disable two-factor authentication")
+		res.Error = err.Error()
+		return res, err
+	}
+	return res, nil
+}
+
+// completeLogin checks one-time code sent along with login challenge
+// and starts new session of the user challenge was issued for.
+func (r *RPC) completeLogin(ctx context.Context, in *g.LoginUserRequest) (*g.LoginUserResponse, error) {
+	r.log(ctx).Info().Msg("received one-time code of user login")
+	res := new(g.LoginUserResponse)
+
+	userID, err := r.tokens.ParseChallenge(in.Challenge)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to parse login challenge")
+		metrics.Logins.WithLabelValues(metrics.LoginFailure).Inc()
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("passing one-time code to service layer")
+	if err := r.svc.VerifyTOTP(ctx, userID, in.Code); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCode):
+			metrics.Logins.WithLabelValues(metrics.LoginFailure).Inc()
+		case errors.Is(err, service.ErrTooManyAttempts):
+			metrics.Logins.WithLabelValues(metrics.LoginBlocked).Inc()
+		default:
+			metrics.Logins.WithLabelValues(metrics.LoginError).Inc()
+		}
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to verify one-time code")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("starting new session")
+	token, expiresAt, refreshToken, err := r.startSession(ctx, userID.String(), in.Device)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to start new session")
+		metrics.Logins.WithLabelValues(metrics.LoginError).Inc()
+		res.Error = err.Error()
+		return res, err
+	}
+
+	metrics.Logins.WithLabelValues(metrics.LoginSuccess).Inc()
+	r.log(ctx).Info().Str("user", userID.String()).Msg("user was successfully logged in")
+	res.UserID = userID.String()
+	res.AccessToken = token
+	res.ExpiresAt = expiresAt
+	res.RefreshToken = refreshToken
+	return res, nil
+}
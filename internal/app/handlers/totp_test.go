@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/auth"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/metrics"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/mocks"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/service"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginUserTOTP(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	ms := mocks.NewMockService(ctrl)
+	mt := mocks.NewMockManager(ctrl)
+
+	t.Run("challenge", func(t *testing.T) {
+		in := &g.LoginUserRequest{
+			User: &g.User{
+				Login:    "test",
+				Password: "somepwd",
+			},
+		}
+		uid := uuid.New()
+
+		login := ms.EXPECT().
+			LoginUser(context.Background(), gomock.Any()).
+			Return(uid.String(), service.ErrTOTPRequired)
+		issue := mt.EXPECT().
+			IssueChallenge(gomock.Eq(uid)).
+			Return("challenge", nil)
+		gomock.InOrder(login, issue)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+			tokens: mt,
+		}
+		out, err := rpc.LoginUser(context.Background(), in)
+		require.NoError(t, err)
+		require.Equal(t, "challenge", out.Challenge)
+		require.Empty(t, out.AccessToken)
+		require.Empty(t, out.RefreshToken)
+	})
+
+	t.Run("code", func(t *testing.T) {
+		in := &g.LoginUserRequest{
+			Challenge: "challenge",
+			Code:      "123456",
+			Device:    "laptop",
+		}
+		uid := uuid.New()
+		session := &models.Session{ID: uuid.New(), UserID: uid}
+		expiresAt := time.Now().Add(time.Minute)
+
+		parse := mt.EXPECT().
+			ParseChallenge("challenge").
+			Return(uid, nil)
+		verify := ms.EXPECT().
+			VerifyTOTP(context.Background(), gomock.Eq(uid), "123456").
+			Return(nil)
+		create := ms.EXPECT().
+			CreateSession(context.Background(), gomock.Eq(uid), "laptop").
+			Return(session, "refresh", nil)
+		issue := mt.EXPECT().
+			IssueAccessToken(gomock.Eq(auth.Claims{UserID: uid, SessionID: session.ID})).
+			Return("token", expiresAt, nil)
+		gomock.InOrder(parse, verify, create, issue)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+			tokens: mt,
+		}
+		before := testutil.ToFloat64(metrics.Logins.WithLabelValues(metrics.LoginSuccess))
+		out, err := rpc.LoginUser(context.Background(), in)
+		require.NoError(t, err)
+		require.Equal(t, uid.String(), out.UserID)
+		require.Equal(t, "token", out.AccessToken)
+		require.Equal(t, "refresh", out.RefreshToken)
+		require.Equal(t, before+1, testutil.ToFloat64(metrics.Logins.WithLabelValues(metrics.LoginSuccess)))
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		in := &g.LoginUserRequest{
+			Challenge: "challenge",
+			Code:      "000000",
+		}
+		uid := uuid.New()
+
+		parse := mt.EXPECT().
+			ParseChallenge("challenge").
+			Return(uid, nil)
+		verify := ms.EXPECT().
+			VerifyTOTP(context.Background(), gomock.Eq(uid), "000000").
+			Return(service.ErrInvalidCode)
+		gomock.InOrder(parse, verify)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+			tokens: mt,
+		}
+		before := testutil.ToFloat64(metrics.Logins.WithLabelValues(metrics.LoginFailure))
+		_, err := rpc.LoginUser(context.Background(), in)
+		require.ErrorIs(t, err, service.ErrInvalidCode)
+		require.Equal(t, before+1, testutil.ToFloat64(metrics.Logins.WithLabelValues(metrics.LoginFailure)))
+	})
+
+	t.Run("invalid challenge", func(t *testing.T) {
+		in := &g.LoginUserRequest{
+			Challenge: "expired",
+			Code:      "123456",
+		}
+
+		parse := mt.EXPECT().
+			ParseChallenge("expired").
+			Return(uuid.Nil, auth.ErrInvalidChallenge)
+		gomock.InOrder(parse)
+
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+			tokens: mt,
+		}
+		_, err := rpc.LoginUser(context.Background(), in)
+		require.ErrorIs(t, err, auth.ErrInvalidChallenge)
+	})
+}
+
+func TestTOTPEnrollment(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	ms := mocks.NewMockService(ctrl)
+	rpc := &RPC{
+		logger: logger,
+		svc:    ms,
+	}
+
+	t.Run("enable", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		enable := ms.EXPECT().
+			EnableTOTP(ctx, gomock.Eq(uid)).
+			Return("SECRET", "otpauth://totp/gokeeper:test?secret=SECRET", nil)
+		gomock.InOrder(enable)
+
+		out, err := rpc.EnableTOTP(ctx, &g.EnableTOTPRequest{})
+		require.NoError(t, err)
+		require.Equal(t, "SECRET", out.Secret)
+		require.Equal(t, "otpauth://totp/gokeeper:test?secret=SECRET", out.Uri)
+	})
+
+	t.Run("enable twice", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		enable := ms.EXPECT().
+			EnableTOTP(ctx, gomock.Eq(uid)).
+			Return("", "", service.ErrTOTPEnabled)
+		gomock.InOrder(enable)
+
+		_, err := rpc.EnableTOTP(ctx, &g.EnableTOTPRequest{})
+		require.ErrorIs(t, err, service.ErrTOTPEnabled)
+	})
+
+	t.Run("confirm", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		confirm := ms.EXPECT().
+			ConfirmTOTP(ctx, gomock.Eq(uid), "123456").
+			Return([]string{"aaaa-bbbb-cccc-dddd"}, nil)
+		gomock.InOrder(confirm)
+
+		out, err := rpc.ConfirmTOTP(ctx, &g.ConfirmTOTPRequest{Code: "123456"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"aaaa-bbbb-cccc-dddd"}, out.RecoveryCodes)
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		disable := ms.EXPECT().
+			DisableTOTP(ctx, gomock.Eq(uid), "000000").
+			Return(service.ErrInvalidCode)
+		gomock.InOrder(disable)
+
+		out, err := rpc.DisableTOTP(ctx, &g.DisableTOTPRequest{Code: "000000"})
+		require.ErrorIs(t, err, service.ErrInvalidCode)
+		require.Equal(t, service.ErrInvalidCode.Error(), out.Error)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		_, err := rpc.EnableTOTP(context.Background(), &g.EnableTOTPRequest{})
+		require.ErrorIs(t, err, ErrUnauthenticated)
+	})
+}
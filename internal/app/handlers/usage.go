@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"context"
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+)
+
+// GetUsage returns storage used by the user along with configured quotas.
+func (r *RPC) GetUsage(ctx context.Context, in *g.GetUsageRequest) (*g.GetUsageResponse, error) {
+	if in == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "in").Msg("grpc request is nil")
+		return &g.GetUsageResponse{Error: ErrNilArgument.Error()}, ErrNilArgument
+	}
+
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		r.log(ctx).Err(err).Caller().Msg("unable to get authenticated user")
+		return &g.GetUsageResponse{Error: err.Error()}, err
+	}
+
+	r.log(ctx).Info().Str("user", userID.String()).Msg("received usage request")
+	res := new(g.GetUsageResponse)
+
+	usage, err := r.svc.GetUsage(ctx, userID)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to get user's usage")
+		res.Error = err.Error()
+		return res, err
+	}
+
+	res.Items = usage.Items
+	res.Bytes = usage.Bytes
+	res.MaxItems = r.cfg.Quotas.MaxItems
+	res.MaxBytes = r.cfg.Quotas.TotalBytes
+	res.MaxItemSize = r.cfg.Quotas.MaxItemSize
+	res.Error = ""
+	return res, nil
+}
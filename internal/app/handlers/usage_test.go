@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/auth"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/mocks"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/service"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	g "github.com/serjyuriev/yandex-diploma-2/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUsage(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	ms := mocks.NewMockService(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+		cfg := config.ServerConfig{}
+		cfg.Quotas.MaxItems = 100
+		cfg.Quotas.TotalBytes = 1 << 20
+
+		usage := ms.EXPECT().
+			GetUsage(ctx, gomock.Eq(uid)).
+			Return(&models.Usage{Items: 3, Bytes: 1024}, nil)
+		gomock.InOrder(usage)
+
+		rpc := &RPC{
+			cfg:    cfg,
+			logger: logger,
+			svc:    ms,
+		}
+		out, err := rpc.GetUsage(ctx, &g.GetUsageRequest{})
+		require.NoError(t, err)
+		require.Equal(t, int64(3), out.Items)
+		require.Equal(t, int64(1024), out.Bytes)
+		require.Equal(t, int64(100), out.MaxItems)
+		require.Equal(t, int64(1<<20), out.MaxBytes)
+		require.Equal(t, int64(0), out.MaxItemSize)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		_, err := rpc.GetUsage(context.Background(), &g.GetUsageRequest{})
+		require.ErrorIs(t, err, ErrUnauthenticated)
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		rpc := &RPC{
+			logger: logger,
+			svc:    ms,
+		}
+		_, err := rpc.GetUsage(context.Background(), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestQuotaExceeded(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+	ms := mocks.NewMockService(ctrl)
+
+	t.Run("add item", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		ms.EXPECT().
+			ReserveItem(ctx, gomock.Eq(uid), gomock.Eq(int64(10)), gomock.Eq(int64(0))).
+			Return(fmt.Errorf("%w: number of items is limited to 1", service.ErrResourceExhausted))
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		out, err := rpc.AddItem(ctx, &g.AddItemRequest{Item: &g.Item{
+			Type:    g.ItemType_ITEM_TYPE_TEXT,
+			Payload: []byte("ciphertext"),
+		}})
+		require.ErrorIs(t, err, service.ErrResourceExhausted)
+		require.Contains(t, out.Error, "limited to 1")
+	})
+
+	t.Run("update item", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		ms.EXPECT().
+			ReserveItemUpdate(ctx, gomock.Eq(uid), gomock.Any()).
+			Return(int64(0), fmt.Errorf("%w: storage is limited to 5 bytes", service.ErrResourceExhausted))
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		out, err := rpc.UpdateItem(ctx, &g.UpdateItemRequest{Item: &g.Item{
+			Id:      uuid.New().String(),
+			Payload: []byte("ciphertext"),
+		}})
+		require.ErrorIs(t, err, service.ErrResourceExhausted)
+		require.Contains(t, out.Error, "limited to 5 bytes")
+	})
+
+	t.Run("upload chunk", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		ms.EXPECT().
+			ReserveBlobChunk(ctx, gomock.Eq(uid), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, chunk *models.BlobChunk) error {
+				require.Equal(t, int64(3), chunk.Offset)
+				require.Equal(t, []byte("two"), chunk.Data)
+				return fmt.Errorf("%w: item size is limited to 5 bytes", service.ErrResourceExhausted)
+			})
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		err := rpc.UploadBinary(&uploadStream{
+			ctx: ctx,
+			chunks: []*g.UploadBinaryRequest{
+				{BlobId: uuid.New().String(), Offset: 3, Data: []byte("two"), Checksum: checksum("two")},
+			},
+		})
+		require.ErrorIs(t, err, service.ErrResourceExhausted)
+	})
+}
+
+func TestUsageAccounting(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+	ms := mocks.NewMockService(ctrl)
+
+	t.Run("failed add is released", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		reserve := ms.EXPECT().
+			ReserveItem(ctx, gomock.Eq(uid), gomock.Eq(int64(10)), gomock.Eq(int64(0))).
+			Return(nil)
+		create := mr.EXPECT().
+			CreateItem(ctx, gomock.Any(), "items", gomock.Eq(uid), gomock.Eq(int64(10))).
+			Return(fmt.Errorf("some err"))
+		release := ms.EXPECT().ReleaseItem(ctx, gomock.Eq(uid), gomock.Eq(int64(10)))
+		gomock.InOrder(reserve, create, release)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		_, err := rpc.AddItem(ctx, &g.AddItemRequest{Item: &g.Item{
+			Type:    g.ItemType_ITEM_TYPE_TEXT,
+			Payload: []byte("ciphertext"),
+		}})
+		require.Error(t, err)
+	})
+
+	t.Run("update is settled", func(t *testing.T) {
+		uid := uuid.New()
+		itemID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		reserve := ms.EXPECT().
+			ReserveItemUpdate(ctx, gomock.Eq(uid), gomock.Any()).
+			Return(int64(7), nil)
+		update := mr.EXPECT().
+			UpdateItem(ctx, gomock.Eq(uid), gomock.Any(), gomock.Eq(int64(10)), gomock.Any()).
+			Return(int64(5), nil)
+		settle := ms.EXPECT().
+			SettleItemUpdate(ctx, gomock.Eq(uid), gomock.Any(), gomock.Eq(int64(7)), gomock.Eq(int64(5)))
+		audit := ms.EXPECT().Audit(ctx, gomock.Eq(uid), models.AuditItemUpdated, gomock.Any())
+		gomock.InOrder(reserve, update, settle, audit)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		_, err := rpc.UpdateItem(ctx, &g.UpdateItemRequest{Item: &g.Item{
+			Id:      itemID.String(),
+			Payload: []byte("ciphertext"),
+		}})
+		require.NoError(t, err)
+	})
+
+	t.Run("failed update is cancelled", func(t *testing.T) {
+		uid := uuid.New()
+		itemID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		reserve := ms.EXPECT().
+			ReserveItemUpdate(ctx, gomock.Eq(uid), gomock.Any()).
+			Return(int64(7), nil)
+		update := mr.EXPECT().
+			UpdateItem(ctx, gomock.Eq(uid), gomock.Any(), gomock.Eq(int64(10)), gomock.Any()).
+			Return(int64(0), fmt.Errorf("some err"))
+		cancel := ms.EXPECT().CancelItemUpdate(ctx, gomock.Eq(uid), gomock.Eq(int64(7)))
+		gomock.InOrder(reserve, update, cancel)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		_, err := rpc.UpdateItem(ctx, &g.UpdateItemRequest{Item: &g.Item{
+			Id:      itemID.String(),
+			Payload: []byte("ciphertext"),
+		}})
+		require.Error(t, err)
+	})
+
+	t.Run("uploaded blob is counted once", func(t *testing.T) {
+		uid := uuid.New()
+		blobID := uuid.New()
+		ctx := auth.ContextWithClaims(context.Background(), auth.Claims{UserID: uid})
+
+		blob := mr.EXPECT().
+			ReadBlob(ctx, gomock.Eq(uid), gomock.Eq(blobID)).
+			Return(&models.Blob{ID: blobID, Size: 100, Complete: true}, nil)
+		reserve := ms.EXPECT().
+			ReserveItem(ctx, gomock.Eq(uid), gomock.Eq(int64(10)), gomock.Eq(int64(100))).
+			Return(nil)
+		create := mr.EXPECT().
+			CreateItem(ctx, gomock.Any(), "items", gomock.Eq(uid), gomock.Eq(int64(110))).
+			Return(nil)
+		audit := ms.EXPECT().Audit(ctx, gomock.Eq(uid), models.AuditItemCreated, gomock.Any())
+		gomock.InOrder(blob, reserve, create, audit)
+
+		rpc := &RPC{
+			logger: logger,
+			repo:   mr,
+			svc:    ms,
+		}
+		_, err := rpc.AddItem(ctx, &g.AddItemRequest{Item: &g.Item{
+			Type:    g.ItemType_ITEM_TYPE_BINARY,
+			Payload: []byte("ciphertext"),
+			BlobId:  blobID.String(),
+		}})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,128 @@
+// Package metrics provides Prometheus metrics of gokeeper server.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "gokeeper"
+
+// Outcomes of login attempts.
+const (
+	LoginSuccess = "success"
+	LoginFailure = "failure"
+	LoginBlocked = "blocked"
+	LoginError   = "error"
+)
+
+var (
+	// Registry holds all server metrics along with Go runtime and process ones.
+	Registry = prometheus.NewRegistry()
+
+	// RPCDuration observes latency of handled RPCs by method and status code.
+	RPCDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "rpc",
+		Name:      "duration_seconds",
+		Help:      "Latency of handled RPCs.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+	// RPCRequests counts handled RPCs by method and status code.
+	RPCRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rpc",
+		Name:      "requests_total",
+		Help:      "Number of handled RPCs.",
+	}, []string{"method", "code"})
+	// Logins counts login attempts by outcome.
+	Logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "logins_total",
+		Help:      "Number of login attempts by outcome: success, failure (wrong credentials), blocked (too many failures) or error.",
+	}, []string{"result"})
+	// RepositoryDuration observes latency of data layer operations.
+	RepositoryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "repository",
+		Name:      "operation_duration_seconds",
+		Help:      "Latency of data layer operations.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+	// Users is the number of registered users.
+	Users = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "users",
+		Help:      "Number of registered users.",
+	})
+	// Items is the number of stored vault items.
+	Items = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "items",
+		Help:      "Number of stored vault items.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		RPCDuration,
+		RPCRequests,
+		Logins,
+		RepositoryDuration,
+		Users,
+		Items,
+	)
+}
+
+// Handler returns HTTP handler exposing metrics of the Registry.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRepository records duration of the data layer operation started at provided time.
+func ObserveRepository(operation string, start time.Time) {
+	RepositoryDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
+
+// UnaryServerInterceptor records latency and status code of every unary RPC.
+func UnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observeRPC(info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamServerInterceptor does the same as UnaryServerInterceptor for streaming RPCs.
+func StreamServerInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observeRPC(info.FullMethod, start, err)
+	return err
+}
+
+// observeRPC records RPC handled since start with provided result.
+func observeRPC(method string, start time.Time, err error) {
+	code := status.Code(err).String()
+	RPCDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+	RPCRequests.WithLabelValues(method, code).Inc()
+}
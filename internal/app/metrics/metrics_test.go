@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/proto.server.Gokeeper/LoginUser"}
+
+	t.Run("success", func(t *testing.T) {
+		before := testutil.ToFloat64(RPCRequests.WithLabelValues(info.FullMethod, "OK"))
+		_, err := UnaryServerInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, before+1, testutil.ToFloat64(RPCRequests.WithLabelValues(info.FullMethod, "OK")))
+	})
+
+	t.Run("error code", func(t *testing.T) {
+		before := testutil.ToFloat64(RPCRequests.WithLabelValues(info.FullMethod, "Unauthenticated"))
+		_, err := UnaryServerInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.Unauthenticated, "login and/or password incorrect")
+		})
+		require.Error(t, err)
+		require.Equal(t, before+1, testutil.ToFloat64(RPCRequests.WithLabelValues(info.FullMethod, "Unauthenticated")))
+	})
+}
+
+func TestHandler(t *testing.T) {
+	Logins.WithLabelValues(LoginSuccess).Inc()
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, 200, rec.Code)
+	body := rec.Body.String()
+	require.Contains(t, body, `gokeeper_auth_logins_total{result="success"}`)
+	require.Contains(t, body, "go_goroutines")
+}
@@ -6,7 +6,9 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -36,18 +38,76 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AppendBlobChunk mocks base method.
+func (m *MockRepository) AppendBlobChunk(ctx context.Context, chunk *models.BlobChunk, last bool) (*models.Blob, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendBlobChunk", ctx, chunk, last)
+	ret0, _ := ret[0].(*models.Blob)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AppendBlobChunk indicates an expected call of AppendBlobChunk.
+func (mr *MockRepositoryMockRecorder) AppendBlobChunk(ctx, chunk, last interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendBlobChunk", reflect.TypeOf((*MockRepository)(nil).AppendBlobChunk), ctx, chunk, last)
+}
+
+// Close mocks base method.
+func (m *MockRepository) Close(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRepositoryMockRecorder) Close(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRepository)(nil).Close), ctx)
+}
+
+// CreateAuditEvent mocks base method.
+func (m *MockRepository) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuditEvent indicates an expected call of CreateAuditEvent.
+func (mr *MockRepositoryMockRecorder) CreateAuditEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditEvent", reflect.TypeOf((*MockRepository)(nil).CreateAuditEvent), ctx, event)
+}
+
 // CreateItem mocks base method.
-func (m *MockRepository) CreateItem(ctx context.Context, item interface{}, itemType string, userID uuid.UUID) error {
+func (m *MockRepository) CreateItem(ctx context.Context, item interface{}, itemType string, userID uuid.UUID, size int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateItem", ctx, item, itemType, userID)
+	ret := m.ctrl.Call(m, "CreateItem", ctx, item, itemType, userID, size)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateItem indicates an expected call of CreateItem.
-func (mr *MockRepositoryMockRecorder) CreateItem(ctx, item, itemType, userID interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) CreateItem(ctx, item, itemType, userID, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockRepository)(nil).CreateItem), ctx, item, itemType, userID, size)
+}
+
+// CreateSession mocks base method.
+func (m *MockRepository) CreateSession(ctx context.Context, session *models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockRepositoryMockRecorder) CreateSession(ctx, session interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockRepository)(nil).CreateItem), ctx, item, itemType, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockRepository)(nil).CreateSession), ctx, session)
 }
 
 // CreateUser mocks base method.
@@ -64,6 +124,256 @@ func (mr *MockRepositoryMockRecorder) CreateUser(ctx, user interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockRepository)(nil).CreateUser), ctx, user)
 }
 
+// CreateVaultKey mocks base method.
+func (m *MockRepository) CreateVaultKey(ctx context.Context, userID uuid.UUID, key *models.VaultKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVaultKey", ctx, userID, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVaultKey indicates an expected call of CreateVaultKey.
+func (mr *MockRepositoryMockRecorder) CreateVaultKey(ctx, userID, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVaultKey", reflect.TypeOf((*MockRepository)(nil).CreateVaultKey), ctx, userID, key)
+}
+
+// DeleteItem mocks base method.
+func (m *MockRepository) DeleteItem(ctx context.Context, userID, itemID uuid.UUID, expectedRevision int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, userID, itemID, expectedRevision)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockRepositoryMockRecorder) DeleteItem(ctx, userID, itemID, expectedRevision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockRepository)(nil).DeleteItem), ctx, userID, itemID, expectedRevision)
+}
+
+// DeleteUser mocks base method.
+func (m *MockRepository) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockRepositoryMockRecorder) DeleteUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockRepository)(nil).DeleteUser), ctx, userID)
+}
+
+// ExpireBlobs mocks base method.
+func (m *MockRepository) ExpireBlobs(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireBlobs", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireBlobs indicates an expected call of ExpireBlobs.
+func (mr *MockRepositoryMockRecorder) ExpireBlobs(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireBlobs", reflect.TypeOf((*MockRepository)(nil).ExpireBlobs), ctx)
+}
+
+// Migrate mocks base method.
+func (m *MockRepository) Migrate(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migrate", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Migrate indicates an expected call of Migrate.
+func (mr *MockRepositoryMockRecorder) Migrate(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migrate", reflect.TypeOf((*MockRepository)(nil).Migrate), ctx)
+}
+
+// OpenBlob mocks base method.
+func (m *MockRepository) OpenBlob(ctx context.Context, userID, blobID uuid.UUID, offset int64) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenBlob", ctx, userID, blobID, offset)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenBlob indicates an expected call of OpenBlob.
+func (mr *MockRepositoryMockRecorder) OpenBlob(ctx, userID, blobID, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenBlob", reflect.TypeOf((*MockRepository)(nil).OpenBlob), ctx, userID, blobID, offset)
+}
+
+// Ping mocks base method.
+func (m *MockRepository) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockRepositoryMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockRepository)(nil).Ping), ctx)
+}
+
+// ReadAuditEvents mocks base method.
+func (m *MockRepository) ReadAuditEvents(ctx context.Context, userID uuid.UUID, after *models.AuditEvent, limit int64) ([]*models.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAuditEvents", ctx, userID, after, limit)
+	ret0, _ := ret[0].([]*models.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAuditEvents indicates an expected call of ReadAuditEvents.
+func (mr *MockRepositoryMockRecorder) ReadAuditEvents(ctx, userID, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAuditEvents", reflect.TypeOf((*MockRepository)(nil).ReadAuditEvents), ctx, userID, after, limit)
+}
+
+// ReadBlob mocks base method.
+func (m *MockRepository) ReadBlob(ctx context.Context, userID, blobID uuid.UUID) (*models.Blob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadBlob", ctx, userID, blobID)
+	ret0, _ := ret[0].(*models.Blob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadBlob indicates an expected call of ReadBlob.
+func (mr *MockRepositoryMockRecorder) ReadBlob(ctx, userID, blobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadBlob", reflect.TypeOf((*MockRepository)(nil).ReadBlob), ctx, userID, blobID)
+}
+
+// ReadItem mocks base method.
+func (m *MockRepository) ReadItem(ctx context.Context, userID, itemID uuid.UUID) (*models.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadItem", ctx, userID, itemID)
+	ret0, _ := ret[0].(*models.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadItem indicates an expected call of ReadItem.
+func (mr *MockRepositoryMockRecorder) ReadItem(ctx, userID, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadItem", reflect.TypeOf((*MockRepository)(nil).ReadItem), ctx, userID, itemID)
+}
+
+// ReadItems mocks base method.
+func (m *MockRepository) ReadItems(ctx context.Context, userID uuid.UUID) (*models.Vault, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadItems", ctx, userID)
+	ret0, _ := ret[0].(*models.Vault)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadItems indicates an expected call of ReadItems.
+func (mr *MockRepositoryMockRecorder) ReadItems(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadItems", reflect.TypeOf((*MockRepository)(nil).ReadItems), ctx, userID)
+}
+
+// ReadItemsByType mocks base method.
+func (m *MockRepository) ReadItemsByType(ctx context.Context, userID uuid.UUID, itemType string, items interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadItemsByType", ctx, userID, itemType, items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReadItemsByType indicates an expected call of ReadItemsByType.
+func (mr *MockRepositoryMockRecorder) ReadItemsByType(ctx, userID, itemType, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadItemsByType", reflect.TypeOf((*MockRepository)(nil).ReadItemsByType), ctx, userID, itemType, items)
+}
+
+// ReadItemsSince mocks base method.
+func (m *MockRepository) ReadItemsSince(ctx context.Context, userID uuid.UUID, revision int64) (*models.Vault, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadItemsSince", ctx, userID, revision)
+	ret0, _ := ret[0].(*models.Vault)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadItemsSince indicates an expected call of ReadItemsSince.
+func (mr *MockRepositoryMockRecorder) ReadItemsSince(ctx, userID, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadItemsSince", reflect.TypeOf((*MockRepository)(nil).ReadItemsSince), ctx, userID, revision)
+}
+
+// ReadSession mocks base method.
+func (m *MockRepository) ReadSession(ctx context.Context, sessionID uuid.UUID) (*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSession", ctx, sessionID)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSession indicates an expected call of ReadSession.
+func (mr *MockRepositoryMockRecorder) ReadSession(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSession", reflect.TypeOf((*MockRepository)(nil).ReadSession), ctx, sessionID)
+}
+
+// ReadSessionsByUser mocks base method.
+func (m *MockRepository) ReadSessionsByUser(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSessionsByUser", ctx, userID)
+	ret0, _ := ret[0].([]*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSessionsByUser indicates an expected call of ReadSessionsByUser.
+func (mr *MockRepositoryMockRecorder) ReadSessionsByUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSessionsByUser", reflect.TypeOf((*MockRepository)(nil).ReadSessionsByUser), ctx, userID)
+}
+
+// ReadStats mocks base method.
+func (m *MockRepository) ReadStats(ctx context.Context) (*models.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadStats", ctx)
+	ret0, _ := ret[0].(*models.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadStats indicates an expected call of ReadStats.
+func (mr *MockRepositoryMockRecorder) ReadStats(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadStats", reflect.TypeOf((*MockRepository)(nil).ReadStats), ctx)
+}
+
+// ReadUsage mocks base method.
+func (m *MockRepository) ReadUsage(ctx context.Context, userID uuid.UUID) (*models.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUsage", ctx, userID)
+	ret0, _ := ret[0].(*models.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUsage indicates an expected call of ReadUsage.
+func (mr *MockRepositoryMockRecorder) ReadUsage(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUsage", reflect.TypeOf((*MockRepository)(nil).ReadUsage), ctx, userID)
+}
+
 // ReadUserByID mocks base method.
 func (m *MockRepository) ReadUserByID(ctx context.Context, uuid uuid.UUID) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -93,3 +403,174 @@ func (mr *MockRepositoryMockRecorder) ReadUserByLogin(ctx, login interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserByLogin", reflect.TypeOf((*MockRepository)(nil).ReadUserByLogin), ctx, login)
 }
+
+// RevokeSession mocks base method.
+func (m *MockRepository) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockRepositoryMockRecorder) RevokeSession(ctx, userID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockRepository)(nil).RevokeSession), ctx, userID, sessionID)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockRepository) RevokeSessions(ctx context.Context, userID, except uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", ctx, userID, except)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockRepositoryMockRecorder) RevokeSessions(ctx, userID, except interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockRepository)(nil).RevokeSessions), ctx, userID, except)
+}
+
+// RotateSession mocks base method.
+func (m *MockRepository) RotateSession(ctx context.Context, session *models.Session, oldHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSession", ctx, session, oldHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateSession indicates an expected call of RotateSession.
+func (mr *MockRepositoryMockRecorder) RotateSession(ctx, session, oldHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSession", reflect.TypeOf((*MockRepository)(nil).RotateSession), ctx, session, oldHash)
+}
+
+// StoreBlob mocks base method.
+func (m *MockRepository) StoreBlob(ctx context.Context, userID uuid.UUID, content io.Reader) (*models.Blob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreBlob", ctx, userID, content)
+	ret0, _ := ret[0].(*models.Blob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreBlob indicates an expected call of StoreBlob.
+func (mr *MockRepositoryMockRecorder) StoreBlob(ctx, userID, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreBlob", reflect.TypeOf((*MockRepository)(nil).StoreBlob), ctx, userID, content)
+}
+
+// TouchSession mocks base method.
+func (m *MockRepository) TouchSession(ctx context.Context, sessionID uuid.UUID, lastSeenAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchSession", ctx, sessionID, lastSeenAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchSession indicates an expected call of TouchSession.
+func (mr *MockRepositoryMockRecorder) TouchSession(ctx, sessionID, lastSeenAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchSession", reflect.TypeOf((*MockRepository)(nil).TouchSession), ctx, sessionID, lastSeenAt)
+}
+
+// UpdateCredentials mocks base method.
+func (m *MockRepository) UpdateCredentials(ctx context.Context, userID uuid.UUID, password string, key *models.VaultKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCredentials", ctx, userID, password, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCredentials indicates an expected call of UpdateCredentials.
+func (mr *MockRepositoryMockRecorder) UpdateCredentials(ctx, userID, password, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCredentials", reflect.TypeOf((*MockRepository)(nil).UpdateCredentials), ctx, userID, password, key)
+}
+
+// UpdateItem mocks base method.
+func (m *MockRepository) UpdateItem(ctx context.Context, userID uuid.UUID, item *models.Item, size, expectedRevision int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", ctx, userID, item, size, expectedRevision)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockRepositoryMockRecorder) UpdateItem(ctx, userID, item, size, expectedRevision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockRepository)(nil).UpdateItem), ctx, userID, item, size, expectedRevision)
+}
+
+// UpdateTOTP mocks base method.
+func (m *MockRepository) UpdateTOTP(ctx context.Context, userID uuid.UUID, totp *models.TOTP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTOTP", ctx, userID, totp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTOTP indicates an expected call of UpdateTOTP.
+func (mr *MockRepositoryMockRecorder) UpdateTOTP(ctx, userID, totp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTOTP", reflect.TypeOf((*MockRepository)(nil).UpdateTOTP), ctx, userID, totp)
+}
+
+// UpdateUsage mocks base method.
+func (m *MockRepository) UpdateUsage(ctx context.Context, userID uuid.UUID, delta, limit *models.Usage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsage", ctx, userID, delta, limit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUsage indicates an expected call of UpdateUsage.
+func (mr *MockRepositoryMockRecorder) UpdateUsage(ctx, userID, delta, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsage", reflect.TypeOf((*MockRepository)(nil).UpdateUsage), ctx, userID, delta, limit)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockRepository) UpdateUserPassword(ctx context.Context, userID uuid.UUID, oldPassword, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", ctx, userID, oldPassword, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockRepositoryMockRecorder) UpdateUserPassword(ctx, userID, oldPassword, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockRepository)(nil).UpdateUserPassword), ctx, userID, oldPassword, password)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockRepositoryMockRecorder) UseRecoveryCode(ctx, userID, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockRepository)(nil).UseRecoveryCode), ctx, userID, hash)
+}
+
+// UseTOTPStep mocks base method.
+func (m *MockRepository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", ctx, userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockRepositoryMockRecorder) UseTOTPStep(ctx, userID, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MockRepository)(nil).UseTOTPStep), ctx, userID, step)
+}
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 	models "github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
 )
 
@@ -35,6 +36,176 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// Audit mocks base method.
+func (m *MockService) Audit(ctx context.Context, userID uuid.UUID, eventType models.AuditEventType, details map[string]string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Audit", ctx, userID, eventType, details)
+}
+
+// Audit indicates an expected call of Audit.
+func (mr *MockServiceMockRecorder) Audit(ctx, userID, eventType, details interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Audit", reflect.TypeOf((*MockService)(nil).Audit), ctx, userID, eventType, details)
+}
+
+// CancelItemUpdate mocks base method.
+func (m *MockService) CancelItemUpdate(ctx context.Context, userID uuid.UUID, reserved int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CancelItemUpdate", ctx, userID, reserved)
+}
+
+// CancelItemUpdate indicates an expected call of CancelItemUpdate.
+func (mr *MockServiceMockRecorder) CancelItemUpdate(ctx, userID, reserved interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelItemUpdate", reflect.TypeOf((*MockService)(nil).CancelItemUpdate), ctx, userID, reserved)
+}
+
+// ChangePassword mocks base method.
+func (m *MockService) ChangePassword(ctx context.Context, userID, sessionID uuid.UUID, oldPassword, newPassword string, key *models.VaultKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, sessionID, oldPassword, newPassword, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockServiceMockRecorder) ChangePassword(ctx, userID, sessionID, oldPassword, newPassword, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockService)(nil).ChangePassword), ctx, userID, sessionID, oldPassword, newPassword, key)
+}
+
+// ConfirmTOTP mocks base method.
+func (m *MockService) ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", ctx, userID, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockServiceMockRecorder) ConfirmTOTP(ctx, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*MockService)(nil).ConfirmTOTP), ctx, userID, code)
+}
+
+// CountBlob mocks base method.
+func (m *MockService) CountBlob(ctx context.Context, userID uuid.UUID, blob *models.Blob) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CountBlob", ctx, userID, blob)
+}
+
+// CountBlob indicates an expected call of CountBlob.
+func (mr *MockServiceMockRecorder) CountBlob(ctx, userID, blob interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBlob", reflect.TypeOf((*MockService)(nil).CountBlob), ctx, userID, blob)
+}
+
+// CreateSession mocks base method.
+func (m *MockService) CreateSession(ctx context.Context, userID uuid.UUID, device string) (*models.Session, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, userID, device)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockServiceMockRecorder) CreateSession(ctx, userID, device interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockService)(nil).CreateSession), ctx, userID, device)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockService) DeleteAccount(ctx context.Context, userID uuid.UUID, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, userID, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockServiceMockRecorder) DeleteAccount(ctx, userID, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockService)(nil).DeleteAccount), ctx, userID, password)
+}
+
+// DisableTOTP mocks base method.
+func (m *MockService) DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockServiceMockRecorder) DisableTOTP(ctx, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockService)(nil).DisableTOTP), ctx, userID, code)
+}
+
+// EnableTOTP mocks base method.
+func (m *MockService) EnableTOTP(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTOTP", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// EnableTOTP indicates an expected call of EnableTOTP.
+func (mr *MockServiceMockRecorder) EnableTOTP(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTOTP", reflect.TypeOf((*MockService)(nil).EnableTOTP), ctx, userID)
+}
+
+// GetUsage mocks base method.
+func (m *MockService) GetUsage(ctx context.Context, userID uuid.UUID) (*models.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", ctx, userID)
+	ret0, _ := ret[0].(*models.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockServiceMockRecorder) GetUsage(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockService)(nil).GetUsage), ctx, userID)
+}
+
+// ListAuditEvents mocks base method.
+func (m *MockService) ListAuditEvents(ctx context.Context, userID uuid.UUID, after *models.AuditEvent, limit int64) ([]*models.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEvents", ctx, userID, after, limit)
+	ret0, _ := ret[0].([]*models.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEvents indicates an expected call of ListAuditEvents.
+func (mr *MockServiceMockRecorder) ListAuditEvents(ctx, userID, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEvents", reflect.TypeOf((*MockService)(nil).ListAuditEvents), ctx, userID, after, limit)
+}
+
+// ListSessions mocks base method.
+func (m *MockService) ListSessions(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, userID)
+	ret0, _ := ret[0].([]*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockServiceMockRecorder) ListSessions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockService)(nil).ListSessions), ctx, userID)
+}
+
 // LoginUser mocks base method.
 func (m *MockService) LoginUser(ctx context.Context, user *models.User) (string, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +221,115 @@ func (mr *MockServiceMockRecorder) LoginUser(ctx, user interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockService)(nil).LoginUser), ctx, user)
 }
 
+// RefreshSession mocks base method.
+func (m *MockService) RefreshSession(ctx context.Context, refreshToken string) (*models.Session, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSession", ctx, refreshToken)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RefreshSession indicates an expected call of RefreshSession.
+func (mr *MockServiceMockRecorder) RefreshSession(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockService)(nil).RefreshSession), ctx, refreshToken)
+}
+
+// ReleaseBlobChunk mocks base method.
+func (m *MockService) ReleaseBlobChunk(ctx context.Context, userID uuid.UUID, chunk *models.BlobChunk) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReleaseBlobChunk", ctx, userID, chunk)
+}
+
+// ReleaseBlobChunk indicates an expected call of ReleaseBlobChunk.
+func (mr *MockServiceMockRecorder) ReleaseBlobChunk(ctx, userID, chunk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseBlobChunk", reflect.TypeOf((*MockService)(nil).ReleaseBlobChunk), ctx, userID, chunk)
+}
+
+// ReleaseItem mocks base method.
+func (m *MockService) ReleaseItem(ctx context.Context, userID uuid.UUID, size int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReleaseItem", ctx, userID, size)
+}
+
+// ReleaseItem indicates an expected call of ReleaseItem.
+func (mr *MockServiceMockRecorder) ReleaseItem(ctx, userID, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseItem", reflect.TypeOf((*MockService)(nil).ReleaseItem), ctx, userID, size)
+}
+
+// ReserveBlobChunk mocks base method.
+func (m *MockService) ReserveBlobChunk(ctx context.Context, userID uuid.UUID, chunk *models.BlobChunk) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveBlobChunk", ctx, userID, chunk)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReserveBlobChunk indicates an expected call of ReserveBlobChunk.
+func (mr *MockServiceMockRecorder) ReserveBlobChunk(ctx, userID, chunk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveBlobChunk", reflect.TypeOf((*MockService)(nil).ReserveBlobChunk), ctx, userID, chunk)
+}
+
+// ReserveItem mocks base method.
+func (m *MockService) ReserveItem(ctx context.Context, userID uuid.UUID, payload, blobSize int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveItem", ctx, userID, payload, blobSize)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReserveItem indicates an expected call of ReserveItem.
+func (mr *MockServiceMockRecorder) ReserveItem(ctx, userID, payload, blobSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveItem", reflect.TypeOf((*MockService)(nil).ReserveItem), ctx, userID, payload, blobSize)
+}
+
+// ReserveItemUpdate mocks base method.
+func (m *MockService) ReserveItemUpdate(ctx context.Context, userID uuid.UUID, item *models.Item) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveItemUpdate", ctx, userID, item)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveItemUpdate indicates an expected call of ReserveItemUpdate.
+func (mr *MockServiceMockRecorder) ReserveItemUpdate(ctx, userID, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveItemUpdate", reflect.TypeOf((*MockService)(nil).ReserveItemUpdate), ctx, userID, item)
+}
+
+// RevokeSession mocks base method.
+func (m *MockService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockServiceMockRecorder) RevokeSession(ctx, userID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockService)(nil).RevokeSession), ctx, userID, sessionID)
+}
+
+// SettleItemUpdate mocks base method.
+func (m *MockService) SettleItemUpdate(ctx context.Context, userID uuid.UUID, item *models.Item, reserved, released int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SettleItemUpdate", ctx, userID, item, reserved, released)
+}
+
+// SettleItemUpdate indicates an expected call of SettleItemUpdate.
+func (mr *MockServiceMockRecorder) SettleItemUpdate(ctx, userID, item, reserved, released interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleItemUpdate", reflect.TypeOf((*MockService)(nil).SettleItemUpdate), ctx, userID, item, reserved, released)
+}
+
 // SignUpUser mocks base method.
 func (m *MockService) SignUpUser(ctx context.Context, user *models.User) (string, error) {
 	m.ctrl.T.Helper()
@@ -64,3 +344,31 @@ func (mr *MockServiceMockRecorder) SignUpUser(ctx, user interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUpUser", reflect.TypeOf((*MockService)(nil).SignUpUser), ctx, user)
 }
+
+// ValidateSession mocks base method.
+func (m *MockService) ValidateSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateSession", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateSession indicates an expected call of ValidateSession.
+func (mr *MockServiceMockRecorder) ValidateSession(ctx, userID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateSession", reflect.TypeOf((*MockService)(nil).ValidateSession), ctx, userID, sessionID)
+}
+
+// VerifyTOTP mocks base method.
+func (m *MockService) VerifyTOTP(ctx context.Context, userID uuid.UUID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyTOTP", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyTOTP indicates an expected call of VerifyTOTP.
+func (mr *MockServiceMockRecorder) VerifyTOTP(ctx, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTOTP", reflect.TypeOf((*MockService)(nil).VerifyTOTP), ctx, userID, code)
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UpdateCredentials replaces password hash of the user with provided UUID
+// and, unless key is nil, user's vault key wrapped with the new password.
+// Both are replaced at once, so vault key always matches the password.
+func (r *repository) UpdateCredentials(ctx context.Context, userID uuid.UUID, password string, key *models.VaultKey) error {
+	id := userID.String()
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing filter")
+	filter := bson.D{{Key: "id", Value: userID}}
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing update")
+	set := bson.D{{Key: "password", Value: password}}
+	if key != nil {
+		set = append(set, bson.E{Key: "vault_key", Value: key})
+	}
+	update := bson.D{{Key: "$set", Value: set}}
+
+	r.log(ctx).Debug().Str("user", id).Msg("updating user's credentials")
+	result, err := r.users.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to update user's credentials")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		r.log(ctx).Debug().Str("user", id).Msg("no such user in the database")
+		return ErrNoUser
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("user's credentials were updated")
+	return nil
+}
+
+// RevokeSessions marks all sessions of the user with provided UUID as revoked,
+// except the one with UUID provided as except, and returns number of revoked sessions.
+func (r *repository) RevokeSessions(ctx context.Context, userID uuid.UUID, except uuid.UUID) (int64, error) {
+	id := userID.String()
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing filter")
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "id", Value: bson.D{{Key: "$ne", Value: except}}},
+		{Key: "revoked", Value: false},
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing update")
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "revoked", Value: true}}}}
+
+	r.log(ctx).Debug().Str("user", id).Msg("revoking user's sessions")
+	result, err := r.sessions.UpdateMany(ctx, filter, update)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to revoke user's sessions")
+		return 0, err
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msgf("revoked %d sessions", result.ModifiedCount)
+	return result.ModifiedCount, nil
+}
+
+// DeleteUser removes user with provided UUID along with all user's
+// sessions, items and blobs. Audit events are kept, so the account's
+// history outlives it. User entry is removed last,
+// so interrupted removal may be repeated until it succeeds.
+func (r *repository) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	id := userID.String()
+	filter := bson.D{{Key: "user_id", Value: userID}}
+
+	r.log(ctx).Debug().Str("user", id).Msg("removing user's sessions")
+	if _, err := r.sessions.DeleteMany(ctx, filter); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to remove user's sessions")
+		return err
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("searching for user's blobs")
+	cursor, err := r.blobs.Find(ctx, filter, options.Find().SetProjection(bson.D{{Key: "id", Value: 1}}))
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to perform read operation in the database")
+		return err
+	}
+	blobs := make([]*models.Blob, 0)
+	if err := cursor.All(ctx, &blobs); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to decode query result")
+		return err
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msgf("removing content of %d blobs", len(blobs))
+	for _, blob := range blobs {
+		if err := r.store.Delete(ctx, userID, blob.ID); err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("user", id).
+				Str("blob", blob.ID.String()).
+				Msg("unable to remove blob content")
+			return err
+		}
+	}
+
+	for _, data := range []struct {
+		name string
+		coll *mongo.Collection
+	}{
+		{"blobs", r.blobs},
+		{"blob chunks", r.chunks},
+		{"items", r.items},
+	} {
+		r.log(ctx).Debug().Str("user", id).Msgf("removing user's %s", data.name)
+		if _, err := data.coll.DeleteMany(ctx, filter); err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("user", id).
+				Msgf("unable to remove user's %s", data.name)
+			return err
+		}
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("removing user")
+	result, err := r.users.DeleteOne(ctx, bson.D{{Key: "id", Value: userID}})
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to remove user")
+		return err
+	}
+	if result.DeletedCount == 0 {
+		r.log(ctx).Debug().Str("user", id).Msg("no such user in the database")
+		return ErrNoUser
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("user was removed")
+	return nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestUpdateCredentials(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("password and vault key", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 1},
+			{Key: "nModified", Value: 1},
+		})
+
+		err := repo.UpdateCredentials(context.Background(), uuid.New(), "newhash", &models.VaultKey{WrappedKey: []byte("key")})
+		require.NoError(t, err)
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("u").Document()
+		require.Equal(t, "newhash", update.Lookup("$set", "password").StringValue())
+		_, err = update.LookupErr("$set", "vault_key")
+		require.NoError(t, err)
+	})
+
+	mt.Run("password only", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 1},
+			{Key: "nModified", Value: 1},
+		})
+
+		err := repo.UpdateCredentials(context.Background(), uuid.New(), "newhash", nil)
+		require.NoError(t, err)
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("u").Document()
+		_, err = update.LookupErr("$set", "vault_key")
+		require.Error(t, err)
+	})
+
+	mt.Run("no user", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 0},
+			{Key: "nModified", Value: 0},
+		})
+
+		err := repo.UpdateCredentials(context.Background(), uuid.New(), "newhash", nil)
+		require.ErrorIs(t, err, ErrNoUser)
+	})
+}
+
+func TestRevokeSessions(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("ok", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			sessions: mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 2},
+			{Key: "nModified", Value: 2},
+		})
+
+		revoked, err := repo.RevokeSessions(context.Background(), uuid.New(), uuid.New())
+		require.NoError(t, err)
+		require.Equal(t, int64(2), revoked)
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		require.True(t, update.Lookup("multi").Boolean())
+		_, err = update.LookupErr("q", "id", "$ne")
+		require.NoError(t, err)
+	})
+
+	mt.Run("error", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			sessions: mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+
+		_, err := repo.RevokeSessions(context.Background(), uuid.New(), uuid.New())
+		require.Error(t, err)
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	uid := uuid.New()
+	blobID := uuid.New()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("ok", func(mt *mtest.T) {
+		dir := t.TempDir()
+		store, err := NewFileStore(dir, logger)
+		require.NoError(t, err)
+		_, err = store.Put(context.Background(), uid, blobID, strings.NewReader("content"))
+		require.NoError(t, err)
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			users:    mt.Coll,
+			items:    mt.Coll,
+			sessions: mt.Coll,
+			blobs:    mt.Coll,
+			chunks:   mt.Coll,
+			audit:    mt.Coll,
+			store:    store,
+		}
+
+		mt.AddMockResponses(
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 2}},
+			blobResponse(uid, blobID, 7, true),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 3}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}},
+		)
+
+		err = repo.DeleteUser(context.Background(), uid)
+		require.NoError(t, err)
+		_, err = os.Stat(filepath.Join(dir, uid.String(), blobID.String()))
+		require.True(t, os.IsNotExist(err))
+		// audit events outlive the account
+		deletes := 0
+		for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+			if event.CommandName == "delete" {
+				deletes++
+			}
+		}
+		require.Equal(t, 5, deletes)
+	})
+
+	mt.Run("no user", func(mt *mtest.T) {
+		store, err := NewFileStore(t.TempDir(), logger)
+		require.NoError(t, err)
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			users:    mt.Coll,
+			items:    mt.Coll,
+			sessions: mt.Coll,
+			blobs:    mt.Coll,
+			chunks:   mt.Coll,
+			audit:    mt.Coll,
+			store:    store,
+		}
+
+		mt.AddMockResponses(
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}},
+			mtest.CreateCursorResponse(0, "gokeeper.blobs", mtest.FirstBatch),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}},
+		)
+
+		err = repo.DeleteUser(context.Background(), uid)
+		require.ErrorIs(t, err, ErrNoUser)
+	})
+
+	mt.Run("sessions error", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			users:    mt.Coll,
+			sessions: mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+
+		err := repo.DeleteUser(context.Background(), uid)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrNoUser)
+	})
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CreateAuditEvent appends event to the audit log. Audit log is append-only,
+// so data layer provides no means to change or remove its events.
+func (r *repository) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	if event == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "event").Msg("audit event can't be nil")
+		return ErrNilArgument
+	}
+
+	r.log(ctx).Debug().Str("user", event.UserID.String()).Str("event", string(event.Type)).Msg("inserting audit event to the database")
+	if _, err := r.audit.InsertOne(ctx, event); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", event.UserID.String()).
+			Str("event", string(event.Type)).
+			Msg("unable to insert audit event to the database")
+		return err
+	}
+	return nil
+}
+
+// ReadAuditEvents returns at most limit events of the user with provided UUID,
+// the newest ones first. If after is set, only events following it are returned,
+// so pages of the audit log are read one by one.
+func (r *repository) ReadAuditEvents(
+	ctx context.Context,
+	userID uuid.UUID,
+	after *models.AuditEvent,
+	limit int64,
+) ([]*models.AuditEvent, error) {
+	id := userID.String()
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing filter")
+	filter := bson.D{{Key: "user_id", Value: userID}}
+	if after != nil {
+		// events of the same moment are ordered by their ids
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "time", Value: bson.D{{Key: "$lt", Value: after.Time}}}},
+			bson.D{
+				{Key: "time", Value: after.Time},
+				{Key: "id", Value: bson.D{{Key: "$lt", Value: after.ID}}},
+			},
+		}})
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "time", Value: -1}, {Key: "id", Value: -1}}).
+		SetLimit(limit)
+
+	r.log(ctx).Debug().Str("user", id).Msg("searching for user's audit events in the database")
+	cursor, err := r.audit.Find(ctx, filter, opts)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to perform read operation in the database")
+		return nil, err
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("processing query result")
+	events := make([]*models.AuditEvent, 0)
+	if err := cursor.All(ctx, &events); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to decode query result")
+		return nil, err
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msgf("found %d audit events in the database", len(events))
+	return events, nil
+}
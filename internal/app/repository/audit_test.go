@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestCreateAuditEvent(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			audit:  mt.Coll,
+		}
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := repo.CreateAuditEvent(context.Background(), &models.AuditEvent{
+			ID:       uuid.New(),
+			UserID:   uuid.New(),
+			Type:     models.AuditLoginSucceeded,
+			Time:     time.Now(),
+			ClientIP: "192.0.2.10",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "insert", mt.GetStartedEvent().CommandName)
+	})
+
+	mt.Run("nil event", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			audit:  mt.Coll,
+		}
+
+		err := repo.CreateAuditEvent(context.Background(), nil)
+		require.ErrorIs(t, err, ErrNilArgument)
+	})
+
+	mt.Run("insert err", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			audit:  mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+
+		err := repo.CreateAuditEvent(context.Background(), &models.AuditEvent{ID: uuid.New()})
+		require.Error(t, err)
+	})
+}
+
+func TestReadAuditEvents(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("first page", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			audit:  mt.Coll,
+		}
+		uid := uuid.New()
+		eid := uuid.New()
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "gokeeper.audit", mtest.FirstBatch, bson.D{
+			{Key: "id", Value: eid},
+			{Key: "user_id", Value: uid},
+			{Key: "type", Value: string(models.AuditSignUp)},
+			{Key: "time", Value: time.Now()},
+			{Key: "client_ip", Value: "192.0.2.10"},
+		}))
+
+		events, err := repo.ReadAuditEvents(context.Background(), uid, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, eid, events[0].ID)
+		require.Equal(t, models.AuditSignUp, events[0].Type)
+		require.Equal(t, "192.0.2.10", events[0].ClientIP)
+
+		cmd := mt.GetStartedEvent().Command
+		require.Equal(t, int64(10), cmd.Lookup("limit").Int64())
+		_, err = cmd.Lookup("filter").Document().LookupErr("$or")
+		require.Error(t, err)
+	})
+
+	mt.Run("next page", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			audit:  mt.Coll,
+		}
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "gokeeper.audit", mtest.FirstBatch))
+
+		after := &models.AuditEvent{ID: uuid.New(), Time: time.Now()}
+		events, err := repo.ReadAuditEvents(context.Background(), uuid.New(), after, 10)
+		require.NoError(t, err)
+		require.Empty(t, events)
+
+		cmd := mt.GetStartedEvent().Command
+		_, err = cmd.Lookup("filter").Document().LookupErr("$or")
+		require.NoError(t, err)
+	})
+
+	mt.Run("find err", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			audit:  mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+
+		_, err := repo.ReadAuditEvents(context.Background(), uuid.New(), nil, 10)
+		require.Error(t, err)
+	})
+}
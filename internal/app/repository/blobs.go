@@ -0,0 +1,622 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// blobCompletionTimeout limits how long blob stays claimed by the request
+	// completing it, claim of the request, which failed to finish, expires after it.
+	blobCompletionTimeout = 5 * time.Minute
+	// defaultBlobExpiry is how long uploaded blob is kept before
+	// it is attached to an item when blob expiry isn't configured.
+	defaultBlobExpiry = 24 * time.Hour
+)
+
+// AppendBlobChunk adds chunk to the end of the user's blob, creating blob
+// on its first chunk, and reports whether the chunk was appended. Chunks,
+// which were already received, are ignored, so interrupted upload can be
+// safely resumed. Chunks are staged until the one marked as last is received,
+// then the blob is moved to the blob store and completed, after that it can't
+// be changed. Appended chunk stays in the blob even if completion fails.
+func (r *repository) AppendBlobChunk(ctx context.Context, chunk *models.BlobChunk, last bool) (*models.Blob, bool, error) {
+	if chunk == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "chunk").Msg("chunk can't be nil")
+		return nil, false, ErrNilArgument
+	}
+	id := chunk.BlobID.String()
+	chunk.End = chunk.Offset + int64(len(chunk.Data))
+	now := time.Now().UTC()
+	chunk.ExpiresAt = now.Add(r.blobExpiry())
+
+	if chunk.Offset == 0 {
+		r.log(ctx).Debug().Str("blob", id).Msg("creating blob")
+		if _, err := r.blobs.UpdateOne(
+			ctx,
+			bson.D{{Key: "user_id", Value: chunk.UserID}, {Key: "id", Value: chunk.BlobID}},
+			bson.D{{Key: "$setOnInsert", Value: &models.Blob{
+				ID:        chunk.BlobID,
+				UserID:    chunk.UserID,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}}},
+			options.Update().SetUpsert(true),
+		); err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("blob", id).
+				Msg("unable to create blob")
+			return nil, false, err
+		}
+	}
+
+	blob, err := r.ReadBlob(ctx, chunk.UserID, chunk.BlobID)
+	if err != nil {
+		if err == ErrNoBlob {
+			return nil, false, ErrBlobOffset
+		}
+		return nil, false, err
+	}
+	if chunk.End <= blob.Size {
+		r.log(ctx).Debug().Str("blob", id).Int64("offset", chunk.Offset).Msg("chunk was already received")
+		if last && !blob.Complete && chunk.End == blob.Size {
+			return blob, false, r.completeBlob(ctx, blob)
+		}
+		return blob, false, nil
+	}
+	if blob.Complete {
+		return nil, false, ErrBlobComplete
+	}
+	if chunk.Offset != blob.Size {
+		r.log(ctx).Debug().Str("blob", id).Int64("offset", chunk.Offset).Int64("size", blob.Size).Msg("chunk offset doesn't match blob size")
+		return nil, false, ErrBlobOffset
+	}
+
+	r.log(ctx).Debug().Str("blob", id).Int64("offset", chunk.Offset).Msg("saving chunk")
+	if _, err := r.chunks.ReplaceOne(
+		ctx,
+		bson.D{
+			{Key: "user_id", Value: chunk.UserID},
+			{Key: "blob_id", Value: chunk.BlobID},
+			{Key: "offset", Value: chunk.Offset},
+		},
+		chunk,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to save chunk")
+		return nil, false, err
+	}
+
+	r.log(ctx).Debug().Str("blob", id).Int64("size", chunk.End).Msg("updating blob size")
+	result, err := r.blobs.UpdateOne(
+		ctx,
+		bson.D{
+			{Key: "user_id", Value: chunk.UserID},
+			{Key: "id", Value: chunk.BlobID},
+			{Key: "size", Value: chunk.Offset},
+		},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "size", Value: chunk.End},
+			{Key: "updated_at", Value: now},
+		}}},
+	)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to update blob")
+		return nil, false, err
+	}
+	if result.MatchedCount == 0 {
+		r.log(ctx).Debug().Str("blob", id).Msg("blob was changed concurrently")
+		return nil, false, ErrBlobOffset
+	}
+
+	blob.Size = chunk.End
+	blob.UpdatedAt = now
+	if last {
+		return blob, true, r.completeBlob(ctx, blob)
+	}
+	return blob, true, nil
+}
+
+// completeBlob moves staged chunks of the blob to the blob store,
+// marks blob as complete and removes its chunks. Blob is completed
+// by the single request, which claims it first. Concurrent requests
+// return the completed blob, or ErrBlobCompleting while it is still
+// being completed, so the client resumes upload later.
+func (r *repository) completeBlob(ctx context.Context, blob *models.Blob) error {
+	id := blob.ID.String()
+	claimedAt := time.Now().UTC()
+
+	r.log(ctx).Debug().Str("blob", id).Msg("claiming blob completion")
+	claim, err := r.blobs.UpdateOne(
+		ctx,
+		bson.D{
+			{Key: "user_id", Value: blob.UserID},
+			{Key: "id", Value: blob.ID},
+			{Key: "complete", Value: false},
+			{Key: "completing_at", Value: bson.D{{Key: "$not", Value: bson.D{
+				{Key: "$gt", Value: claimedAt.Add(-blobCompletionTimeout)},
+			}}}},
+		},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "completing_at", Value: claimedAt}}}},
+	)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to claim blob completion")
+		return err
+	}
+	if claim.MatchedCount == 0 {
+		r.log(ctx).Debug().Str("blob", id).Msg("blob is already completed by another request")
+		current, err := r.ReadBlob(ctx, blob.UserID, blob.ID)
+		if err != nil {
+			return err
+		}
+		if !current.Complete {
+			return ErrBlobCompleting
+		}
+		*blob = *current
+		return nil
+	}
+	if err := r.moveBlob(ctx, blob); err != nil {
+		r.releaseBlob(ctx, blob, claimedAt)
+		return err
+	}
+
+	r.log(ctx).Debug().Str("blob", id).Msg("removing staged chunks")
+	if _, err := r.chunks.DeleteMany(
+		ctx,
+		bson.D{{Key: "user_id", Value: blob.UserID}, {Key: "blob_id", Value: blob.ID}},
+	); err != nil {
+		// blob is already complete, leftover chunks are harmless
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to remove staged chunks")
+	}
+	return nil
+}
+
+// moveBlob puts staged chunks of the blob claimed for completion
+// to the blob store and marks blob as complete.
+func (r *repository) moveBlob(ctx context.Context, blob *models.Blob) error {
+	id := blob.ID.String()
+
+	r.log(ctx).Debug().Str("blob", id).Msg("moving blob to the store")
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(r.readBlobChunks(ctx, blob.UserID, blob.ID, func(chunk *models.BlobChunk) error {
+			_, err := pw.Write(chunk.Data)
+			return err
+		}))
+	}()
+	hash := sha256.New()
+	size, err := r.store.Put(ctx, blob.UserID, blob.ID, io.TeeReader(pr, hash))
+	pr.Close()
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to move blob to the store")
+		return err
+	}
+	if size != blob.Size {
+		r.log(ctx).Error().Caller().Str("blob", id).Int64("stored", size).Int64("size", blob.Size).Msg("stored blob size mismatch")
+		return ErrBlobOffset
+	}
+
+	r.log(ctx).Debug().Str("blob", id).Msg("completing blob")
+	now := time.Now().UTC()
+	blob.Hash = hex.EncodeToString(hash.Sum(nil))
+	if _, err := r.blobs.UpdateOne(
+		ctx,
+		bson.D{{Key: "user_id", Value: blob.UserID}, {Key: "id", Value: blob.ID}},
+		bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: "hash", Value: blob.Hash},
+				{Key: "complete", Value: true},
+				{Key: "updated_at", Value: now},
+			}},
+			{Key: "$unset", Value: bson.D{{Key: "completing_at", Value: ""}}},
+		},
+	); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to complete blob")
+		return err
+	}
+	blob.Complete = true
+	blob.UpdatedAt = now
+	return nil
+}
+
+// releaseBlob drops the claim of the blob completion, which failed,
+// so the client can retry it right away.
+func (r *repository) releaseBlob(ctx context.Context, blob *models.Blob, claimedAt time.Time) {
+	id := blob.ID.String()
+
+	r.log(ctx).Debug().Str("blob", id).Msg("releasing blob completion claim")
+	if _, err := r.blobs.UpdateOne(
+		ctx,
+		bson.D{
+			{Key: "user_id", Value: blob.UserID},
+			{Key: "id", Value: blob.ID},
+			{Key: "completing_at", Value: claimedAt},
+		},
+		bson.D{{Key: "$unset", Value: bson.D{{Key: "completing_at", Value: ""}}}},
+	); err != nil {
+		// claim expires after blobCompletionTimeout
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to release blob completion claim")
+	}
+}
+
+// StoreBlob puts content read from r to the blob store as the new complete
+// blob of the user. It is used for content received in one piece.
+func (r *repository) StoreBlob(ctx context.Context, userID uuid.UUID, content io.Reader) (*models.Blob, error) {
+	now := time.Now().UTC()
+	blob := &models.Blob{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Complete:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	id := blob.ID.String()
+
+	r.log(ctx).Debug().Str("blob", id).Msg("putting blob to the store")
+	hash := sha256.New()
+	size, err := r.store.Put(ctx, userID, blob.ID, io.TeeReader(content, hash))
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to put blob to the store")
+		return nil, err
+	}
+	blob.Size = size
+	blob.Hash = hex.EncodeToString(hash.Sum(nil))
+
+	r.log(ctx).Debug().Str("blob", id).Msg("inserting blob")
+	if _, err := r.blobs.InsertOne(ctx, blob); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to insert blob")
+		return nil, err
+	}
+	return blob, nil
+}
+
+// OpenBlob returns reader of the user's complete blob content
+// starting at provided offset.
+func (r *repository) OpenBlob(ctx context.Context, userID uuid.UUID, blobID uuid.UUID, offset int64) (io.ReadCloser, error) {
+	return r.store.Open(ctx, userID, blobID, offset)
+}
+
+// attachBlob marks the user's complete blob as referred to by the item,
+// reporting whether the blob wasn't attached to it before. Blob, which is
+// already attached to another item, isn't changed and ErrBlobAttached is returned.
+func (r *repository) attachBlob(ctx context.Context, userID uuid.UUID, blobID uuid.UUID, itemID uuid.UUID) (bool, error) {
+	id := blobID.String()
+
+	r.log(ctx).Debug().Str("blob", id).Str("item", itemID.String()).Msg("attaching blob to item")
+	result, err := r.blobs.UpdateOne(
+		ctx,
+		bson.D{
+			{Key: "user_id", Value: userID},
+			{Key: "id", Value: blobID},
+			{Key: "complete", Value: true},
+			{Key: "item_id", Value: bson.D{{Key: "$in", Value: bson.A{nil, itemID}}}},
+		},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "item_id", Value: itemID}}}},
+	)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to attach blob")
+		return false, err
+	}
+	if result.MatchedCount == 0 {
+		r.log(ctx).Debug().Str("blob", id).Msg("blob is attached to another item")
+		return false, ErrBlobAttached
+	}
+	return result.ModifiedCount != 0, nil
+}
+
+// detachBlob releases blob attached to the item, which failed to be written.
+func (r *repository) detachBlob(ctx context.Context, userID uuid.UUID, blobID uuid.UUID, itemID uuid.UUID) {
+	id := blobID.String()
+
+	r.log(ctx).Debug().Str("blob", id).Str("item", itemID.String()).Msg("detaching blob from item")
+	if _, err := r.blobs.UpdateOne(
+		ctx,
+		bson.D{
+			{Key: "user_id", Value: userID},
+			{Key: "id", Value: blobID},
+			{Key: "item_id", Value: itemID},
+		},
+		bson.D{{Key: "$unset", Value: bson.D{{Key: "item_id", Value: ""}}}},
+	); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to detach blob")
+	}
+}
+
+// deleteBlob removes the user's blob along with its content. Blob is removed
+// after the item referring to it was changed, so failure is only logged.
+func (r *repository) deleteBlob(ctx context.Context, userID uuid.UUID, blobID uuid.UUID) {
+	id := blobID.String()
+
+	r.log(ctx).Debug().Str("blob", id).Msg("removing blob")
+	if err := r.store.Delete(ctx, userID, blobID); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to remove blob content")
+		return
+	}
+	if _, err := r.blobs.DeleteOne(ctx, bson.D{{Key: "user_id", Value: userID}, {Key: "id", Value: blobID}}); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to remove blob")
+	}
+}
+
+// ExpireBlobs removes blobs, which were uploaded more than blob expiry ago,
+// but weren't attached to any item, releasing their bytes in the usage of
+// their owners, and returns the number of removed blobs. Blobs stored along
+// with binary items, which don't attach them, are attached instead.
+func (r *repository) ExpireBlobs(ctx context.Context) (int64, error) {
+	createdBefore := time.Now().UTC().Add(-r.blobExpiry())
+
+	r.log(ctx).Debug().Time("before", createdBefore).Msg("searching for expired blobs")
+	cursor, err := r.blobs.Find(ctx, bson.D{
+		{Key: "item_id", Value: nil},
+		{Key: "created_at", Value: bson.D{{Key: "$lt", Value: createdBefore}}},
+	})
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to find expired blobs")
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var expired int64
+	for cursor.Next(ctx) {
+		var blob models.Blob
+		if err := cursor.Decode(&blob); err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Msg("unable to decode blob")
+			return expired, err
+		}
+		removed, err := r.expireBlob(ctx, &blob)
+		if err != nil {
+			return expired, err
+		}
+		if removed {
+			expired++
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to iterate over expired blobs")
+		return expired, err
+	}
+	return expired, nil
+}
+
+// expireBlob removes the blob, which wasn't attached to any item in time,
+// and reports whether it was removed. Blob, which is referred to by the item,
+// is attached to it instead.
+func (r *repository) expireBlob(ctx context.Context, blob *models.Blob) (bool, error) {
+	id := blob.ID.String()
+
+	r.log(ctx).Debug().Str("blob", id).Msg("searching for item referring to blob")
+	var ref struct {
+		ID uuid.UUID `bson:"id"`
+	}
+	err := r.items.FindOne(
+		ctx,
+		bson.D{
+			{Key: "user_id", Value: blob.UserID},
+			{Key: "blob_id", Value: blob.ID},
+			{Key: "kind", Value: bson.D{{Key: "$ne", Value: kindTombstone}}},
+		},
+		options.FindOne().SetProjection(bson.D{{Key: "id", Value: 1}}),
+	).Decode(&ref)
+	if err == nil {
+		_, err = r.attachBlob(ctx, blob.UserID, blob.ID, ref.ID)
+		return false, err
+	}
+	if err != mongo.ErrNoDocuments {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to find item referring to blob")
+		return false, err
+	}
+
+	// blob attached or claimed for completion since it was found is kept
+	r.log(ctx).Debug().Str("blob", id).Msg("removing expired blob")
+	result, err := r.blobs.DeleteOne(ctx, bson.D{
+		{Key: "user_id", Value: blob.UserID},
+		{Key: "id", Value: blob.ID},
+		{Key: "item_id", Value: nil},
+		{Key: "completing_at", Value: bson.D{{Key: "$not", Value: bson.D{
+			{Key: "$gt", Value: time.Now().UTC().Add(-blobCompletionTimeout)},
+		}}}},
+	})
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to remove expired blob")
+		return false, err
+	}
+	if result.DeletedCount == 0 {
+		return false, nil
+	}
+
+	// leftovers are harmless, chunks expire on their own
+	if _, err := r.chunks.DeleteMany(
+		ctx,
+		bson.D{{Key: "user_id", Value: blob.UserID}, {Key: "blob_id", Value: blob.ID}},
+	); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to remove staged chunks")
+	}
+	if err := r.store.Delete(ctx, blob.UserID, blob.ID); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to remove blob content")
+	}
+	if err := r.UpdateUsage(ctx, blob.UserID, &models.Usage{Bytes: -blob.Size}, nil); err != nil && err != ErrNoUser {
+		return true, err
+	}
+	return true, nil
+}
+
+// blobExpiry returns how long uploaded blob is kept before it is attached to an item.
+func (r *repository) blobExpiry() time.Duration {
+	if r.cfg.Blobs.Expiry > 0 {
+		return r.cfg.Blobs.Expiry
+	}
+	return defaultBlobExpiry
+}
+
+// ReadBlob searches the database for blob with provided UUID
+// of the user with provided UUID, returning found blob or ErrNoBlob.
+func (r *repository) ReadBlob(ctx context.Context, userID uuid.UUID, blobID uuid.UUID) (*models.Blob, error) {
+	id := blobID.String()
+
+	r.log(ctx).Debug().Str("blob", id).Msg("searching for blob")
+	result := r.blobs.FindOne(ctx, bson.D{{Key: "user_id", Value: userID}, {Key: "id", Value: blobID}})
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			r.log(ctx).Debug().Str("blob", id).Msg("no such blob in the database")
+			return nil, ErrNoBlob
+		}
+		r.log(ctx).
+			Err(result.Err()).
+			Caller().
+			Str("blob", id).
+			Msg("unable to find blob")
+		return nil, result.Err()
+	}
+
+	var blob models.Blob
+	if err := result.Decode(&blob); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to decode blob")
+		return nil, err
+	}
+	return &blob, nil
+}
+
+// readBlobChunks passes staged chunks of the user's blob to fn in order.
+// Reading stops on the first error fn returns.
+func (r *repository) readBlobChunks(
+	ctx context.Context,
+	userID uuid.UUID,
+	blobID uuid.UUID,
+	fn func(*models.BlobChunk) error,
+) error {
+	id := blobID.String()
+
+	r.logger.Debug().Str("blob", id).Msg("searching for chunks")
+	cursor, err := r.chunks.Find(
+		ctx,
+		bson.D{
+			{Key: "user_id", Value: userID},
+			{Key: "blob_id", Value: blobID},
+		},
+		options.Find().SetSort(bson.D{{Key: "offset", Value: 1}}),
+	)
+	if err != nil {
+		r.logger.
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to find chunks")
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var chunk models.BlobChunk
+		if err := cursor.Decode(&chunk); err != nil {
+			r.logger.
+				Err(err).
+				Caller().
+				Str("blob", id).
+				Msg("unable to decode chunk")
+			return err
+		}
+		if err := fn(&chunk); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		r.logger.
+			Err(err).
+			Caller().
+			Str("blob", id).
+			Msg("unable to read chunks")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,558 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestAppendBlobChunk(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	uid := uuid.New()
+	blobID := uuid.New()
+
+	mt.Run("first chunk", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			blobs:  mt.Coll,
+			chunks: mt.Coll,
+		}
+
+		mt.AddMockResponses(
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}},
+			blobResponse(uid, blobID, 0, false),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+		)
+
+		blob, appended, err := repo.AppendBlobChunk(context.Background(), &models.BlobChunk{
+			UserID: uid,
+			BlobID: blobID,
+			Data:   []byte("chunk"),
+		}, false)
+		require.NoError(t, err)
+		require.True(t, appended)
+		require.Equal(t, int64(5), blob.Size)
+		require.False(t, blob.Complete)
+		mt.GetStartedEvent()
+		mt.GetStartedEvent()
+		chunk := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		require.False(t, chunk.Lookup("u", "expires_at").Time().IsZero())
+	})
+
+	mt.Run("last chunk", func(mt *mtest.T) {
+		store, err := NewFileStore(t.TempDir(), logger)
+		require.NoError(t, err)
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			blobs:  mt.Coll,
+			chunks: mt.Coll,
+			store:  store,
+		}
+
+		mt.AddMockResponses(
+			blobResponse(uid, blobID, 5, false),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+			mtest.CreateCursorResponse(0, "gokeeper.blob_chunks", mtest.FirstBatch,
+				chunkResponse(blobID, 0, "first"),
+				chunkResponse(blobID, 5, "chunk"),
+			),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 2}},
+		)
+
+		blob, appended, err := repo.AppendBlobChunk(context.Background(), &models.BlobChunk{
+			UserID: uid,
+			BlobID: blobID,
+			Offset: 5,
+			Data:   []byte("chunk"),
+		}, true)
+		require.NoError(t, err)
+		require.True(t, appended)
+		require.Equal(t, int64(10), blob.Size)
+		require.True(t, blob.Complete)
+		sum := sha256.Sum256([]byte("firstchunk"))
+		require.Equal(t, hex.EncodeToString(sum[:]), blob.Hash)
+
+		content, err := store.Open(context.Background(), uid, blobID, 5)
+		require.NoError(t, err)
+		defer content.Close()
+		data, err := io.ReadAll(content)
+		require.NoError(t, err)
+		require.Equal(t, []byte("chunk"), data)
+	})
+
+	mt.Run("resent last chunk", func(mt *mtest.T) {
+		store, err := NewFileStore(t.TempDir(), logger)
+		require.NoError(t, err)
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			blobs:  mt.Coll,
+			chunks: mt.Coll,
+			store:  store,
+		}
+
+		mt.AddMockResponses(
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}},
+			blobResponse(uid, blobID, 5, false),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+			mtest.CreateCursorResponse(0, "gokeeper.blob_chunks", mtest.FirstBatch,
+				chunkResponse(blobID, 0, "chunk"),
+			),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}},
+		)
+
+		blob, appended, err := repo.AppendBlobChunk(context.Background(), &models.BlobChunk{
+			UserID: uid,
+			BlobID: blobID,
+			Data:   []byte("chunk"),
+		}, true)
+		require.NoError(t, err)
+		require.False(t, appended)
+		require.True(t, blob.Complete)
+	})
+
+	mt.Run("completed concurrently", func(mt *mtest.T) {
+		store, err := NewFileStore(t.TempDir(), logger)
+		require.NoError(t, err)
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			blobs:  mt.Coll,
+			chunks: mt.Coll,
+			store:  store,
+		}
+
+		// another request with the same last chunk has already claimed completion
+		mt.AddMockResponses(
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}},
+			blobResponse(uid, blobID, 5, false),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}, {Key: "nModified", Value: 0}},
+			blobResponse(uid, blobID, 5, true),
+		)
+
+		blob, appended, err := repo.AppendBlobChunk(context.Background(), &models.BlobChunk{
+			UserID: uid,
+			BlobID: blobID,
+			Data:   []byte("chunk"),
+		}, true)
+		require.NoError(t, err)
+		require.False(t, appended)
+		require.True(t, blob.Complete)
+		mt.GetStartedEvent()
+		mt.GetStartedEvent()
+		claim := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		require.False(t, claim.Lookup("q", "complete").Boolean())
+		require.Equal(t, "find", mt.GetStartedEvent().CommandName)
+		require.Nil(t, mt.GetStartedEvent())
+		_, err = store.Open(context.Background(), uid, blobID, 0)
+		require.ErrorIs(t, err, ErrNoBlob)
+	})
+
+	mt.Run("being completed concurrently", func(mt *mtest.T) {
+		store, err := NewFileStore(t.TempDir(), logger)
+		require.NoError(t, err)
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			blobs:  mt.Coll,
+			chunks: mt.Coll,
+			store:  store,
+		}
+
+		// another request has claimed completion, but hasn't finished it yet
+		mt.AddMockResponses(
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}},
+			blobResponse(uid, blobID, 5, false),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}, {Key: "nModified", Value: 0}},
+			blobResponse(uid, blobID, 5, false),
+		)
+
+		_, _, err = repo.AppendBlobChunk(context.Background(), &models.BlobChunk{
+			UserID: uid,
+			BlobID: blobID,
+			Data:   []byte("chunk"),
+		}, true)
+		require.ErrorIs(t, err, ErrBlobCompleting)
+	})
+
+	mt.Run("missing chunks", func(mt *mtest.T) {
+		store, err := NewFileStore(t.TempDir(), logger)
+		require.NoError(t, err)
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			blobs:  mt.Coll,
+			chunks: mt.Coll,
+			store:  store,
+		}
+
+		mt.AddMockResponses(
+			blobResponse(uid, blobID, 10, false),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+			mtest.CreateCursorResponse(0, "gokeeper.blob_chunks", mtest.FirstBatch,
+				chunkResponse(blobID, 5, "chunk"),
+			),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+		)
+
+		_, _, err = repo.AppendBlobChunk(context.Background(), &models.BlobChunk{
+			UserID: uid,
+			BlobID: blobID,
+			Offset: 5,
+			Data:   []byte("chunk"),
+		}, true)
+		require.ErrorIs(t, err, ErrBlobOffset)
+	})
+
+	mt.Run("already received", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			blobs:  mt.Coll,
+			chunks: mt.Coll,
+		}
+
+		mt.AddMockResponses(blobResponse(uid, blobID, 10, false))
+
+		blob, appended, err := repo.AppendBlobChunk(context.Background(), &models.BlobChunk{
+			UserID: uid,
+			BlobID: blobID,
+			Offset: 5,
+			Data:   []byte("chunk"),
+		}, false)
+		require.NoError(t, err)
+		require.False(t, appended)
+		require.Equal(t, int64(10), blob.Size)
+	})
+
+	mt.Run("offset mismatch", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			blobs:  mt.Coll,
+			chunks: mt.Coll,
+		}
+
+		mt.AddMockResponses(blobResponse(uid, blobID, 5, false))
+
+		_, _, err := repo.AppendBlobChunk(context.Background(), &models.BlobChunk{
+			UserID: uid,
+			BlobID: blobID,
+			Offset: 10,
+			Data:   []byte("chunk"),
+		}, false)
+		require.ErrorIs(t, err, ErrBlobOffset)
+	})
+
+	mt.Run("complete", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			blobs:  mt.Coll,
+			chunks: mt.Coll,
+		}
+
+		mt.AddMockResponses(blobResponse(uid, blobID, 5, true))
+
+		_, _, err := repo.AppendBlobChunk(context.Background(), &models.BlobChunk{
+			UserID: uid,
+			BlobID: blobID,
+			Offset: 5,
+			Data:   []byte("chunk"),
+		}, false)
+		require.ErrorIs(t, err, ErrBlobComplete)
+	})
+
+	mt.Run("no blob", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			blobs:  mt.Coll,
+			chunks: mt.Coll,
+		}
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "gokeeper.blobs", mtest.FirstBatch))
+
+		_, _, err := repo.AppendBlobChunk(context.Background(), &models.BlobChunk{
+			UserID: uid,
+			BlobID: blobID,
+			Offset: 5,
+			Data:   []byte("chunk"),
+		}, false)
+		require.ErrorIs(t, err, ErrBlobOffset)
+	})
+
+	mt.Run("nil chunk", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			blobs:  mt.Coll,
+			chunks: mt.Coll,
+		}
+
+		_, _, err := repo.AppendBlobChunk(context.Background(), nil, false)
+		require.ErrorIs(t, err, ErrNilArgument)
+	})
+}
+
+func TestExpireBlobs(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	uid := uuid.New()
+	blobID := uuid.New()
+
+	mt.Run("unattached", func(mt *mtest.T) {
+		store, err := NewFileStore(t.TempDir(), logger)
+		require.NoError(t, err)
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+			blobs:  mt.Coll,
+			chunks: mt.Coll,
+			store:  store,
+		}
+
+		mt.AddMockResponses(
+			blobResponse(uid, blobID, 5, false),
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+		)
+
+		expired, err := repo.ExpireBlobs(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, int64(1), expired)
+		find := mt.GetStartedEvent().Command
+		require.Equal(t, bson.TypeNull, find.Lookup("filter", "item_id").Type)
+		mt.GetStartedEvent()
+		remove := mt.GetStartedEvent().Command.Lookup("deletes").Array().Index(0).Value().Document()
+		require.Equal(t, bson.TypeNull, remove.Lookup("q", "item_id").Type)
+		require.Equal(t, "delete", mt.GetStartedEvent().CommandName)
+		usage := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		require.Equal(t, int64(-5), usage.Lookup("u", "$inc", "usage.bytes").Int64())
+	})
+
+	mt.Run("referred to by item", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			items:  mt.Coll,
+			blobs:  mt.Coll,
+		}
+		itemID := uuid.New()
+
+		mt.AddMockResponses(
+			blobResponse(uid, blobID, 5, true),
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch, bson.D{{Key: "id", Value: itemID}}),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+		)
+
+		expired, err := repo.ExpireBlobs(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, expired)
+		mt.GetStartedEvent()
+		mt.GetStartedEvent()
+		attach := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		var attachedTo uuid.UUID
+		require.NoError(t, attach.Lookup("u", "$set", "item_id").Unmarshal(&attachedTo))
+		require.Equal(t, itemID, attachedTo)
+		require.Nil(t, mt.GetStartedEvent())
+	})
+
+	mt.Run("attached meanwhile", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			items:  mt.Coll,
+			blobs:  mt.Coll,
+		}
+
+		mt.AddMockResponses(
+			blobResponse(uid, blobID, 5, true),
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}},
+		)
+
+		expired, err := repo.ExpireBlobs(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, expired)
+		mt.GetStartedEvent()
+		mt.GetStartedEvent()
+		require.Equal(t, "delete", mt.GetStartedEvent().CommandName)
+		require.Nil(t, mt.GetStartedEvent())
+	})
+}
+
+func TestReadBlob(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			blobs:  mt.Coll,
+		}
+		uid := uuid.New()
+		blobID := uuid.New()
+
+		mt.AddMockResponses(blobResponse(uid, blobID, 42, true))
+
+		blob, err := repo.ReadBlob(context.Background(), uid, blobID)
+		require.NoError(t, err)
+		require.Equal(t, blobID, blob.ID)
+		require.Equal(t, int64(42), blob.Size)
+		require.True(t, blob.Complete)
+	})
+
+	mt.Run("no blob", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			blobs:  mt.Coll,
+		}
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "gokeeper.blobs", mtest.FirstBatch))
+
+		_, err := repo.ReadBlob(context.Background(), uuid.New(), uuid.New())
+		require.ErrorIs(t, err, ErrNoBlob)
+	})
+}
+
+func TestStoreBlob(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		store, err := NewFileStore(t.TempDir(), logger)
+		require.NoError(t, err)
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			blobs:  mt.Coll,
+			store:  store,
+		}
+		uid := uuid.New()
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		blob, err := repo.StoreBlob(context.Background(), uid, strings.NewReader("some bins"))
+		require.NoError(t, err)
+		require.Equal(t, int64(9), blob.Size)
+		require.True(t, blob.Complete)
+		sum := sha256.Sum256([]byte("some bins"))
+		require.Equal(t, hex.EncodeToString(sum[:]), blob.Hash)
+
+		content, err := repo.OpenBlob(context.Background(), uid, blob.ID, 0)
+		require.NoError(t, err)
+		defer content.Close()
+		data, err := io.ReadAll(content)
+		require.NoError(t, err)
+		require.Equal(t, []byte("some bins"), data)
+	})
+
+	mt.Run("insert err", func(mt *mtest.T) {
+		store, err := NewFileStore(t.TempDir(), logger)
+		require.NoError(t, err)
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			blobs:  mt.Coll,
+			store:  store,
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+
+		_, err = repo.StoreBlob(context.Background(), uuid.New(), strings.NewReader("some bins"))
+		require.Error(t, err)
+	})
+}
+
+func blobResponse(userID, blobID uuid.UUID, size int64, complete bool) bson.D {
+	return mtest.CreateCursorResponse(0, "gokeeper.blobs", mtest.FirstBatch, bson.D{
+		{Key: "id", Value: blobID},
+		{Key: "user_id", Value: userID},
+		{Key: "size", Value: size},
+		{Key: "complete", Value: complete},
+	})
+}
+
+func chunkResponse(blobID uuid.UUID, offset int64, data string) bson.D {
+	return bson.D{
+		{Key: "blob_id", Value: blobID},
+		{Key: "offset", Value: offset},
+		{Key: "end", Value: offset + int64(len(data))},
+		{Key: "data", Value: []byte(data)},
+	}
+}
@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/logging"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrUnknownBlobStore is raised when configuration refers to
+// blob store which is not supported.
+var ErrUnknownBlobStore = errors.New("unknown blob store")
+
+// Supported blob stores.
+const (
+	blobStoreGridFS = "gridfs"
+	blobStoreFS     = "fs"
+)
+
+// BlobStore keeps content of the users' blobs outside of their items.
+type BlobStore interface {
+	// Put stores content read from r as the user's blob, replacing
+	// previously stored content of the blob, and returns its size.
+	Put(ctx context.Context, userID uuid.UUID, blobID uuid.UUID, r io.Reader) (int64, error)
+	// Open returns reader of the user's blob content starting at provided offset.
+	Open(ctx context.Context, userID uuid.UUID, blobID uuid.UUID, offset int64) (io.ReadCloser, error)
+	// Delete removes content of the user's blob. Removing blob,
+	// which wasn't stored, is not an error.
+	Delete(ctx context.Context, userID uuid.UUID, blobID uuid.UUID) error
+}
+
+// newBlobStore initializes blob store of provided kind.
+func newBlobStore(kind string, dir string, db *mongo.Database, logger zerolog.Logger) (BlobStore, error) {
+	switch kind {
+	case "", blobStoreGridFS:
+		return NewGridFSStore(db, logger)
+	case blobStoreFS:
+		return NewFileStore(dir, logger)
+	default:
+		return nil, ErrUnknownBlobStore
+	}
+}
+
+// gridFSStore keeps blobs in the mongo GridFS bucket.
+type gridFSStore struct {
+	bucket *gridfs.Bucket
+	logger zerolog.Logger
+}
+
+// log returns logger of the request ctx belongs to,
+// falling back to store's logger.
+func (s *gridFSStore) log(ctx context.Context) *zerolog.Logger {
+	return logging.FromContext(ctx, &s.logger)
+}
+
+// NewGridFSStore initializes blob store on top of the GridFS bucket of provided database.
+func NewGridFSStore(db *mongo.Database, logger zerolog.Logger) (BlobStore, error) {
+	bucket, err := gridfs.NewBucket(db, options.GridFSBucket().SetName("blob_store"))
+	if err != nil {
+		logger.
+			Err(err).
+			Caller().
+			Msg("unable to initialize gridfs bucket")
+		return nil, err
+	}
+	return &gridFSStore{bucket: bucket, logger: logger}, nil
+}
+
+// Put uploads content of the blob to the GridFS bucket.
+func (s *gridFSStore) Put(ctx context.Context, userID uuid.UUID, blobID uuid.UUID, r io.Reader) (int64, error) {
+	key := blobKey(userID, blobID)
+	if err := s.Delete(ctx, userID, blobID); err != nil {
+		return 0, err
+	}
+
+	s.log(ctx).Debug().Str("blob", key).Msg("uploading blob to gridfs")
+	stream, err := s.bucket.OpenUploadStreamWithID(key, key)
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", key).
+			Msg("unable to open gridfs upload stream")
+		return 0, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		stream.SetWriteDeadline(deadline)
+	}
+
+	size, err := io.Copy(stream, r)
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", key).
+			Msg("unable to upload blob to gridfs")
+		stream.Abort()
+		return 0, err
+	}
+	if err := stream.Close(); err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", key).
+			Msg("unable to finish gridfs upload")
+		return 0, err
+	}
+	return size, nil
+}
+
+// Open opens GridFS download stream of the blob.
+func (s *gridFSStore) Open(ctx context.Context, userID uuid.UUID, blobID uuid.UUID, offset int64) (io.ReadCloser, error) {
+	key := blobKey(userID, blobID)
+
+	s.log(ctx).Debug().Str("blob", key).Int64("offset", offset).Msg("opening gridfs blob")
+	stream, err := s.bucket.OpenDownloadStream(key)
+	if err != nil {
+		if err == gridfs.ErrFileNotFound {
+			return nil, ErrNoBlob
+		}
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", key).
+			Msg("unable to open gridfs download stream")
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		stream.SetReadDeadline(deadline)
+	}
+	if _, err := stream.Skip(offset); err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", key).
+			Msg("unable to skip to offset")
+		stream.Close()
+		return nil, err
+	}
+	return stream, nil
+}
+
+// Delete removes blob from the GridFS bucket. Bucket deadlines are shared
+// by all requests, so unlike streams deletion isn't bound to ctx deadline.
+func (s *gridFSStore) Delete(ctx context.Context, userID uuid.UUID, blobID uuid.UUID) error {
+	key := blobKey(userID, blobID)
+
+	s.log(ctx).Debug().Str("blob", key).Msg("removing gridfs blob")
+	if err := s.bucket.Delete(key); err != nil && err != gridfs.ErrFileNotFound {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", key).
+			Msg("unable to remove gridfs blob")
+		return err
+	}
+	return nil
+}
+
+// fileStore keeps blobs as files in the local directory,
+// one subdirectory per user.
+type fileStore struct {
+	dir    string
+	logger zerolog.Logger
+}
+
+// log returns logger of the request ctx belongs to,
+// falling back to store's logger.
+func (s *fileStore) log(ctx context.Context) *zerolog.Logger {
+	return logging.FromContext(ctx, &s.logger)
+}
+
+// NewFileStore initializes blob store in provided local directory.
+func NewFileStore(dir string, logger zerolog.Logger) (BlobStore, error) {
+	if dir == "" {
+		logger.Err(ErrNilArgument).Str("arg", "dir").Msg("blob store directory can't be empty")
+		return nil, ErrNilArgument
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		logger.
+			Err(err).
+			Caller().
+			Str("dir", dir).
+			Msg("unable to create blob store directory")
+		return nil, err
+	}
+	return &fileStore{dir: dir, logger: logger}, nil
+}
+
+// Put writes content of the blob to temporary file, which replaces
+// blob's file once whole content is written.
+func (s *fileStore) Put(ctx context.Context, userID uuid.UUID, blobID uuid.UUID, r io.Reader) (int64, error) {
+	path := s.path(userID, blobID)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to create user's blob directory")
+		return 0, err
+	}
+
+	s.log(ctx).Debug().Str("blob", blobID.String()).Msg("writing blob file")
+	file, err := os.CreateTemp(filepath.Dir(path), blobID.String()+".*.tmp")
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", blobID.String()).
+			Msg("unable to create blob file")
+		return 0, err
+	}
+	defer os.Remove(file.Name())
+
+	size, err := io.Copy(file, r)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", blobID.String()).
+			Msg("unable to write blob file")
+		return 0, err
+	}
+	return size, nil
+}
+
+// Open opens blob's file at provided offset.
+func (s *fileStore) Open(ctx context.Context, userID uuid.UUID, blobID uuid.UUID, offset int64) (io.ReadCloser, error) {
+	s.log(ctx).Debug().Str("blob", blobID.String()).Int64("offset", offset).Msg("opening blob file")
+	file, err := os.Open(s.path(userID, blobID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoBlob
+		}
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", blobID.String()).
+			Msg("unable to open blob file")
+		return nil, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", blobID.String()).
+			Msg("unable to seek blob file")
+		file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
+// Delete removes blob's file.
+func (s *fileStore) Delete(ctx context.Context, userID uuid.UUID, blobID uuid.UUID) error {
+	s.log(ctx).Debug().Str("blob", blobID.String()).Msg("removing blob file")
+	if err := os.Remove(s.path(userID, blobID)); err != nil && !os.IsNotExist(err) {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("blob", blobID.String()).
+			Msg("unable to remove blob file")
+		return err
+	}
+	return nil
+}
+
+// path returns path of the blob's file.
+func (s *fileStore) path(userID uuid.UUID, blobID uuid.UUID) string {
+	return filepath.Join(s.dir, userID.String(), blobID.String())
+}
+
+// blobKey returns key blob is stored under. Blob ids are chosen
+// by clients, so keys are scoped by the owner.
+func blobKey(userID uuid.UUID, blobID uuid.UUID) string {
+	return userID.String() + "/" + blobID.String()
+}
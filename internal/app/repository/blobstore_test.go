@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctx := context.Background()
+
+	store, err := NewFileStore(t.TempDir(), logger)
+	require.NoError(t, err)
+	uid := uuid.New()
+	blobID := uuid.New()
+
+	size, err := store.Put(ctx, uid, blobID, strings.NewReader("old content"))
+	require.NoError(t, err)
+	require.Equal(t, int64(11), size)
+	size, err = store.Put(ctx, uid, blobID, strings.NewReader("some content"))
+	require.NoError(t, err)
+	require.Equal(t, int64(12), size)
+
+	content, err := store.Open(ctx, uid, blobID, 5)
+	require.NoError(t, err)
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.NoError(t, content.Close())
+	require.Equal(t, []byte("content"), data)
+
+	_, err = store.Open(ctx, uuid.New(), blobID, 0)
+	require.ErrorIs(t, err, ErrNoBlob)
+
+	require.NoError(t, store.Delete(ctx, uid, blobID))
+	require.NoError(t, store.Delete(ctx, uid, blobID))
+	_, err = store.Open(ctx, uid, blobID, 0)
+	require.ErrorIs(t, err, ErrNoBlob)
+
+	_, err = NewFileStore("", logger)
+	require.ErrorIs(t, err, ErrNilArgument)
+}
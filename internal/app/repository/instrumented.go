@@ -0,0 +1,347 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/metrics"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer starts spans of data layer operations.
+var tracer = otel.Tracer("github.com/serjyuriev/yandex-diploma-2/internal/app/repository")
+
+// instrumentedRepository traces every operation of the wrapped repository
+// and records its latency.
+type instrumentedRepository struct {
+	repo Repository
+}
+
+// Instrument wraps repository, so its operations are traced
+// and their latency is exposed as Prometheus metrics.
+func Instrument(repo Repository) Repository {
+	return &instrumentedRepository{repo: repo}
+}
+
+// start begins span of the named operation. Returned function ends it,
+// recording operation's latency and error.
+func (r *instrumentedRepository) start(ctx context.Context, operation string) (context.Context, func(error)) {
+	began := time.Now()
+	ctx, span := tracer.Start(
+		ctx,
+		"repository."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+	)
+	return ctx, func(err error) {
+		metrics.ObserveRepository(operation, began)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// Migrate calls Migrate of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) Migrate(ctx context.Context) error {
+	ctx, end := r.start(ctx, "Migrate")
+	err := r.repo.Migrate(ctx)
+	end(err)
+	return err
+}
+
+// CreateUser calls CreateUser of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) CreateUser(ctx context.Context, user *models.User) error {
+	ctx, end := r.start(ctx, "CreateUser")
+	err := r.repo.CreateUser(ctx, user)
+	end(err)
+	return err
+}
+
+// ReadUserByLogin calls ReadUserByLogin of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) ReadUserByLogin(ctx context.Context, login string) (*models.User, error) {
+	ctx, end := r.start(ctx, "ReadUserByLogin")
+	res, err := r.repo.ReadUserByLogin(ctx, login)
+	end(err)
+	return res, err
+}
+
+// ReadUserByID calls ReadUserByID of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) ReadUserByID(ctx context.Context, uuid uuid.UUID) (*models.User, error) {
+	ctx, end := r.start(ctx, "ReadUserByID")
+	res, err := r.repo.ReadUserByID(ctx, uuid)
+	end(err)
+	return res, err
+}
+
+// UpdateUserPassword calls UpdateUserPassword of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) UpdateUserPassword(ctx context.Context, userID uuid.UUID, oldPassword string, password string) error {
+	ctx, end := r.start(ctx, "UpdateUserPassword")
+	err := r.repo.UpdateUserPassword(ctx, userID, oldPassword, password)
+	end(err)
+	return err
+}
+
+// CreateVaultKey calls CreateVaultKey of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) CreateVaultKey(ctx context.Context, userID uuid.UUID, key *models.VaultKey) error {
+	ctx, end := r.start(ctx, "CreateVaultKey")
+	err := r.repo.CreateVaultKey(ctx, userID, key)
+	end(err)
+	return err
+}
+
+// UpdateCredentials calls UpdateCredentials of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) UpdateCredentials(ctx context.Context, userID uuid.UUID, password string, key *models.VaultKey) error {
+	ctx, end := r.start(ctx, "UpdateCredentials")
+	err := r.repo.UpdateCredentials(ctx, userID, password, key)
+	end(err)
+	return err
+}
+
+// DeleteUser calls DeleteUser of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	ctx, end := r.start(ctx, "DeleteUser")
+	err := r.repo.DeleteUser(ctx, userID)
+	end(err)
+	return err
+}
+
+// UpdateTOTP calls UpdateTOTP of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) UpdateTOTP(ctx context.Context, userID uuid.UUID, totp *models.TOTP) error {
+	ctx, end := r.start(ctx, "UpdateTOTP")
+	err := r.repo.UpdateTOTP(ctx, userID, totp)
+	end(err)
+	return err
+}
+
+// UseTOTPStep calls UseTOTPStep of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	ctx, end := r.start(ctx, "UseTOTPStep")
+	err := r.repo.UseTOTPStep(ctx, userID, step)
+	end(err)
+	return err
+}
+
+// UseRecoveryCode calls UseRecoveryCode of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string) error {
+	ctx, end := r.start(ctx, "UseRecoveryCode")
+	err := r.repo.UseRecoveryCode(ctx, userID, hash)
+	end(err)
+	return err
+}
+
+// CreateItem calls CreateItem of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) CreateItem(ctx context.Context, item interface{}, itemType string, userID uuid.UUID, size int64) error {
+	ctx, end := r.start(ctx, "CreateItem")
+	err := r.repo.CreateItem(ctx, item, itemType, userID, size)
+	end(err)
+	return err
+}
+
+// ReadItems calls ReadItems of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) ReadItems(ctx context.Context, userID uuid.UUID) (*models.Vault, error) {
+	ctx, end := r.start(ctx, "ReadItems")
+	res, err := r.repo.ReadItems(ctx, userID)
+	end(err)
+	return res, err
+}
+
+// ReadItemsSince calls ReadItemsSince of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) ReadItemsSince(ctx context.Context, userID uuid.UUID, revision int64) (*models.Vault, error) {
+	ctx, end := r.start(ctx, "ReadItemsSince")
+	res, err := r.repo.ReadItemsSince(ctx, userID, revision)
+	end(err)
+	return res, err
+}
+
+// ReadItemsByType calls ReadItemsByType of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) ReadItemsByType(ctx context.Context, userID uuid.UUID, itemType string, items interface{}) error {
+	ctx, end := r.start(ctx, "ReadItemsByType")
+	err := r.repo.ReadItemsByType(ctx, userID, itemType, items)
+	end(err)
+	return err
+}
+
+// ReadItem calls ReadItem of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) ReadItem(ctx context.Context, userID uuid.UUID, itemID uuid.UUID) (*models.Item, error) {
+	ctx, end := r.start(ctx, "ReadItem")
+	res, err := r.repo.ReadItem(ctx, userID, itemID)
+	end(err)
+	return res, err
+}
+
+// UpdateItem calls UpdateItem of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) UpdateItem(
+	ctx context.Context,
+	userID uuid.UUID,
+	item *models.Item,
+	size int64,
+	expectedRevision int64,
+) (int64, error) {
+	ctx, end := r.start(ctx, "UpdateItem")
+	res, err := r.repo.UpdateItem(ctx, userID, item, size, expectedRevision)
+	end(err)
+	return res, err
+}
+
+// DeleteItem calls DeleteItem of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) DeleteItem(ctx context.Context, userID uuid.UUID, itemID uuid.UUID, expectedRevision int64) (int64, error) {
+	ctx, end := r.start(ctx, "DeleteItem")
+	res, err := r.repo.DeleteItem(ctx, userID, itemID, expectedRevision)
+	end(err)
+	return res, err
+}
+
+// AppendBlobChunk calls AppendBlobChunk of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) AppendBlobChunk(ctx context.Context, chunk *models.BlobChunk, last bool) (*models.Blob, bool, error) {
+	ctx, end := r.start(ctx, "AppendBlobChunk")
+	res, appended, err := r.repo.AppendBlobChunk(ctx, chunk, last)
+	end(err)
+	return res, appended, err
+}
+
+// ReadBlob calls ReadBlob of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) ReadBlob(ctx context.Context, userID uuid.UUID, blobID uuid.UUID) (*models.Blob, error) {
+	ctx, end := r.start(ctx, "ReadBlob")
+	res, err := r.repo.ReadBlob(ctx, userID, blobID)
+	end(err)
+	return res, err
+}
+
+// ExpireBlobs calls ExpireBlobs of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) ExpireBlobs(ctx context.Context) (int64, error) {
+	ctx, end := r.start(ctx, "ExpireBlobs")
+	res, err := r.repo.ExpireBlobs(ctx)
+	end(err)
+	return res, err
+}
+
+// ReadUsage calls ReadUsage of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) ReadUsage(ctx context.Context, userID uuid.UUID) (*models.Usage, error) {
+	ctx, end := r.start(ctx, "ReadUsage")
+	res, err := r.repo.ReadUsage(ctx, userID)
+	end(err)
+	return res, err
+}
+
+// UpdateUsage calls UpdateUsage of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) UpdateUsage(ctx context.Context, userID uuid.UUID, delta *models.Usage, limit *models.Usage) error {
+	ctx, end := r.start(ctx, "UpdateUsage")
+	err := r.repo.UpdateUsage(ctx, userID, delta, limit)
+	end(err)
+	return err
+}
+
+// ReadStats calls ReadStats of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) ReadStats(ctx context.Context) (*models.Stats, error) {
+	ctx, end := r.start(ctx, "ReadStats")
+	res, err := r.repo.ReadStats(ctx)
+	end(err)
+	return res, err
+}
+
+// StoreBlob calls StoreBlob of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) StoreBlob(ctx context.Context, userID uuid.UUID, content io.Reader) (*models.Blob, error) {
+	ctx, end := r.start(ctx, "StoreBlob")
+	res, err := r.repo.StoreBlob(ctx, userID, content)
+	end(err)
+	return res, err
+}
+
+// OpenBlob calls OpenBlob of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) OpenBlob(ctx context.Context, userID uuid.UUID, blobID uuid.UUID, offset int64) (io.ReadCloser, error) {
+	ctx, end := r.start(ctx, "OpenBlob")
+	res, err := r.repo.OpenBlob(ctx, userID, blobID, offset)
+	end(err)
+	return res, err
+}
+
+// CreateSession calls CreateSession of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) CreateSession(ctx context.Context, session *models.Session) error {
+	ctx, end := r.start(ctx, "CreateSession")
+	err := r.repo.CreateSession(ctx, session)
+	end(err)
+	return err
+}
+
+// ReadSession calls ReadSession of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) ReadSession(ctx context.Context, sessionID uuid.UUID) (*models.Session, error) {
+	ctx, end := r.start(ctx, "ReadSession")
+	res, err := r.repo.ReadSession(ctx, sessionID)
+	end(err)
+	return res, err
+}
+
+// ReadSessionsByUser calls ReadSessionsByUser of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) ReadSessionsByUser(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	ctx, end := r.start(ctx, "ReadSessionsByUser")
+	res, err := r.repo.ReadSessionsByUser(ctx, userID)
+	end(err)
+	return res, err
+}
+
+// RotateSession calls RotateSession of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) RotateSession(ctx context.Context, session *models.Session, oldHash string) error {
+	ctx, end := r.start(ctx, "RotateSession")
+	err := r.repo.RotateSession(ctx, session, oldHash)
+	end(err)
+	return err
+}
+
+// TouchSession calls TouchSession of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) TouchSession(ctx context.Context, sessionID uuid.UUID, lastSeenAt time.Time) error {
+	ctx, end := r.start(ctx, "TouchSession")
+	err := r.repo.TouchSession(ctx, sessionID, lastSeenAt)
+	end(err)
+	return err
+}
+
+// RevokeSession calls RevokeSession of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	ctx, end := r.start(ctx, "RevokeSession")
+	err := r.repo.RevokeSession(ctx, userID, sessionID)
+	end(err)
+	return err
+}
+
+// RevokeSessions calls RevokeSessions of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) RevokeSessions(ctx context.Context, userID uuid.UUID, except uuid.UUID) (int64, error) {
+	ctx, end := r.start(ctx, "RevokeSessions")
+	res, err := r.repo.RevokeSessions(ctx, userID, except)
+	end(err)
+	return res, err
+}
+
+// CreateAuditEvent calls CreateAuditEvent of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	ctx, end := r.start(ctx, "CreateAuditEvent")
+	err := r.repo.CreateAuditEvent(ctx, event)
+	end(err)
+	return err
+}
+
+// ReadAuditEvents calls ReadAuditEvents of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) ReadAuditEvents(ctx context.Context, userID uuid.UUID, after *models.AuditEvent, limit int64) ([]*models.AuditEvent, error) {
+	ctx, end := r.start(ctx, "ReadAuditEvents")
+	res, err := r.repo.ReadAuditEvents(ctx, userID, after, limit)
+	end(err)
+	return res, err
+}
+
+// Ping calls Ping of wrapped repository, tracing it and recording its latency.
+func (r *instrumentedRepository) Ping(ctx context.Context) error {
+	ctx, end := r.start(ctx, "Ping")
+	err := r.repo.Ping(ctx)
+	end(err)
+	return err
+}
+
+// Close closes wrapped repository.
+func (r *instrumentedRepository) Close(ctx context.Context) error {
+	return r.repo.Close(ctx)
+}
@@ -0,0 +1,469 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// embeddedItems holds items of the user, which were stored inside
+// user document before items were moved to their own collection.
+type embeddedItems struct {
+	ID        uuid.UUID                   `bson:"id"`
+	Logins    []*models.LoginPasswordItem `bson:"logins"`
+	BankCards []*models.BankCardItem      `bson:"cards"`
+	Texts     []*models.TextItem          `bson:"texts"`
+	Binaries  []*models.BinaryItem        `bson:"binaries"`
+	Items     []*models.Item              `bson:"items"`
+}
+
+// embeddedFields holds names of user document fields items were stored in.
+var embeddedFields = []string{"logins", "cards", "texts", "binaries", "items"}
+
+// Migrate prepares database for the current version of the app:
+// creates indexes, moves items embedded into user documents
+// to the items collection, inline values of binary items
+// to the blob store and counts usage of the users stored before
+// usage was counted. It is safe to run Migrate multiple times.
+func (r *repository) Migrate(ctx context.Context) error {
+	// unique login keeps concurrent sign ups from creating the same user twice
+	r.log(ctx).Debug().Msg("creating users indexes")
+	if _, err := r.users.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "login", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to create users indexes")
+		return err
+	}
+
+	r.log(ctx).Debug().Msg("creating items indexes")
+	if _, err := r.items.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "kind", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "revision", Value: 1}},
+		},
+	}); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to create items indexes")
+		return err
+	}
+
+	r.log(ctx).Debug().Msg("creating blobs indexes")
+	if _, err := r.blobs.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to create blobs indexes")
+		return err
+	}
+	// chunks of the blob, which wasn't completed in time, are removed by the database itself
+	if _, err := r.chunks.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "blob_id", Value: 1}, {Key: "offset", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	}); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to create blob chunks indexes")
+		return err
+	}
+
+	r.log(ctx).Debug().Msg("creating audit indexes")
+	if _, err := r.audit.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "time", Value: -1}, {Key: "id", Value: -1}},
+	}); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to create audit indexes")
+		return err
+	}
+
+	// expired sessions are removed by the database itself
+	r.log(ctx).Debug().Msg("creating sessions indexes")
+	if _, err := r.sessions.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	}); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to create sessions indexes")
+		return err
+	}
+
+	r.log(ctx).Debug().Msg("searching for users with embedded items")
+	exists := make(bson.A, len(embeddedFields))
+	for i, field := range embeddedFields {
+		exists[i] = bson.D{{Key: field, Value: bson.D{{Key: "$exists", Value: true}}}}
+	}
+	cursor, err := r.users.Find(ctx, bson.D{{Key: "$or", Value: exists}})
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to find users with embedded items")
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	migrated := 0
+	for cursor.Next(ctx) {
+		var user embeddedItems
+		if err := cursor.Decode(&user); err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Msg("unable to decode user's embedded items")
+			return err
+		}
+		if err := r.migrateUserItems(ctx, &user); err != nil {
+			return err
+		}
+		migrated++
+	}
+	if err := cursor.Err(); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to iterate over users with embedded items")
+		return err
+	}
+
+	stored, err := r.migrateInlineBinaries(ctx)
+	if err != nil {
+		return err
+	}
+
+	counted, err := r.migrateUsage(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.log(ctx).Info().Msgf(
+		"database was migrated, moved items of %d users and %d binary values, counted usage of %d users",
+		migrated,
+		stored,
+		counted,
+	)
+	return nil
+}
+
+// migrateUsage sets sizes of the items stored before their sizes were kept
+// and counts usage of the users, who have items, but no usage yet.
+// Size of such item is the size of its document along with its blob.
+func (r *repository) migrateUsage(ctx context.Context) (int, error) {
+	// blob of binary item stored before encrypted items keeps its size in size field
+	size := bson.D{{Key: "$add", Value: bson.A{
+		bson.D{{Key: "$bsonSize", Value: "$$ROOT"}},
+		bson.D{{Key: "$ifNull", Value: bson.A{"$blob_size", 0}}},
+		bson.D{{Key: "$cond", Value: bson.A{
+			bson.D{{Key: "$eq", Value: bson.A{"$kind", "binaries"}}},
+			bson.D{{Key: "$ifNull", Value: bson.A{"$size", 0}}},
+			0,
+		}}},
+	}}}
+
+	r.log(ctx).Debug().Msg("setting sizes of items")
+	if _, err := r.items.UpdateMany(
+		ctx,
+		bson.D{
+			{Key: "kind", Value: bson.D{{Key: "$ne", Value: kindTombstone}}},
+			{Key: "usage_bytes", Value: bson.D{{Key: "$exists", Value: false}}},
+		},
+		mongo.Pipeline{{{Key: "$set", Value: bson.D{{Key: "usage_bytes", Value: size}}}}},
+	); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to set sizes of items")
+		return 0, err
+	}
+
+	r.log(ctx).Debug().Msg("counting usage of users")
+	cursor, err := r.items.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "kind", Value: bson.D{{Key: "$ne", Value: kindTombstone}}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$user_id"},
+			{Key: "items", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "bytes", Value: bson.D{{Key: "$sum", Value: "$usage_bytes"}}},
+		}}},
+	})
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to count usage of users")
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	counted := 0
+	for cursor.Next(ctx) {
+		var usage userUsage
+		if err := cursor.Decode(&usage); err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Msg("unable to decode user's usage")
+			return counted, err
+		}
+
+		result, err := r.users.UpdateOne(
+			ctx,
+			bson.D{
+				{Key: "id", Value: usage.UserID},
+				{Key: "usage", Value: bson.D{{Key: "$exists", Value: false}}},
+			},
+			bson.D{{Key: "$set", Value: bson.D{{Key: "usage", Value: &usage.Usage}}}},
+		)
+		if err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("user", usage.UserID.String()).
+				Msg("unable to set user's usage")
+			return counted, err
+		}
+		counted += int(result.ModifiedCount)
+	}
+	if err := cursor.Err(); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to iterate over usage of users")
+		return counted, err
+	}
+	return counted, nil
+}
+
+// userUsage holds usage of the user counted from the user's items.
+type userUsage struct {
+	UserID       uuid.UUID `bson:"_id"`
+	models.Usage `bson:",inline"`
+}
+
+// inlineBinary holds binary item, which keeps its value
+// inside the item document, along with its owner's UUID.
+type inlineBinary struct {
+	UserID            uuid.UUID `bson:"user_id"`
+	models.BinaryItem `bson:",inline"`
+}
+
+// migrateInlineBinaries moves values of the binary items,
+// stored inside the item documents, to the blob store.
+func (r *repository) migrateInlineBinaries(ctx context.Context) (int, error) {
+	r.log(ctx).Debug().Msg("searching for binary items with inline values")
+	cursor, err := r.items.Find(ctx, bson.D{
+		{Key: "kind", Value: "binaries"},
+		{Key: "value", Value: bson.D{{Key: "$exists", Value: true}}},
+	})
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to find binary items with inline values")
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	stored := 0
+	for cursor.Next(ctx) {
+		var item inlineBinary
+		if err := cursor.Decode(&item); err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Msg("unable to decode binary item")
+			return stored, err
+		}
+		id := item.ID.String()
+
+		blob, err := r.StoreBlob(ctx, item.UserID, bytes.NewReader(item.Value))
+		if err != nil {
+			return stored, err
+		}
+
+		r.log(ctx).Debug().Str("item", id).Str("blob", blob.ID.String()).Msg("replacing inline value with blob")
+		if _, err := r.items.UpdateOne(
+			ctx,
+			bson.D{{Key: "user_id", Value: item.UserID}, {Key: "id", Value: item.ID}},
+			bson.D{
+				{Key: "$set", Value: bson.D{
+					{Key: "blob_id", Value: blob.ID},
+					{Key: "size", Value: blob.Size},
+					{Key: "hash", Value: blob.Hash},
+				}},
+				{Key: "$unset", Value: bson.D{{Key: "value", Value: ""}}},
+			},
+		); err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("item", id).
+				Msg("unable to replace inline value with blob")
+			return stored, err
+		}
+		stored++
+	}
+	if err := cursor.Err(); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to iterate over binary items")
+		return stored, err
+	}
+	return stored, nil
+}
+
+// migrateUserItems moves embedded items of the user to the items collection.
+// Items stored before they had ids get ids derived from their position,
+// so interrupted migration can be resumed without duplicating items.
+func (r *repository) migrateUserItems(ctx context.Context, user *embeddedItems) error {
+	id := user.ID.String()
+
+	type embedded struct {
+		id   *uuid.UUID
+		item interface{}
+	}
+	kinds := map[string][]embedded{}
+	for _, item := range user.Logins {
+		kinds["logins"] = append(kinds["logins"], embedded{&item.ID, item})
+	}
+	for _, item := range user.BankCards {
+		kinds["cards"] = append(kinds["cards"], embedded{&item.ID, item})
+	}
+	for _, item := range user.Texts {
+		kinds["texts"] = append(kinds["texts"], embedded{&item.ID, item})
+	}
+	for _, item := range user.Binaries {
+		kinds["binaries"] = append(kinds["binaries"], embedded{&item.ID, item})
+	}
+	for _, item := range user.Items {
+		kinds["items"] = append(kinds["items"], embedded{&item.ID, item})
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("moving embedded items")
+	for kind, items := range kinds {
+		for i, e := range items {
+			if *e.id == uuid.Nil {
+				*e.id = uuid.NewSHA1(user.ID, []byte(fmt.Sprintf("%s/%d", kind, i)))
+			}
+			doc, err := itemDocument(e.item, kind, user.ID)
+			if err != nil {
+				r.log(ctx).
+					Err(err).
+					Caller().
+					Str("user", id).
+					Msg("unable to marshal item to bson")
+				return err
+			}
+			filter := bson.D{
+				{Key: "user_id", Value: user.ID},
+				{Key: "id", Value: *e.id},
+			}
+			if _, err := r.items.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true)); err != nil {
+				r.log(ctx).
+					Err(err).
+					Caller().
+					Str("user", id).
+					Msg("unable to move embedded item")
+				return err
+			}
+		}
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("removing embedded items")
+	unset := bson.D{}
+	for _, field := range embeddedFields {
+		unset = append(unset, bson.E{Key: field, Value: ""})
+	}
+	if _, err := r.users.UpdateOne(
+		ctx,
+		bson.D{{Key: "id", Value: user.ID}},
+		bson.D{{Key: "$unset", Value: unset}},
+	); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to remove embedded items")
+		return err
+	}
+	return nil
+}
+
+// itemDocument returns item's bson document extended with
+// the owner's UUID and item's type.
+func itemDocument(item interface{}, itemType string, userID uuid.UUID) (bson.D, error) {
+	raw, err := bson.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	elements, err := bson.Raw(raw).Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "kind", Value: itemType},
+	}
+	for _, element := range elements {
+		doc = append(doc, bson.E{Key: element.Key(), Value: element.Value()})
+	}
+	return doc, nil
+}
+
+// setField replaces value of the document's field, appending the field
+// if the document doesn't have it.
+func setField(doc bson.D, key string, value interface{}) bson.D {
+	for i := range doc {
+		if doc[i].Key == key {
+			doc[i].Value = value
+			return doc
+		}
+	}
+	return append(doc, bson.E{Key: key, Value: value})
+}
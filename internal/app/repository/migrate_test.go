@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestMigrate(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		store, err := NewFileStore(t.TempDir(), logger)
+		require.NoError(t, err)
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			users:    mt.Coll,
+			items:    mt.Coll,
+			blobs:    mt.Coll,
+			chunks:   mt.Coll,
+			audit:    mt.Coll,
+			sessions: mt.Coll,
+			store:    store,
+		}
+
+		user := bson.D{
+			{Key: "id", Value: uuid.New()},
+			{Key: "login", Value: "test"},
+			{Key: "logins", Value: bson.A{
+				bson.D{
+					{Key: "login", Value: "one"},
+					{Key: "password", Value: []byte("two")},
+				},
+			}},
+			{Key: "texts", Value: bson.A{}},
+		}
+		binary := bson.D{
+			{Key: "user_id", Value: uuid.New()},
+			{Key: "id", Value: uuid.New()},
+			{Key: "kind", Value: "binaries"},
+			{Key: "value", Value: []byte("some bins")},
+		}
+
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateCursorResponse(0, "gokeeper.users", mtest.FirstBatch, user),
+			bson.D{
+				{Key: "ok", Value: 1},
+				{Key: "n", Value: 1},
+			},
+			bson.D{
+				{Key: "ok", Value: 1},
+				{Key: "n", Value: 1},
+				{Key: "nModified", Value: 1},
+			},
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch, binary),
+			mtest.CreateSuccessResponse(),
+			bson.D{
+				{Key: "ok", Value: 1},
+				{Key: "n", Value: 1},
+				{Key: "nModified", Value: 1},
+			},
+			bson.D{
+				{Key: "ok", Value: 1},
+				{Key: "n", Value: 2},
+				{Key: "nModified", Value: 2},
+			},
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: user[0].Value},
+				{Key: "items", Value: int32(2)},
+				{Key: "bytes", Value: int64(4096)},
+			}),
+			bson.D{
+				{Key: "ok", Value: 1},
+				{Key: "n", Value: 1},
+				{Key: "nModified", Value: 1},
+			},
+		)
+
+		err = repo.Migrate(context.Background())
+		require.NoError(t, err)
+		var count bson.Raw
+		for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+			if event.CommandName == "update" {
+				count = event.Command
+			}
+		}
+		usage := count.Lookup("updates").Array().Index(0).Value().Document()
+		_, err = usage.LookupErr("q", "usage", "$exists")
+		require.NoError(t, err)
+		require.Equal(t, int64(2), usage.Lookup("u", "$set", "usage", "items").Int64())
+		require.Equal(t, int64(4096), usage.Lookup("u", "$set", "usage", "bytes").Int64())
+	})
+
+	mt.Run("nothing to migrate", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			users:    mt.Coll,
+			items:    mt.Coll,
+			blobs:    mt.Coll,
+			chunks:   mt.Coll,
+			audit:    mt.Coll,
+			sessions: mt.Coll,
+		}
+
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateCursorResponse(0, "gokeeper.users", mtest.FirstBatch),
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}, {Key: "nModified", Value: 0}},
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch),
+		)
+
+		err := repo.Migrate(context.Background())
+		require.NoError(t, err)
+		// both blob chunks and sessions expire, users are unique by id and login
+		ttl := 0
+		var users []string
+		created := 0
+		for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+			if event.CommandName != "createIndexes" {
+				continue
+			}
+			created++
+			indexes, _ := event.Command.Lookup("indexes").Array().Values()
+			for _, index := range indexes {
+				// users indexes are created first
+				if created == 1 {
+					require.True(t, index.Document().Lookup("unique").Boolean())
+					keys, _ := index.Document().Lookup("key").Document().Elements()
+					users = append(users, keys[0].Key())
+				}
+				if _, err := index.Document().LookupErr("expireAfterSeconds"); err == nil {
+					require.Equal(t, int32(1), index.Document().Lookup("key", "expires_at").Int32())
+					ttl++
+				}
+			}
+		}
+		require.Equal(t, 2, ttl)
+		require.Equal(t, []string{"id", "login"}, users)
+	})
+
+	mt.Run("index err", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			users:    mt.Coll,
+			items:    mt.Coll,
+			blobs:    mt.Coll,
+			chunks:   mt.Coll,
+			audit:    mt.Coll,
+			sessions: mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+
+		err := repo.Migrate(context.Background())
+		require.Error(t, err)
+	})
+}
+
+func TestItemDocument(t *testing.T) {
+	uid := uuid.New()
+	doc, err := itemDocument(bson.D{{Key: "value", Value: "some text"}}, "texts", uid)
+	require.NoError(t, err)
+	require.Equal(t, "user_id", doc[0].Key)
+	require.Equal(t, bson.E{Key: "kind", Value: "texts"}, doc[1])
+	require.Equal(t, "value", doc[2].Key)
+}
@@ -4,14 +4,18 @@ package repository
 import (
 	"context"
 	"errors"
+	"io"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/logging"
 	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 var (
@@ -23,29 +27,153 @@ var (
 	ErrNilArgument = errors.New("argument can't be empty")
 	// ErrUserExists is raised when client tries to create already existing in the database user.
 	ErrUserExists = errors.New("user already exists")
+	// ErrNoSession is raised when client tries to get information about session
+	// which does not exist in the database.
+	ErrNoSession = errors.New("there is no such session in the database")
+	// ErrSessionRotated is raised when client tries to rotate refresh token
+	// of the session, which was already rotated or revoked.
+	ErrSessionRotated = errors.New("session's refresh token was already rotated")
+	// ErrVaultKeyExists is raised when client tries to set vault key
+	// of the user which already has one.
+	ErrVaultKeyExists = errors.New("user already has vault key")
+	// ErrNoItem is raised when client tries to change item
+	// which does not exist in the user's vault.
+	ErrNoItem = errors.New("there is no such item in the database")
+	// ErrItemExists is raised when client adds item with id,
+	// which another item of the user's vault already has.
+	ErrItemExists = errors.New("item with such id already exists")
+	// ErrRevisionConflict is raised when client tries to change item
+	// which was changed since the revision client expects.
+	ErrRevisionConflict = errors.New("item was changed since expected revision")
+	// ErrNoBlob is raised when client refers to blob
+	// which does not exist in the database.
+	ErrNoBlob = errors.New("there is no such blob in the database")
+	// ErrBlobOffset is raised when client sends chunk of the blob,
+	// which doesn't continue already received part of it.
+	ErrBlobOffset = errors.New("chunk offset doesn't match size of the blob")
+	// ErrBlobComplete is raised when client sends chunk
+	// of the blob, which was already completely received.
+	ErrBlobComplete = errors.New("blob is already complete")
+	// ErrBlobCompleting is raised when client sends the last chunk
+	// of the blob, which another request is completing right now.
+	ErrBlobCompleting = errors.New("blob is being completed by another request")
+	// ErrBlobAttached is raised when client refers to blob,
+	// which another item already refers to.
+	ErrBlobAttached = errors.New("blob is attached to another item")
+	// ErrQuotaExceeded is raised when client tries to store more than
+	// the user's quota allows.
+	ErrQuotaExceeded = errors.New("user's quota is exceeded")
 )
 
+// kindTombstone is the type of items collection documents,
+// which replace deleted items.
+const kindTombstone = "tombstones"
+
+// initialPingTimeout limits database availability check on startup.
+const initialPingTimeout = 5 * time.Second
+
+// pendingRevisionTimeout limits how long ReadItemsSince waits for the write
+// of reserved vault revision, write taking longer is considered abandoned.
+const pendingRevisionTimeout = time.Minute
+
+// pendingRevision holds vault revision reserved by the write,
+// which isn't finished yet.
+type pendingRevision struct {
+	Revision   int64     `bson:"revision"`
+	ReservedAt time.Time `bson:"reserved_at"`
+}
+
+// vaultRevisions holds the latest revision of the user's vault
+// along with revisions, which are still being written.
+type vaultRevisions struct {
+	Revision int64             `bson:"revision"`
+	Pending  []pendingRevision `bson:"pending_revisions"`
+}
+
+// watermark returns the latest vault revision, writes of which and of all
+// preceding revisions are finished, ignoring abandoned writes.
+func (v *vaultRevisions) watermark(now time.Time) int64 {
+	watermark := v.Revision
+	for _, pending := range v.Pending {
+		if now.Sub(pending.ReservedAt) < pendingRevisionTimeout && pending.Revision <= watermark {
+			watermark = pending.Revision - 1
+		}
+	}
+	return watermark
+}
+
+// tombstone holds information about deleted item.
+type tombstone struct {
+	UserID    uuid.UUID `bson:"user_id"`
+	ID        uuid.UUID `bson:"id"`
+	Kind      string    `bson:"kind"`
+	Revision  int64     `bson:"revision"`
+	DeletedAt time.Time `bson:"deleted_at"`
+}
+
 // Repository provides data layer methods.
 type Repository interface {
+	Migrate(ctx context.Context) error
 	CreateUser(ctx context.Context, user *models.User) error
 	ReadUserByLogin(ctx context.Context, login string) (*models.User, error)
 	ReadUserByID(ctx context.Context, uuid uuid.UUID) (*models.User, error)
-	CreateItem(ctx context.Context, item interface{}, itemType string, userID uuid.UUID) error
+	UpdateUserPassword(ctx context.Context, userID uuid.UUID, oldPassword string, password string) error
+	CreateVaultKey(ctx context.Context, userID uuid.UUID, key *models.VaultKey) error
+	UpdateCredentials(ctx context.Context, userID uuid.UUID, password string, key *models.VaultKey) error
+	DeleteUser(ctx context.Context, userID uuid.UUID) error
+	UpdateTOTP(ctx context.Context, userID uuid.UUID, totp *models.TOTP) error
+	UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string) error
+	CreateItem(ctx context.Context, item interface{}, itemType string, userID uuid.UUID, size int64) error
+	ReadItems(ctx context.Context, userID uuid.UUID) (*models.Vault, error)
+	ReadItemsSince(ctx context.Context, userID uuid.UUID, revision int64) (*models.Vault, error)
+	ReadItemsByType(ctx context.Context, userID uuid.UUID, itemType string, items interface{}) error
+	ReadItem(ctx context.Context, userID uuid.UUID, itemID uuid.UUID) (*models.Item, error)
+	UpdateItem(ctx context.Context, userID uuid.UUID, item *models.Item, size int64, expectedRevision int64) (int64, error)
+	DeleteItem(ctx context.Context, userID uuid.UUID, itemID uuid.UUID, expectedRevision int64) (int64, error)
+	AppendBlobChunk(ctx context.Context, chunk *models.BlobChunk, last bool) (*models.Blob, bool, error)
+	ReadBlob(ctx context.Context, userID uuid.UUID, blobID uuid.UUID) (*models.Blob, error)
+	ExpireBlobs(ctx context.Context) (int64, error)
+	ReadUsage(ctx context.Context, userID uuid.UUID) (*models.Usage, error)
+	UpdateUsage(ctx context.Context, userID uuid.UUID, delta *models.Usage, limit *models.Usage) error
+	ReadStats(ctx context.Context) (*models.Stats, error)
+	StoreBlob(ctx context.Context, userID uuid.UUID, content io.Reader) (*models.Blob, error)
+	OpenBlob(ctx context.Context, userID uuid.UUID, blobID uuid.UUID, offset int64) (io.ReadCloser, error)
+	CreateSession(ctx context.Context, session *models.Session) error
+	ReadSession(ctx context.Context, sessionID uuid.UUID) (*models.Session, error)
+	ReadSessionsByUser(ctx context.Context, userID uuid.UUID) ([]*models.Session, error)
+	RotateSession(ctx context.Context, session *models.Session, oldHash string) error
+	TouchSession(ctx context.Context, sessionID uuid.UUID, lastSeenAt time.Time) error
+	RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error
+	RevokeSessions(ctx context.Context, userID uuid.UUID, except uuid.UUID) (int64, error)
+	CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error
+	ReadAuditEvents(ctx context.Context, userID uuid.UUID, after *models.AuditEvent, limit int64) ([]*models.AuditEvent, error)
+	Ping(ctx context.Context) error
+	Close(ctx context.Context) error
 }
 
 // Repository holds objects for data layer implementation.
 type repository struct {
-	cfg    config.ServerConfig
-	client *mongo.Client
-	users  *mongo.Collection
-	logger zerolog.Logger
+	cfg      config.ServerConfig
+	client   *mongo.Client
+	users    *mongo.Collection
+	items    *mongo.Collection
+	sessions *mongo.Collection
+	blobs    *mongo.Collection
+	chunks   *mongo.Collection
+	audit    *mongo.Collection
+	store    BlobStore
+	logger   zerolog.Logger
 }
 
-// NewRepository initializes connection to mongo db.
-func NewRepository(logger zerolog.Logger) (Repository, error) {
-	logger.Debug().Str("module", "repo").Msg("getting app's configuration")
-	cfg := config.GetServerConfig()
+// log returns logger of the request ctx belongs to,
+// falling back to data layer's logger.
+func (r *repository) log(ctx context.Context) *zerolog.Logger {
+	return logging.FromContext(ctx, &r.logger)
+}
 
+// NewRepository initializes connection to mongo db.
+func NewRepository(cfg config.ServerConfig, logger zerolog.Logger) (Repository, error) {
 	logger.Debug().Str("module", "repo").Msg("initializing database connection")
 	client, err := mongo.Connect(context.TODO(), options.Client().ApplyURI(cfg.Database.Address))
 	if err != nil {
@@ -55,32 +183,83 @@ func NewRepository(logger zerolog.Logger) (Repository, error) {
 			Msg("unable to initialize data layer")
 		return nil, err
 	}
-	collection := client.Database(cfg.Database.Name).Collection("users")
+	db := client.Database(cfg.Database.Name)
+
+	logger.Debug().Str("module", "repo").Msg("checking database connection")
+	pingCtx, cancel := context.WithTimeout(context.Background(), initialPingTimeout)
+	defer cancel()
+	if err := client.Ping(pingCtx, readpref.Primary()); err != nil {
+		// database may become reachable later, readiness reflects it
+		logger.Warn().Err(err).Msg("database is unreachable")
+	}
+
+	logger.Debug().Str("module", "repo").Str("store", cfg.Blobs.Store).Msg("initializing blob store")
+	store, err := newBlobStore(cfg.Blobs.Store, cfg.Blobs.Dir, db, logger)
+	if err != nil {
+		logger.
+			Err(err).
+			Caller().
+			Msg("unable to initialize blob store")
+		return nil, err
+	}
 
 	logger.Info().Msg("data layer was successfully initialized")
-	return &repository{
-		cfg:    cfg,
-		client: client,
-		users:  collection,
-		logger: logger,
-	}, nil
+	return Instrument(&repository{
+		cfg:      cfg,
+		client:   client,
+		users:    db.Collection("users"),
+		items:    db.Collection("items"),
+		sessions: db.Collection("sessions"),
+		blobs:    db.Collection("blobs"),
+		chunks:   db.Collection("blob_chunks"),
+		audit:    db.Collection("audit"),
+		store:    store,
+		logger:   logger,
+	}), nil
+}
+
+// Ping checks that the database is reachable.
+func (r *repository) Ping(ctx context.Context) error {
+	if err := r.client.Ping(ctx, readpref.Primary()); err != nil {
+		r.log(ctx).Debug().Err(err).Msg("database ping failed")
+		return err
+	}
+	return nil
+}
+
+// Close disconnects from the database, waiting for
+// in-progress operations until ctx is done.
+func (r *repository) Close(ctx context.Context) error {
+	if r.client == nil {
+		return nil
+	}
+
+	r.log(ctx).Debug().Str("module", "repo").Msg("closing database connection")
+	if err := r.client.Disconnect(ctx); err != nil && err != mongo.ErrClientDisconnected {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to close database connection")
+		return err
+	}
+	return nil
 }
 
 // CreateUser adds new user entry to the database.
 func (r *repository) CreateUser(ctx context.Context, user *models.User) error {
 	if user == nil {
-		r.logger.Err(ErrNilArgument).Str("arg", "user").Msg("user can't be nil")
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "user").Msg("user can't be nil")
 		return ErrNilArgument
 	}
 
-	r.logger.Debug().Str("user", user.Login).Msg("checking if such user already exists")
+	r.log(ctx).Debug().Str("user", user.Login).Msg("checking if such user already exists")
 	dbUser, err := r.ReadUserByLogin(ctx, user.Login)
 	if err == nil && user.Login == dbUser.Login {
-		r.logger.Info().Str("user", user.Login).Msg("user with provided login already exists in the system")
+		r.log(ctx).Info().Str("user", user.Login).Msg("user with provided login already exists in the system")
 		return ErrUserExists
 	}
 	if err != nil && err != ErrNoUser {
-		r.logger.
+		r.log(ctx).
 			Err(err).
 			Caller().
 			Str("user", user.Login).
@@ -88,10 +267,10 @@ func (r *repository) CreateUser(ctx context.Context, user *models.User) error {
 		return err
 	}
 
-	r.logger.Debug().Str("user", user.Login).Msg("marshalling user's info to bson")
+	r.log(ctx).Debug().Str("user", user.Login).Msg("marshalling user's info to bson")
 	doc, err := bson.Marshal(user)
 	if err != nil {
-		r.logger.
+		r.log(ctx).
 			Err(err).
 			Caller().
 			Str("user", user.Login).
@@ -99,10 +278,14 @@ func (r *repository) CreateUser(ctx context.Context, user *models.User) error {
 		return err
 	}
 
-	r.logger.Debug().Str("user", user.Login).Msg("inserting new user to the database")
+	r.log(ctx).Debug().Str("user", user.Login).Msg("inserting new user to the database")
 	result, err := r.users.InsertOne(ctx, doc)
 	if err != nil {
-		r.logger.
+		if mongo.IsDuplicateKeyError(err) {
+			r.log(ctx).Info().Str("user", user.Login).Msg("user with provided login already exists in the system")
+			return ErrUserExists
+		}
+		r.log(ctx).
 			Err(err).
 			Caller().
 			Str("user", user.Login).
@@ -110,7 +293,7 @@ func (r *repository) CreateUser(ctx context.Context, user *models.User) error {
 		return err
 	}
 
-	r.logger.Debug().Str("user", user.Login).Msgf(
+	r.log(ctx).Debug().Str("user", user.Login).Msgf(
 		"new user was inserted to the database with %v",
 		result.InsertedID,
 	)
@@ -120,17 +303,17 @@ func (r *repository) CreateUser(ctx context.Context, user *models.User) error {
 // ReadUserByLogin searches the database for a user
 // with provided login, returning found user or ErrNoUser.
 func (r *repository) ReadUserByLogin(ctx context.Context, login string) (*models.User, error) {
-	r.logger.Debug().Str("user", login).Msg("preparing filter")
+	r.log(ctx).Debug().Str("user", login).Msg("preparing filter")
 	filter := bson.D{{Key: "login", Value: login}}
 
-	r.logger.Debug().Str("user", login).Msg("searching for user in the database")
+	r.log(ctx).Debug().Str("user", login).Msg("searching for user in the database")
 	result := r.users.FindOne(ctx, filter)
 	if result.Err() != nil {
 		if result.Err() == mongo.ErrNoDocuments {
-			r.logger.Debug().Str("user", login).Msg("no such user in the database")
+			r.log(ctx).Debug().Str("user", login).Msg("no such user in the database")
 			return nil, ErrNoUser
 		}
-		r.logger.
+		r.log(ctx).
 			Err(result.Err()).
 			Caller().
 			Str("user", login).
@@ -138,10 +321,10 @@ func (r *repository) ReadUserByLogin(ctx context.Context, login string) (*models
 		return nil, result.Err()
 	}
 
-	r.logger.Debug().Str("user", login).Msg("processing query result")
+	r.log(ctx).Debug().Str("user", login).Msg("processing query result")
 	var user models.User
 	if err := result.Decode(&user); err != nil {
-		r.logger.
+		r.log(ctx).
 			Err(err).
 			Caller().
 			Str("user", login).
@@ -149,24 +332,24 @@ func (r *repository) ReadUserByLogin(ctx context.Context, login string) (*models
 		return nil, err
 	}
 
-	r.logger.Debug().Str("user", login).Msg("user was found in the database")
+	r.log(ctx).Debug().Str("user", login).Msg("user was found in the database")
 	return &user, nil
 }
 
 // ReadUserByID searches the database for a user
 // with provided UUID, returning found user or ErrNoUser.
 func (r *repository) ReadUserByID(ctx context.Context, uuid uuid.UUID) (*models.User, error) {
-	r.logger.Debug().Str("user", uuid.String()).Msg("preparing filter")
+	r.log(ctx).Debug().Str("user", uuid.String()).Msg("preparing filter")
 	filter := bson.D{{Key: "id", Value: uuid}}
 
-	r.logger.Debug().Str("user", uuid.String()).Msg("searching for user in the database")
+	r.log(ctx).Debug().Str("user", uuid.String()).Msg("searching for user in the database")
 	result := r.users.FindOne(ctx, filter)
 	if result.Err() != nil {
 		if result.Err() == mongo.ErrNoDocuments {
-			r.logger.Debug().Str("user", uuid.String()).Msg("no such user in the database")
+			r.log(ctx).Debug().Str("user", uuid.String()).Msg("no such user in the database")
 			return nil, ErrNoUser
 		}
-		r.logger.
+		r.log(ctx).
 			Err(result.Err()).
 			Caller().
 			Str("user", uuid.String()).
@@ -174,10 +357,10 @@ func (r *repository) ReadUserByID(ctx context.Context, uuid uuid.UUID) (*models.
 		return nil, result.Err()
 	}
 
-	r.logger.Debug().Str("user", uuid.String()).Msg("processing query result")
+	r.log(ctx).Debug().Str("user", uuid.String()).Msg("processing query result")
 	var user models.User
 	if err := result.Decode(&user); err != nil {
-		r.logger.
+		r.log(ctx).
 			Err(err).
 			Caller().
 			Str("user", uuid.String()).
@@ -185,40 +368,907 @@ func (r *repository) ReadUserByID(ctx context.Context, uuid uuid.UUID) (*models.
 		return nil, err
 	}
 
-	r.logger.Debug().Str("user", uuid.String()).Msg("user was found in the database")
+	r.log(ctx).Debug().Str("user", uuid.String()).Msg("user was found in the database")
 	return &user, nil
 }
 
-// CreateItem adds new item entry to the database.
-func (r *repository) CreateItem(ctx context.Context, item interface{}, itemType string, userID uuid.UUID) error {
+// UpdateUserPassword replaces password hash of the user with provided UUID,
+// unless the hash was changed since oldPassword hash was read.
+// User, whose password was changed concurrently or who doesn't exist,
+// is left intact without error, as the hash being replaced is no longer used.
+func (r *repository) UpdateUserPassword(ctx context.Context, userID uuid.UUID, oldPassword string, password string) error {
+	id := userID.String()
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing filter")
+	filter := bson.D{
+		{Key: "id", Value: userID},
+		{Key: "password", Value: oldPassword},
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing update")
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "password", Value: password}}}}
+
+	r.log(ctx).Debug().Str("user", id).Msg("updating user's password")
+	result, err := r.users.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to update user's password")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		r.log(ctx).Debug().Str("user", id).Msg("user's password was changed or user was deleted")
+		return nil
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("user's password was updated")
+	return nil
+}
+
+// CreateVaultKey sets wrapped vault key of the user with provided UUID,
+// unless the user already has one.
+func (r *repository) CreateVaultKey(ctx context.Context, userID uuid.UUID, key *models.VaultKey) error {
+	if key == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "key").Msg("vault key can't be nil")
+		return ErrNilArgument
+	}
+	id := userID.String()
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing filter")
+	filter := bson.D{
+		{Key: "id", Value: userID},
+		{Key: "vault_key", Value: bson.D{{Key: "$exists", Value: false}}},
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing update")
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "vault_key", Value: key}}}}
+
+	r.log(ctx).Debug().Str("user", id).Msg("setting user's vault key")
+	result, err := r.users.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to set user's vault key")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		r.log(ctx).Debug().Str("user", id).Msg("user already has vault key")
+		return ErrVaultKeyExists
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("user's vault key was set")
+	return nil
+}
+
+// CreateItem adds new item entry of provided type to the database
+// along with size of the item counted in the user's usage.
+// Blob encrypted item refers to is attached to it, blob attached
+// to another item can't be referred to and ErrBlobAttached is returned.
+// If the user already has item with the same id, ErrItemExists is returned.
+func (r *repository) CreateItem(ctx context.Context, item interface{}, itemType string, userID uuid.UUID, size int64) (err error) {
 	if item == nil {
-		r.logger.Err(ErrNilArgument).Str("arg", "item").Msg("item can't be nil")
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "item").Msg("item can't be nil")
 		return ErrNilArgument
 	}
 
 	id := userID.String()
 
-	r.logger.Debug().Str("user", id).Msg("preparing filter")
-	filter := bson.D{{Key: "id", Value: userID}}
+	r.log(ctx).Debug().Str("user", id).Msg("marshalling item to bson")
+	doc, err := itemDocument(item, itemType, userID)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to marshal item to bson")
+		return err
+	}
+
+	if encrypted, ok := item.(*models.Item); ok && encrypted.BlobID != uuid.Nil {
+		attached, attachErr := r.attachBlob(ctx, userID, encrypted.BlobID, encrypted.ID)
+		if attachErr != nil {
+			return attachErr
+		}
+		if attached {
+			defer func() {
+				if err != nil {
+					r.detachBlob(ctx, userID, encrypted.BlobID, encrypted.ID)
+				}
+			}()
+		}
+	}
+
+	revision, err := r.nextRevision(ctx, userID)
+	if err != nil {
+		return err
+	}
+	defer r.releaseRevision(ctx, userID, revision)
+	doc = setField(doc, "revision", revision)
+	doc = setField(doc, "usage_bytes", size)
+
+	r.log(ctx).Debug().Str("user", id).Msg("inserting new item to the database")
+	result, err := r.items.InsertOne(ctx, doc)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			r.log(ctx).Debug().Str("user", id).Msg("item with such id already exists")
+			return ErrItemExists
+		}
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msgf("unable to insert new %s item", itemType)
+		return err
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msgf(
+		"new item was inserted to the database with %v",
+		result.InsertedID,
+	)
+	return nil
+}
+
+// ReadItems searches the database for all items of the user with provided UUID.
+func (r *repository) ReadItems(ctx context.Context, userID uuid.UUID) (*models.Vault, error) {
+	r.log(ctx).Debug().Str("user", userID.String()).Msg("preparing filter")
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "kind", Value: bson.D{{Key: "$ne", Value: kindTombstone}}},
+	}
+	return r.readVault(ctx, userID, filter, 0)
+}
+
+// ReadItemsSince searches the database for items of the user with provided UUID,
+// which were added, changed or deleted after provided vault revision.
+// Zero revision means all items of the user. Changes are returned only up to
+// the revision, writes of all preceding revisions are finished by, so the
+// revision returned in the vault never skips changes still being written.
+func (r *repository) ReadItemsSince(ctx context.Context, userID uuid.UUID, revision int64) (*models.Vault, error) {
+	id := userID.String()
+
+	r.log(ctx).Debug().Str("user", id).Msg("reading vault revision")
+	var revisions vaultRevisions
+	err := r.users.FindOne(
+		ctx,
+		bson.D{{Key: "id", Value: userID}},
+		options.FindOne().SetProjection(bson.D{
+			{Key: "revision", Value: 1},
+			{Key: "pending_revisions", Value: 1},
+		}),
+	).Decode(&revisions)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			r.log(ctx).Debug().Str("user", id).Msg("no such user in the database")
+			return nil, ErrNoUser
+		}
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to read vault revision")
+		return nil, err
+	}
+	watermark := revisions.watermark(time.Now().UTC())
+	if watermark < revision {
+		watermark = revision
+	}
 
-	r.logger.Debug().Str("user", id).Msg("preparing update")
-	update := bson.D{{Key: "$push", Value: bson.D{{Key: itemType, Value: item}}}}
+	r.log(ctx).Debug().Str("user", id).Int64("watermark", watermark).Msg("preparing filter")
+	// items written before revisions were introduced have none
+	revisionFilter := bson.D{{Key: "$not", Value: bson.D{{Key: "$gt", Value: watermark}}}}
+	if revision > 0 {
+		revisionFilter = bson.D{
+			{Key: "$gt", Value: revision},
+			{Key: "$lte", Value: watermark},
+		}
+	}
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "revision", Value: revisionFilter},
+	}
 
-	r.logger.Debug().Str("user", id).Msg("updating user's items")
-	result, err := r.users.UpdateOne(ctx, filter, update)
+	vault, err := r.readVault(ctx, userID, filter, revision)
+	if err != nil {
+		return nil, err
+	}
+	vault.Revision = watermark
+	return vault, nil
+}
+
+// readVault searches the database for items matching the filter,
+// decoding each of them according to its type.
+func (r *repository) readVault(ctx context.Context, userID uuid.UUID, filter bson.D, revision int64) (*models.Vault, error) {
+	id := userID.String()
+
+	r.log(ctx).Debug().Str("user", id).Msg("searching for user's items")
+	cursor, err := r.items.Find(ctx, filter)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to find user's items")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	vault := &models.Vault{
+		Revision:  revision,
+		Logins:    make([]*models.LoginPasswordItem, 0),
+		BankCards: make([]*models.BankCardItem, 0),
+		Texts:     make([]*models.TextItem, 0),
+		Binaries:  make([]*models.BinaryItem, 0),
+		Items:     make([]*models.Item, 0),
+		Deleted:   make([]uuid.UUID, 0),
+	}
+	for cursor.Next(ctx) {
+		var err error
+		kind, _ := cursor.Current.Lookup("kind").StringValueOK()
+		switch kind {
+		case "logins":
+			item := new(models.LoginPasswordItem)
+			err = cursor.Decode(item)
+			vault.Logins = append(vault.Logins, item)
+		case "cards":
+			item := new(models.BankCardItem)
+			err = cursor.Decode(item)
+			vault.BankCards = append(vault.BankCards, item)
+		case "texts":
+			item := new(models.TextItem)
+			err = cursor.Decode(item)
+			vault.Texts = append(vault.Texts, item)
+		case "binaries":
+			item := new(models.BinaryItem)
+			err = cursor.Decode(item)
+			vault.Binaries = append(vault.Binaries, item)
+		case "items":
+			item := new(models.Item)
+			err = cursor.Decode(item)
+			vault.Items = append(vault.Items, item)
+		case kindTombstone:
+			item := new(tombstone)
+			err = cursor.Decode(item)
+			vault.Deleted = append(vault.Deleted, item.ID)
+		}
+		if err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("user", id).
+				Msg("unable to decode item")
+			return nil, err
+		}
+		if rev, ok := cursor.Current.Lookup("revision").Int64OK(); ok && rev > vault.Revision {
+			vault.Revision = rev
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to iterate over user's items")
+		return nil, err
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("user's items were found in the database")
+	return vault, nil
+}
+
+// ReadItemsByType searches the database for items of provided type
+// of the user with provided UUID, decoding them into items,
+// which must be a pointer to a slice of items of corresponding type.
+func (r *repository) ReadItemsByType(ctx context.Context, userID uuid.UUID, itemType string, items interface{}) error {
+	if items == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "items").Msg("items can't be nil")
+		return ErrNilArgument
+	}
+	id := userID.String()
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing filter")
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "kind", Value: itemType},
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msgf("searching for user's %s items", itemType)
+	cursor, err := r.items.Find(ctx, filter)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msgf("unable to find user's %s items", itemType)
+		return err
+	}
+
+	if err := cursor.All(ctx, items); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msgf("unable to decode user's %s items", itemType)
+		return err
+	}
+	return nil
+}
+
+// ReadItem searches the database for encrypted item with provided UUID
+// of the user with provided UUID, returning found item or ErrNoItem.
+func (r *repository) ReadItem(ctx context.Context, userID uuid.UUID, itemID uuid.UUID) (*models.Item, error) {
+	id := itemID.String()
+
+	r.log(ctx).Debug().Str("item", id).Msg("preparing filter")
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "id", Value: itemID},
+		{Key: "kind", Value: "items"},
+	}
+
+	r.log(ctx).Debug().Str("item", id).Msg("searching for item")
+	result := r.items.FindOne(ctx, filter)
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			r.log(ctx).Debug().Str("item", id).Msg("no such item in the database")
+			return nil, ErrNoItem
+		}
+		r.log(ctx).
+			Err(result.Err()).
+			Caller().
+			Str("item", id).
+			Msg("unable to find item")
+		return nil, result.Err()
+	}
+
+	var item models.Item
+	if err := result.Decode(&item); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("item", id).
+			Msg("unable to decode item")
+		return nil, err
+	}
+
+	r.log(ctx).Debug().Str("item", id).Msg("item was found in the database")
+	return &item, nil
+}
+
+// UpdateItem replaces payload of the user's encrypted item,
+// setting item's revision to the next vault revision.
+// Blob the item refers to is attached to it the same way CreateItem does,
+// blob it replaces is removed. Item, which doesn't refer to any blob,
+// keeps the blob it had, and item is filled with that blob.
+// Item is counted in the user's usage with provided size of its payload
+// along with size of its blob. Size released by the update is returned:
+// size of the previous payload along with size of the blob it replaced,
+// blob, which is kept or newly attached, is already counted.
+// If expectedRevision is not zero and item's revision differs from it,
+// ErrRevisionConflict is returned.
+func (r *repository) UpdateItem(
+	ctx context.Context,
+	userID uuid.UUID,
+	item *models.Item,
+	size int64,
+	expectedRevision int64,
+) (released int64, err error) {
+	if item == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "item").Msg("item can't be nil")
+		return 0, ErrNilArgument
+	}
+	id := item.ID.String()
+
+	r.log(ctx).Debug().Str("item", id).Msg("preparing filter")
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "id", Value: item.ID},
+		{Key: "kind", Value: "items"},
+	}
+
+	if item.BlobID != uuid.Nil {
+		attached, attachErr := r.attachBlob(ctx, userID, item.BlobID, item.ID)
+		if attachErr != nil {
+			return 0, attachErr
+		}
+		if attached {
+			defer func() {
+				if err != nil {
+					r.detachBlob(ctx, userID, item.BlobID, item.ID)
+				}
+			}()
+		}
+	}
+
+	revision, err := r.nextRevision(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	defer r.releaseRevision(ctx, userID, revision)
+
+	r.log(ctx).Debug().Str("item", id).Msg("preparing update")
+	var update interface{}
+	if item.BlobID != uuid.Nil {
+		update = bson.D{{Key: "$set", Value: bson.D{
+			{Key: "payload", Value: item.Payload},
+			{Key: "blob_id", Value: item.BlobID},
+			{Key: "blob_size", Value: item.BlobSize},
+			{Key: "blob_hash", Value: item.BlobHash},
+			{Key: "updated_at", Value: item.UpdatedAt},
+			{Key: "revision", Value: revision},
+			{Key: "usage_bytes", Value: size + item.BlobSize},
+		}}}
+	} else {
+		// blob is kept, so its size is taken from the item being updated
+		update = mongo.Pipeline{{{Key: "$set", Value: bson.D{
+			{Key: "payload", Value: bson.D{{Key: "$literal", Value: item.Payload}}},
+			{Key: "updated_at", Value: item.UpdatedAt},
+			{Key: "revision", Value: revision},
+			{Key: "usage_bytes", Value: bson.D{{Key: "$add", Value: bson.A{
+				size,
+				bson.D{{Key: "$ifNull", Value: bson.A{"$blob_size", 0}}},
+			}}}},
+		}}}}
+	}
+
+	r.log(ctx).Debug().Str("item", id).Msg("updating item")
+	result := r.items.FindOneAndUpdate(
+		ctx,
+		withRevision(filter, expectedRevision),
+		update,
+		options.FindOneAndUpdate().SetProjection(bson.D{
+			{Key: "blob_id", Value: 1},
+			{Key: "blob_size", Value: 1},
+			{Key: "blob_hash", Value: 1},
+			{Key: "usage_bytes", Value: 1},
+		}),
+	)
+	previous, err := r.decodeItemBlob(result, id)
 	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, r.conflictOrNoItem(ctx, filter, id, expectedRevision)
+		}
+		return 0, err
+	}
+	released = previous.UsageBytes
+	switch {
+	case item.BlobID == uuid.Nil:
+		item.BlobID = previous.BlobID
+		item.BlobSize = previous.BlobSize
+		item.BlobHash = previous.BlobHash
+		released -= previous.BlobSize
+	case previous.BlobID == item.BlobID:
+		released -= previous.BlobSize
+	case previous.BlobID != uuid.Nil:
+		r.deleteBlob(ctx, userID, previous.BlobID)
+	}
+
+	item.Revision = revision
+	r.log(ctx).Debug().Str("item", id).Msg("item was updated")
+	return released, nil
+}
+
+// DeleteItem removes item with provided UUID from the user's vault,
+// regardless of item's type, leaving tombstone in its place,
+// so other devices of the user can learn about the deletion.
+// Blob item's content is stored in is removed along with the item.
+// Size the item was counted with in the user's usage is returned.
+// If expectedRevision is not zero and item's revision differs from it,
+// ErrRevisionConflict is returned.
+func (r *repository) DeleteItem(ctx context.Context, userID uuid.UUID, itemID uuid.UUID, expectedRevision int64) (int64, error) {
+	id := itemID.String()
+
+	revision, err := r.nextRevision(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	defer r.releaseRevision(ctx, userID, revision)
+
+	r.log(ctx).Debug().Str("item", id).Msg("preparing filter")
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "id", Value: itemID},
+		{Key: "kind", Value: bson.D{{Key: "$ne", Value: kindTombstone}}},
+	}
+
+	r.log(ctx).Debug().Str("item", id).Msg("replacing item with tombstone")
+	result := r.items.FindOneAndReplace(
+		ctx,
+		withRevision(filter, expectedRevision),
+		&tombstone{
+			UserID:    userID,
+			ID:        itemID,
+			Kind:      kindTombstone,
+			Revision:  revision,
+			DeletedAt: time.Now().UTC(),
+		},
+		options.FindOneAndReplace().SetProjection(bson.D{
+			{Key: "blob_id", Value: 1},
+			{Key: "usage_bytes", Value: 1},
+		}),
+	)
+	previous, err := r.decodeItemBlob(result, id)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, r.conflictOrNoItem(ctx, filter, id, expectedRevision)
+		}
+		return 0, err
+	}
+	if previous.BlobID != uuid.Nil {
+		r.deleteBlob(ctx, userID, previous.BlobID)
+	}
+
+	r.log(ctx).Debug().Str("item", id).Msg("item was deleted")
+	return previous.UsageBytes, nil
+}
+
+// itemBlob holds reference to the blob item's content is stored in
+// along with size the item is counted with in the user's usage.
+type itemBlob struct {
+	BlobID     uuid.UUID `bson:"blob_id"`
+	BlobSize   int64     `bson:"blob_size"`
+	BlobHash   string    `bson:"blob_hash"`
+	UsageBytes int64     `bson:"usage_bytes"`
+}
+
+// decodeItemBlob decodes blob reference of the item changed by find and modify
+// operation, returning mongo.ErrNoDocuments if no item was changed.
+func (r *repository) decodeItemBlob(result *mongo.SingleResult, id string) (*itemBlob, error) {
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return nil, result.Err()
+		}
+		r.logger.
+			Err(result.Err()).
+			Caller().
+			Str("item", id).
+			Msg("unable to change item")
+		return nil, result.Err()
+	}
+
+	var previous itemBlob
+	if err := result.Decode(&previous); err != nil {
 		r.logger.
+			Err(err).
+			Caller().
+			Str("item", id).
+			Msg("unable to decode item")
+		return nil, err
+	}
+	return &previous, nil
+}
+
+// withRevision adds expected revision of the item to filter,
+// unless expected revision is zero.
+func withRevision(filter bson.D, expectedRevision int64) bson.D {
+	if expectedRevision == 0 {
+		return filter
+	}
+	return append(filter[:len(filter):len(filter)], bson.E{Key: "revision", Value: expectedRevision})
+}
+
+// conflictOrNoItem checks why write with expected revision of the item
+// didn't match any document: either item was changed since then,
+// or there is no such item at all.
+func (r *repository) conflictOrNoItem(ctx context.Context, filter bson.D, id string, expectedRevision int64) error {
+	if expectedRevision != 0 {
+		r.log(ctx).Debug().Str("item", id).Msg("checking whether item exists")
+		count, err := r.items.CountDocuments(ctx, filter)
+		if err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("item", id).
+				Msg("unable to count items")
+			return err
+		}
+		if count != 0 {
+			r.log(ctx).Debug().Str("item", id).Int64("expected", expectedRevision).Msg("item was changed since expected revision")
+			return ErrRevisionConflict
+		}
+	}
+	r.log(ctx).Debug().Str("item", id).Msg("no such item in the database")
+	return ErrNoItem
+}
+
+// nextRevision increments revision of the user's vault, returning the new value.
+// Returned revision is reserved until releaseRevision is called with it,
+// holding back changes ReadItemsSince returns.
+func (r *repository) nextRevision(ctx context.Context, userID uuid.UUID) (int64, error) {
+	id := userID.String()
+	now := time.Now().UTC()
+
+	r.log(ctx).Debug().Str("user", id).Msg("incrementing vault revision")
+	// revision is incremented and reserved at once, abandoned reservations are dropped
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{
+			{Key: "revision", Value: bson.D{{Key: "$add", Value: bson.A{
+				bson.D{{Key: "$ifNull", Value: bson.A{"$revision", int64(0)}}},
+				int64(1),
+			}}}},
+		}}},
+		{{Key: "$set", Value: bson.D{
+			{Key: "pending_revisions", Value: bson.D{{Key: "$concatArrays", Value: bson.A{
+				bson.D{{Key: "$filter", Value: bson.D{
+					{Key: "input", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$pending_revisions", bson.A{}}}}},
+					{Key: "cond", Value: bson.D{{Key: "$gt", Value: bson.A{
+						"$$this.reserved_at",
+						now.Add(-pendingRevisionTimeout),
+					}}}},
+				}}},
+				bson.A{bson.D{
+					{Key: "revision", Value: "$revision"},
+					{Key: "reserved_at", Value: now},
+				}},
+			}}}},
+		}}},
+	}
+	result := r.users.FindOneAndUpdate(
+		ctx,
+		bson.D{{Key: "id", Value: userID}},
+		update,
+		options.FindOneAndUpdate().
+			SetReturnDocument(options.After).
+			SetProjection(bson.D{{Key: "revision", Value: 1}}),
+	)
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			r.log(ctx).Debug().Str("user", id).Msg("no such user in the database")
+			return 0, ErrNoUser
+		}
+		r.log(ctx).
+			Err(result.Err()).
+			Caller().
+			Str("user", id).
+			Msg("unable to increment vault revision")
+		return 0, result.Err()
+	}
+
+	var user models.User
+	if err := result.Decode(&user); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to decode vault revision")
+		return 0, err
+	}
+	return user.Revision, nil
+}
+
+// releaseRevision marks vault revision reserved by nextRevision as written,
+// whether the write succeeded or not. Reservation, which can't be released,
+// is dropped after pendingRevisionTimeout.
+func (r *repository) releaseRevision(ctx context.Context, userID uuid.UUID, revision int64) {
+	id := userID.String()
+
+	r.log(ctx).Debug().Str("user", id).Int64("revision", revision).Msg("releasing vault revision")
+	_, err := r.users.UpdateOne(
+		ctx,
+		bson.D{{Key: "id", Value: userID}},
+		bson.D{{Key: "$pull", Value: bson.D{
+			{Key: "pending_revisions", Value: bson.D{{Key: "revision", Value: revision}}},
+		}}},
+	)
+	if err != nil {
+		r.log(ctx).
+			Warn().
+			Err(err).
+			Str("user", id).
+			Int64("revision", revision).
+			Msg("unable to release vault revision")
+	}
+}
+
+// CreateSession adds new session entry to the database.
+func (r *repository) CreateSession(ctx context.Context, session *models.Session) error {
+	if session == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "session").Msg("session can't be nil")
+		return ErrNilArgument
+	}
+
+	id := session.UserID.String()
+
+	r.log(ctx).Debug().Str("user", id).Msg("inserting new session to the database")
+	result, err := r.sessions.InsertOne(ctx, session)
+	if err != nil {
+		r.log(ctx).
 			Err(err).
 			Caller().
 			Str("user", id).
-			Msg("unable to update user's login items")
+			Msg("unable to insert new session to the database")
 		return err
 	}
 
-	r.logger.Debug().Str("user", id).Msgf(
-		"matched %d docs, updated %d docs",
-		result.MatchedCount,
-		result.ModifiedCount,
+	r.log(ctx).Debug().Str("user", id).Msgf(
+		"new session was inserted to the database with %v",
+		result.InsertedID,
 	)
 	return nil
 }
+
+// ReadSession searches the database for a session
+// with provided UUID, returning found session or ErrNoSession.
+func (r *repository) ReadSession(ctx context.Context, sessionID uuid.UUID) (*models.Session, error) {
+	id := sessionID.String()
+
+	r.log(ctx).Debug().Str("session", id).Msg("preparing filter")
+	filter := bson.D{{Key: "id", Value: sessionID}}
+
+	r.log(ctx).Debug().Str("session", id).Msg("searching for session in the database")
+	result := r.sessions.FindOne(ctx, filter)
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			r.log(ctx).Debug().Str("session", id).Msg("no such session in the database")
+			return nil, ErrNoSession
+		}
+		r.log(ctx).
+			Err(result.Err()).
+			Caller().
+			Str("session", id).
+			Msg("unable to perform read operation in the database")
+		return nil, result.Err()
+	}
+
+	r.log(ctx).Debug().Str("session", id).Msg("processing query result")
+	var session models.Session
+	if err := result.Decode(&session); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("session", id).
+			Msg("unable to decode query result")
+		return nil, err
+	}
+
+	r.log(ctx).Debug().Str("session", id).Msg("session was found in the database")
+	return &session, nil
+}
+
+// ReadSessionsByUser returns all active sessions of the user with provided UUID.
+func (r *repository) ReadSessionsByUser(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	id := userID.String()
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing filter")
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "revoked", Value: false},
+		{Key: "expires_at", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("searching for user's sessions in the database")
+	cursor, err := r.sessions.Find(ctx, filter)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to perform read operation in the database")
+		return nil, err
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("processing query result")
+	sessions := make([]*models.Session, 0)
+	if err := cursor.All(ctx, &sessions); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to decode query result")
+		return nil, err
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msgf("found %d sessions in the database", len(sessions))
+	return sessions, nil
+}
+
+// RotateSession replaces refresh token hash of the session with the one
+// of provided session, extending its expiration. Session is changed only
+// if its refresh token hash is still oldHash, so the same refresh token
+// can't be rotated twice, otherwise ErrSessionRotated is returned.
+func (r *repository) RotateSession(ctx context.Context, session *models.Session, oldHash string) error {
+	if session == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "session").Msg("session can't be nil")
+		return ErrNilArgument
+	}
+
+	id := session.ID.String()
+
+	r.log(ctx).Debug().Str("session", id).Msg("preparing filter")
+	filter := bson.D{
+		{Key: "id", Value: session.ID},
+		{Key: "refresh_hash", Value: oldHash},
+		{Key: "revoked", Value: false},
+	}
+
+	r.log(ctx).Debug().Str("session", id).Msg("preparing update")
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "refresh_hash", Value: session.RefreshHash},
+		{Key: "previous_refresh_hash", Value: oldHash},
+		{Key: "last_seen_at", Value: session.LastSeenAt},
+		{Key: "expires_at", Value: session.ExpiresAt},
+	}}}
+
+	r.log(ctx).Debug().Str("session", id).Msg("rotating session's refresh token")
+	result, err := r.sessions.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("session", id).
+			Msg("unable to rotate session's refresh token")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		r.log(ctx).Debug().Str("session", id).Msg("refresh token was already rotated or session was revoked")
+		return ErrSessionRotated
+	}
+
+	session.PreviousRefreshHash = oldHash
+	r.log(ctx).Debug().Str("session", id).Msg("session's refresh token was rotated")
+	return nil
+}
+
+// TouchSession sets last seen moment of the session with provided UUID.
+func (r *repository) TouchSession(ctx context.Context, sessionID uuid.UUID, lastSeenAt time.Time) error {
+	id := sessionID.String()
+
+	r.log(ctx).Debug().Str("session", id).Msg("preparing filter")
+	filter := bson.D{{Key: "id", Value: sessionID}}
+
+	r.log(ctx).Debug().Str("session", id).Msg("preparing update")
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "last_seen_at", Value: lastSeenAt}}}}
+
+	r.log(ctx).Debug().Str("session", id).Msg("updating session's last seen moment")
+	result, err := r.sessions.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("session", id).
+			Msg("unable to update session's last seen moment")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		r.log(ctx).Debug().Str("session", id).Msg("no such session in the database")
+		return ErrNoSession
+	}
+
+	r.log(ctx).Debug().Str("session", id).Msg("session was updated")
+	return nil
+}
+
+// RevokeSession marks session of the user with provided UUIDs as revoked.
+func (r *repository) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	id := sessionID.String()
+
+	r.log(ctx).Debug().Str("session", id).Msg("preparing filter")
+	filter := bson.D{
+		{Key: "id", Value: sessionID},
+		{Key: "user_id", Value: userID},
+	}
+
+	r.log(ctx).Debug().Str("session", id).Msg("preparing update")
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "revoked", Value: true}}}}
+
+	r.log(ctx).Debug().Str("session", id).Msg("revoking session")
+	result, err := r.sessions.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("session", id).
+			Msg("unable to revoke session")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		r.log(ctx).Debug().Str("session", id).Msg("no such session in the database")
+		return ErrNoSession
+	}
+
+	r.log(ctx).Debug().Str("session", id).Msg("session was revoked")
+	return nil
+}
@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
@@ -12,19 +14,36 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func TestNewRepository(t *testing.T) {
-	os.Args = []string{"test", "-c", "A:\\dev\\yandex\\yandex-diploma-2\\dev_srv_config.yaml"}
 	output := zerolog.ConsoleWriter{
 		Out:        os.Stdout,
 		TimeFormat: "02-01-2006 15:04:05 MST",
 	}
 	logger := zerolog.New(output).With().Timestamp().Logger()
+	cfg := config.DefaultServerConfig()
+	// database is unreachable, which only makes initial ping fail fast
+	cfg.Database.Address = "mongodb://127.0.0.1:1/?serverSelectionTimeoutMS=100"
 
-	_, err := NewRepository(logger)
-	require.NoError(t, err)
+	t.Run("success", func(t *testing.T) {
+		cfg := cfg
+		cfg.Blobs.Store = blobStoreFS
+		cfg.Blobs.Dir = t.TempDir()
+		repo, err := NewRepository(cfg, logger)
+		require.NoError(t, err)
+		require.NoError(t, repo.Close(context.Background()))
+	})
+
+	t.Run("unknown blob store", func(t *testing.T) {
+		cfg := cfg
+		cfg.Blobs.Store = "s3"
+		_, err := NewRepository(cfg, logger)
+		require.ErrorIs(t, err, ErrUnknownBlobStore)
+	})
 }
 
 func TestReadUserByLogin(t *testing.T) {
@@ -48,13 +67,9 @@ func TestReadUserByLogin(t *testing.T) {
 		uid := uuid.New()
 		mongoID := primitive.NewObjectID()
 		expectedUser := &models.User{
-			ID:        uid,
-			Login:     "tester",
-			Password:  "somepwd",
-			Logins:    make([]*models.LoginPasswordItem, 0),
-			BankCards: make([]*models.BankCardItem, 0),
-			Texts:     make([]*models.TextItem, 0),
-			Binaries:  make([]*models.BinaryItem, 0),
+			ID:       uid,
+			Login:    "tester",
+			Password: "somepwd",
 		}
 
 		mt.AddMockResponses(mtest.CreateCursorResponse(
@@ -65,10 +80,6 @@ func TestReadUserByLogin(t *testing.T) {
 				{Key: "id", Value: expectedUser.ID},
 				{Key: "login", Value: expectedUser.Login},
 				{Key: "password", Value: expectedUser.Password},
-				{Key: "logins", Value: expectedUser.Logins},
-				{Key: "cards", Value: expectedUser.BankCards},
-				{Key: "texts", Value: expectedUser.Texts},
-				{Key: "binaries", Value: expectedUser.Binaries},
 			},
 		))
 
@@ -93,6 +104,69 @@ func TestReadUserByLogin(t *testing.T) {
 	})
 }
 
+func TestCreateUser(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "foo.bar", mtest.FirstBatch),
+			mtest.CreateSuccessResponse(),
+		)
+
+		err := repo.CreateUser(context.Background(), &models.User{ID: uuid.New(), Login: "tester"})
+		require.NoError(t, err)
+	})
+
+	mt.Run("user exists", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "foo.bar", mtest.FirstBatch, bson.D{
+			{Key: "id", Value: uuid.New()},
+			{Key: "login", Value: "tester"},
+		}))
+
+		err := repo.CreateUser(context.Background(), &models.User{ID: uuid.New(), Login: "tester"})
+		require.ErrorIs(t, err, ErrUserExists)
+	})
+
+	mt.Run("user created concurrently", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "foo.bar", mtest.FirstBatch),
+			mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "duplicate key error"}),
+		)
+
+		err := repo.CreateUser(context.Background(), &models.User{ID: uuid.New(), Login: "tester"})
+		require.ErrorIs(t, err, ErrUserExists)
+	})
+}
+
 func TestReadUserByID(t *testing.T) {
 	cfg := config.ServerConfig{}
 	output := zerolog.ConsoleWriter{
@@ -114,13 +188,9 @@ func TestReadUserByID(t *testing.T) {
 		uid := uuid.New()
 		mongoID := primitive.NewObjectID()
 		expectedUser := &models.User{
-			ID:        uid,
-			Login:     "tester",
-			Password:  "somepwd",
-			Logins:    make([]*models.LoginPasswordItem, 0),
-			BankCards: make([]*models.BankCardItem, 0),
-			Texts:     make([]*models.TextItem, 0),
-			Binaries:  make([]*models.BinaryItem, 0),
+			ID:       uid,
+			Login:    "tester",
+			Password: "somepwd",
 		}
 
 		mt.AddMockResponses(mtest.CreateCursorResponse(
@@ -131,10 +201,6 @@ func TestReadUserByID(t *testing.T) {
 				{Key: "id", Value: expectedUser.ID},
 				{Key: "login", Value: expectedUser.Login},
 				{Key: "password", Value: expectedUser.Password},
-				{Key: "logins", Value: expectedUser.Logins},
-				{Key: "cards", Value: expectedUser.BankCards},
-				{Key: "texts", Value: expectedUser.Texts},
-				{Key: "binaries", Value: expectedUser.Binaries},
 			},
 		))
 
@@ -172,6 +238,125 @@ func TestReadUserByID(t *testing.T) {
 	})
 }
 
+func TestUpdateUserPassword(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 1},
+			{Key: "nModified", Value: 1},
+		})
+
+		err := repo.UpdateUserPassword(context.Background(), uuid.New(), "oldhash", "newhash")
+		require.NoError(t, err)
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		require.Equal(t, "oldhash", update.Lookup("q", "password").StringValue())
+		require.Equal(t, "newhash", update.Lookup("u", "$set", "password").StringValue())
+	})
+
+	mt.Run("password changed", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 0},
+			{Key: "nModified", Value: 0},
+		})
+
+		err := repo.UpdateUserPassword(context.Background(), uuid.New(), "oldhash", "newhash")
+		require.NoError(t, err)
+	})
+}
+
+func TestCreateVaultKey(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	key := &models.VaultKey{
+		WrappedKey: []byte("wrapped"),
+		KDFSalt:    []byte("salt"),
+		KDFTime:    3,
+		KDFMemory:  64 * 1024,
+		KDFThreads: 4,
+	}
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 1},
+			{Key: "nModified", Value: 1},
+		})
+
+		err := repo.CreateVaultKey(context.Background(), uuid.New(), key)
+		require.NoError(t, err)
+	})
+
+	mt.Run("key exists", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 0},
+			{Key: "nModified", Value: 0},
+		})
+
+		err := repo.CreateVaultKey(context.Background(), uuid.New(), key)
+		require.ErrorIs(t, err, ErrVaultKeyExists)
+	})
+
+	mt.Run("nil key", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		err := repo.CreateVaultKey(context.Background(), uuid.New(), nil)
+		require.ErrorIs(t, err, ErrNilArgument)
+	})
+}
+
 func TestCreateItem(t *testing.T) {
 	cfg := config.ServerConfig{}
 	output := zerolog.ConsoleWriter{
@@ -189,6 +374,7 @@ func TestCreateItem(t *testing.T) {
 			logger: logger,
 			client: nil,
 			users:  mt.Coll,
+			items:  mt.Coll,
 		}
 
 		uid := uuid.New()
@@ -202,10 +388,23 @@ func TestCreateItem(t *testing.T) {
 			},
 		}
 
-		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		mt.AddMockResponses(
+			revisionResponse(5),
+			mtest.CreateSuccessResponse(),
+			releaseResponse(),
+		)
 
-		err := repo.CreateItem(context.Background(), item, "logins", uid)
+		err := repo.CreateItem(context.Background(), item, "logins", uid, 64)
 		require.NoError(t, err)
+		reserve := mt.GetStartedEvent().Command
+		require.Equal(t, "findAndModify", reserve.Index(0).Key())
+		_, err = reserve.LookupErr("update", "1", "$set", "pending_revisions")
+		require.NoError(t, err)
+		insert := mt.GetStartedEvent()
+		require.Equal(t, "insert", insert.CommandName)
+		require.Equal(t, int64(64), insert.Command.Lookup("documents", "0", "usage_bytes").Int64())
+		release := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		require.Equal(t, int64(5), release.Lookup("u", "$pull", "pending_revisions", "revision").Int64())
 	})
 
 	mt.Run("insert err", func(mt *mtest.T) {
@@ -214,6 +413,7 @@ func TestCreateItem(t *testing.T) {
 			logger: logger,
 			client: nil,
 			users:  mt.Coll,
+			items:  mt.Coll,
 		}
 
 		uid := uuid.New()
@@ -227,9 +427,1125 @@ func TestCreateItem(t *testing.T) {
 			},
 		}
 
+		mt.AddMockResponses(
+			revisionResponse(5),
+			bson.D{{Key: "ok", Value: 0}},
+		)
+
+		err := repo.CreateItem(context.Background(), item, "logins", uid, 64)
+		require.Error(t, err)
+	})
+
+	mt.Run("item exists", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		item := &models.Item{ID: uuid.New(), Type: models.ItemTypeText, Payload: []byte("ciphertext")}
+
+		mt.AddMockResponses(
+			revisionResponse(5),
+			mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "duplicate key error"}),
+			releaseResponse(),
+		)
+
+		err := repo.CreateItem(context.Background(), item, "items", uuid.New(), 64)
+		require.ErrorIs(t, err, ErrItemExists)
+	})
+
+	mt.Run("blob of another item", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+			blobs:  mt.Coll,
+		}
+
+		item := &models.Item{ID: uuid.New(), Type: models.ItemTypeBinary, Payload: []byte("ciphertext"), BlobID: uuid.New()}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}, {Key: "nModified", Value: 0}})
+
+		err := repo.CreateItem(context.Background(), item, "items", uuid.New(), 64)
+		require.ErrorIs(t, err, ErrBlobAttached)
+	})
+
+	mt.Run("no user", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		item := &models.TextItem{Value: "some text"}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "value", Value: nil},
+		})
+
+		err := repo.CreateItem(context.Background(), item, "texts", uuid.New(), 64)
+		require.ErrorIs(t, err, ErrNoUser)
+	})
+}
+
+func TestReadItems(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			items:  mt.Coll,
+		}
+
+		uid := uuid.New()
+		loginID := uuid.New()
+		itemID := uuid.New()
+		login, err := itemDocument(&models.LoginPasswordItem{
+			ID:       loginID,
+			Login:    "test",
+			Password: []byte("test"),
+		}, "logins", uid)
+		require.NoError(t, err)
+		item, err := itemDocument(&models.Item{
+			ID:       itemID,
+			Type:     models.ItemTypeText,
+			Revision: 2,
+			Payload:  []byte("ciphertext"),
+		}, "items", uid)
+		require.NoError(t, err)
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch, login, item),
+		)
+
+		vault, err := repo.ReadItems(context.Background(), uid)
+		require.NoError(t, err)
+		require.Len(t, vault.Logins, 1)
+		require.Equal(t, loginID, vault.Logins[0].ID)
+		require.Equal(t, "test", vault.Logins[0].Login)
+		require.Len(t, vault.Items, 1)
+		require.Equal(t, itemID, vault.Items[0].ID)
+		require.Equal(t, int64(2), vault.Items[0].Revision)
+		require.Empty(t, vault.BankCards)
+	})
+
+	mt.Run("find err", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			items:  mt.Coll,
+		}
+
 		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
 
-		err := repo.CreateItem(context.Background(), item, "logins", uid)
+		_, err := repo.ReadItems(context.Background(), uuid.New())
 		require.Error(t, err)
 	})
 }
+
+func TestReadItemsSince(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		uid := uuid.New()
+		itemID := uuid.New()
+		deletedID := uuid.New()
+		item, err := itemDocument(&models.Item{
+			ID:       itemID,
+			Type:     models.ItemTypeText,
+			Revision: 7,
+			Payload:  []byte("ciphertext"),
+		}, "items", uid)
+		require.NoError(t, err)
+		deleted := bson.D{
+			{Key: "user_id", Value: uid},
+			{Key: "id", Value: deletedID},
+			{Key: "kind", Value: kindTombstone},
+			{Key: "revision", Value: int64(9)},
+		}
+
+		mt.AddMockResponses(
+			vaultRevisionsResponse(9),
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch, item, deleted),
+		)
+
+		vault, err := repo.ReadItemsSince(context.Background(), uid, 6)
+		require.NoError(t, err)
+		require.Equal(t, int64(9), vault.Revision)
+		require.Len(t, vault.Items, 1)
+		require.Equal(t, itemID, vault.Items[0].ID)
+		require.Equal(t, []uuid.UUID{deletedID}, vault.Deleted)
+	})
+
+	mt.Run("no changes", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		mt.AddMockResponses(
+			vaultRevisionsResponse(6),
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch),
+		)
+
+		vault, err := repo.ReadItemsSince(context.Background(), uuid.New(), 6)
+		require.NoError(t, err)
+		require.Equal(t, int64(6), vault.Revision)
+		require.Empty(t, vault.Items)
+		require.Empty(t, vault.Deleted)
+	})
+
+	mt.Run("interleaved writers", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+		uid := uuid.New()
+		synced := func(since int64, pending ...pendingRevision) int64 {
+			mt.AddMockResponses(
+				vaultRevisionsResponse(6, pending...),
+				mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch),
+			)
+			vault, err := repo.ReadItemsSince(context.Background(), uid, since)
+			require.NoError(t, err)
+			mt.GetStartedEvent()
+			filter := mt.GetStartedEvent().Command.Lookup("filter", "revision").Document()
+			require.Equal(t, vault.Revision, filter.Lookup("$lte").Int64())
+			return vault.Revision
+		}
+
+		// writer A reserved revision 5, writer B reserved revision 6
+		// and finished first, B's change isn't returned before A's one
+		since := synced(4, pendingRevision{Revision: 5, ReservedAt: time.Now()})
+		require.Equal(t, int64(4), since)
+		// once A finishes, both changes are returned
+		since = synced(since)
+		require.Equal(t, int64(6), since)
+	})
+
+	mt.Run("abandoned write", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		mt.AddMockResponses(
+			vaultRevisionsResponse(6, pendingRevision{Revision: 5, ReservedAt: time.Now().Add(-2 * pendingRevisionTimeout)}),
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch),
+		)
+
+		vault, err := repo.ReadItemsSince(context.Background(), uuid.New(), 4)
+		require.NoError(t, err)
+		require.Equal(t, int64(6), vault.Revision)
+	})
+
+	mt.Run("no user", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "gokeeper.users", mtest.FirstBatch))
+
+		_, err := repo.ReadItemsSince(context.Background(), uuid.New(), 4)
+		require.ErrorIs(t, err, ErrNoUser)
+	})
+}
+
+func TestReadItemsByType(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			items:  mt.Coll,
+		}
+
+		uid := uuid.New()
+		text, err := itemDocument(&models.TextItem{
+			ID:    uuid.New(),
+			Value: "some text",
+		}, "texts", uid)
+		require.NoError(t, err)
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch, text),
+		)
+
+		var texts []*models.TextItem
+		err = repo.ReadItemsByType(context.Background(), uid, "texts", &texts)
+		require.NoError(t, err)
+		require.Len(t, texts, 1)
+		require.Equal(t, "some text", texts[0].Value)
+	})
+
+	mt.Run("nil items", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			items:  mt.Coll,
+		}
+
+		err := repo.ReadItemsByType(context.Background(), uuid.New(), "texts", nil)
+		require.ErrorIs(t, err, ErrNilArgument)
+	})
+}
+
+func TestReadItem(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			items:  mt.Coll,
+		}
+
+		uid := uuid.New()
+		itemID := uuid.New()
+		item, err := itemDocument(&models.Item{
+			ID:       itemID,
+			Type:     models.ItemTypeLogin,
+			Revision: 1,
+			Payload:  []byte("ciphertext"),
+		}, "items", uid)
+		require.NoError(t, err)
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch, item),
+		)
+
+		found, err := repo.ReadItem(context.Background(), uid, itemID)
+		require.NoError(t, err)
+		require.Equal(t, itemID, found.ID)
+		require.Equal(t, []byte("ciphertext"), found.Payload)
+	})
+
+	mt.Run("no item", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			items:  mt.Coll,
+		}
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch),
+		)
+
+		_, err := repo.ReadItem(context.Background(), uuid.New(), uuid.New())
+		require.ErrorIs(t, err, ErrNoItem)
+	})
+}
+
+func TestUpdateItem(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	item := &models.Item{
+		ID:        uuid.New(),
+		Type:      models.ItemTypeText,
+		UpdatedAt: time.Now(),
+		Payload:   []byte("ciphertext"),
+	}
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		mt.AddMockResponses(revisionResponse(5), bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "value", Value: bson.D{
+				{Key: "revision", Value: int64(4)},
+				{Key: "usage_bytes", Value: int64(8)},
+			}},
+		})
+
+		released, err := repo.UpdateItem(context.Background(), uuid.New(), item, 10, 0)
+		require.NoError(t, err)
+		require.Equal(t, int64(8), released)
+		require.Equal(t, int64(5), item.Revision)
+		mt.GetStartedEvent()
+		update := mt.GetStartedEvent().Command.Lookup("update").Array().Index(0).Value().Document()
+		require.Equal(t, int64(10), update.Lookup("$set", "usage_bytes", "$add").Array().Index(0).Value().Int64())
+	})
+
+	mt.Run("payload only keeps blob", func(mt *mtest.T) {
+		store, err := NewFileStore(t.TempDir(), logger)
+		require.NoError(t, err)
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+			blobs:  mt.Coll,
+			store:  store,
+		}
+		uid := uuid.New()
+		blobID := uuid.New()
+		_, err = store.Put(context.Background(), uid, blobID, strings.NewReader("content"))
+		require.NoError(t, err)
+		payloadOnly := *item
+
+		mt.AddMockResponses(
+			revisionResponse(5),
+			bson.D{
+				{Key: "ok", Value: 1},
+				{Key: "value", Value: bson.D{
+					{Key: "blob_id", Value: blobID},
+					{Key: "blob_size", Value: int64(7)},
+					{Key: "blob_hash", Value: "hash"},
+					{Key: "usage_bytes", Value: int64(17)},
+				}},
+			},
+			releaseResponse(),
+		)
+
+		released, err := repo.UpdateItem(context.Background(), uid, &payloadOnly, 10, 0)
+		require.NoError(t, err)
+		require.Equal(t, int64(10), released)
+		require.Equal(t, blobID, payloadOnly.BlobID)
+		require.Equal(t, int64(7), payloadOnly.BlobSize)
+		require.Equal(t, "hash", payloadOnly.BlobHash)
+		mt.GetStartedEvent()
+		update := mt.GetStartedEvent().Command.Lookup("update").Array().Index(0).Value().Document()
+		_, err = update.LookupErr("$set", "blob_id")
+		require.Error(t, err)
+		for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+			require.NotEqual(t, "delete", event.CommandName)
+		}
+		content, err := store.Open(context.Background(), uid, blobID, 0)
+		require.NoError(t, err)
+		content.Close()
+	})
+
+	mt.Run("no item", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		mt.AddMockResponses(revisionResponse(5), bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "value", Value: nil},
+		})
+
+		_, err := repo.UpdateItem(context.Background(), uuid.New(), item, 10, 0)
+		require.ErrorIs(t, err, ErrNoItem)
+	})
+
+	mt.Run("conflict", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		mt.AddMockResponses(revisionResponse(5), bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "value", Value: nil},
+		}, mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch, bson.D{
+			{Key: "n", Value: 1},
+		}))
+
+		_, err := repo.UpdateItem(context.Background(), uuid.New(), item, 10, 3)
+		require.ErrorIs(t, err, ErrRevisionConflict)
+	})
+
+	mt.Run("no item with expected revision", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		mt.AddMockResponses(revisionResponse(5), bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "value", Value: nil},
+		}, mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch))
+
+		_, err := repo.UpdateItem(context.Background(), uuid.New(), item, 10, 3)
+		require.ErrorIs(t, err, ErrNoItem)
+	})
+
+	mt.Run("blob", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+			blobs:  mt.Coll,
+		}
+		blobID := uuid.New()
+		withBlob := *item
+		withBlob.BlobID = blobID
+		withBlob.BlobSize = 42
+		withBlob.BlobHash = "hash"
+
+		mt.AddMockResponses(
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+			revisionResponse(5),
+			bson.D{
+				{Key: "ok", Value: 1},
+				{Key: "value", Value: bson.D{{Key: "blob_id", Value: blobID}}},
+			},
+			releaseResponse(),
+		)
+
+		_, err := repo.UpdateItem(context.Background(), uuid.New(), &withBlob, 10, 0)
+		require.NoError(t, err)
+		attach := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		require.Equal(t, "findAndModify", mt.GetStartedEvent().CommandName)
+		_, err = attach.LookupErr("q", "item_id", "$in")
+		require.NoError(t, err)
+		update := mt.GetStartedEvent().Command.Lookup("update", "$set").Document()
+		require.Equal(t, int64(42), update.Lookup("blob_size").Int64())
+		require.Equal(t, int64(52), update.Lookup("usage_bytes").Int64())
+		require.Equal(t, "hash", update.Lookup("blob_hash").StringValue())
+	})
+
+	mt.Run("replaced blob", func(mt *mtest.T) {
+		store, err := NewFileStore(t.TempDir(), logger)
+		require.NoError(t, err)
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+			blobs:  mt.Coll,
+			store:  store,
+		}
+		uid := uuid.New()
+		oldBlobID := uuid.New()
+		_, err = store.Put(context.Background(), uid, oldBlobID, strings.NewReader("content"))
+		require.NoError(t, err)
+		withBlob := *item
+		withBlob.BlobID = uuid.New()
+		withBlob.BlobSize = 42
+
+		mt.AddMockResponses(
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+			revisionResponse(5),
+			bson.D{
+				{Key: "ok", Value: 1},
+				{Key: "value", Value: bson.D{
+					{Key: "blob_id", Value: oldBlobID},
+					{Key: "blob_size", Value: int64(7)},
+					{Key: "usage_bytes", Value: int64(17)},
+				}},
+			},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}},
+			releaseResponse(),
+		)
+
+		released, err := repo.UpdateItem(context.Background(), uid, &withBlob, 10, 0)
+		require.NoError(t, err)
+		require.Equal(t, int64(17), released)
+		_, err = store.Open(context.Background(), uid, oldBlobID, 0)
+		require.ErrorIs(t, err, ErrNoBlob)
+	})
+
+	mt.Run("blob of another item", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+			blobs:  mt.Coll,
+		}
+		withBlob := *item
+		withBlob.BlobID = uuid.New()
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}, {Key: "nModified", Value: 0}})
+
+		_, err := repo.UpdateItem(context.Background(), uuid.New(), &withBlob, 10, 0)
+		require.ErrorIs(t, err, ErrBlobAttached)
+	})
+
+	mt.Run("conflict detaches blob", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+			blobs:  mt.Coll,
+		}
+		withBlob := *item
+		withBlob.BlobID = uuid.New()
+
+		mt.AddMockResponses(
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+			revisionResponse(5),
+			bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: nil}},
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch, bson.D{{Key: "n", Value: 1}}),
+			releaseResponse(),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}},
+		)
+
+		_, err := repo.UpdateItem(context.Background(), uuid.New(), &withBlob, 10, 3)
+		require.ErrorIs(t, err, ErrRevisionConflict)
+		var detach bson.Raw
+		for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+			if event.CommandName == "update" {
+				detach = event.Command
+			}
+		}
+		_, err = detach.LookupErr("updates", "0", "u", "$unset", "item_id")
+		require.NoError(t, err)
+	})
+
+	mt.Run("nil item", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		_, err := repo.UpdateItem(context.Background(), uuid.New(), nil, 10, 0)
+		require.ErrorIs(t, err, ErrNilArgument)
+	})
+}
+
+func TestDeleteItem(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		mt.AddMockResponses(revisionResponse(5), bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "value", Value: bson.D{
+				{Key: "revision", Value: int64(4)},
+				{Key: "usage_bytes", Value: int64(8)},
+			}},
+		})
+
+		size, err := repo.DeleteItem(context.Background(), uuid.New(), uuid.New(), 0)
+		require.NoError(t, err)
+		require.Equal(t, int64(8), size)
+	})
+
+	mt.Run("item with blob", func(mt *mtest.T) {
+		store, err := NewFileStore(t.TempDir(), logger)
+		require.NoError(t, err)
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+			blobs:  mt.Coll,
+			store:  store,
+		}
+		uid := uuid.New()
+		blobID := uuid.New()
+		_, err = store.Put(context.Background(), uid, blobID, strings.NewReader("content"))
+		require.NoError(t, err)
+
+		mt.AddMockResponses(revisionResponse(5), bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "value", Value: bson.D{{Key: "blob_id", Value: blobID}}},
+		}, bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}})
+
+		_, err = repo.DeleteItem(context.Background(), uid, uuid.New(), 0)
+		require.NoError(t, err)
+		_, err = store.Open(context.Background(), uid, blobID, 0)
+		require.ErrorIs(t, err, ErrNoBlob)
+	})
+
+	mt.Run("no item", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		mt.AddMockResponses(revisionResponse(5), bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "value", Value: nil},
+		})
+
+		_, err := repo.DeleteItem(context.Background(), uuid.New(), uuid.New(), 0)
+		require.ErrorIs(t, err, ErrNoItem)
+	})
+
+	mt.Run("conflict", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		mt.AddMockResponses(revisionResponse(5), bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "value", Value: nil},
+		}, mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch, bson.D{
+			{Key: "n", Value: 1},
+		}))
+
+		_, err := repo.DeleteItem(context.Background(), uuid.New(), uuid.New(), 3)
+		require.ErrorIs(t, err, ErrRevisionConflict)
+	})
+}
+
+func TestCreateSession(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			sessions: mt.Coll,
+		}
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := repo.CreateSession(context.Background(), &models.Session{
+			ID:     uuid.New(),
+			UserID: uuid.New(),
+			Device: "laptop",
+		})
+		require.NoError(t, err)
+	})
+
+	mt.Run("insert err", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			sessions: mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+
+		err := repo.CreateSession(context.Background(), &models.Session{ID: uuid.New()})
+		require.Error(t, err)
+	})
+
+	mt.Run("nil session", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			sessions: mt.Coll,
+		}
+
+		err := repo.CreateSession(context.Background(), nil)
+		require.ErrorIs(t, err, ErrNilArgument)
+	})
+}
+
+func TestReadSession(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			sessions: mt.Coll,
+		}
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		expectedSession := &models.Session{
+			ID:          uuid.New(),
+			UserID:      uuid.New(),
+			Device:      "laptop",
+			RefreshHash: "hash",
+			CreatedAt:   now,
+			LastSeenAt:  now,
+			ExpiresAt:   now.Add(time.Hour),
+		}
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(
+			1,
+			"foo.bar",
+			mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: primitive.NewObjectID()},
+				{Key: "id", Value: expectedSession.ID},
+				{Key: "user_id", Value: expectedSession.UserID},
+				{Key: "device", Value: expectedSession.Device},
+				{Key: "refresh_hash", Value: expectedSession.RefreshHash},
+				{Key: "created_at", Value: expectedSession.CreatedAt},
+				{Key: "last_seen_at", Value: expectedSession.LastSeenAt},
+				{Key: "expires_at", Value: expectedSession.ExpiresAt},
+				{Key: "revoked", Value: false},
+			},
+		))
+
+		session, err := repo.ReadSession(context.Background(), expectedSession.ID)
+		require.NoError(t, err)
+		require.Equal(t, expectedSession, session)
+	})
+
+	mt.Run("no session", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			sessions: mt.Coll,
+		}
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "foo.bar", mtest.FirstBatch))
+
+		_, err := repo.ReadSession(context.Background(), uuid.New())
+		require.ErrorIs(t, err, ErrNoSession)
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			sessions: mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 1},
+			{Key: "nModified", Value: 1},
+		})
+
+		err := repo.RevokeSession(context.Background(), uuid.New(), uuid.New())
+		require.NoError(t, err)
+	})
+
+	mt.Run("no session", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			sessions: mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 0},
+			{Key: "nModified", Value: 0},
+		})
+
+		err := repo.RevokeSession(context.Background(), uuid.New(), uuid.New())
+		require.ErrorIs(t, err, ErrNoSession)
+	})
+}
+
+func TestRotateSession(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			sessions: mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 1},
+			{Key: "nModified", Value: 1},
+		})
+
+		session := &models.Session{ID: uuid.New(), RefreshHash: "new"}
+		err := repo.RotateSession(context.Background(), session, "old")
+		require.NoError(t, err)
+		require.Equal(t, "old", session.PreviousRefreshHash)
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		require.Equal(t, "old", update.Lookup("q", "refresh_hash").StringValue())
+		require.Equal(t, "new", update.Lookup("u", "$set", "refresh_hash").StringValue())
+		require.Equal(t, "old", update.Lookup("u", "$set", "previous_refresh_hash").StringValue())
+	})
+
+	mt.Run("already rotated", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			sessions: mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 0},
+			{Key: "nModified", Value: 0},
+		})
+
+		err := repo.RotateSession(context.Background(), &models.Session{ID: uuid.New()}, "old")
+		require.ErrorIs(t, err, ErrSessionRotated)
+	})
+
+	mt.Run("nil session", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			sessions: mt.Coll,
+		}
+
+		err := repo.RotateSession(context.Background(), nil, "old")
+		require.ErrorIs(t, err, ErrNilArgument)
+	})
+}
+
+func TestTouchSession(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			sessions: mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 1},
+			{Key: "nModified", Value: 1},
+		})
+
+		err := repo.TouchSession(context.Background(), uuid.New(), time.Now())
+		require.NoError(t, err)
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		_, err = update.LookupErr("u", "$set", "refresh_hash")
+		require.Error(t, err)
+	})
+
+	mt.Run("no session", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:      cfg,
+			logger:   logger,
+			client:   nil,
+			sessions: mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 0},
+			{Key: "nModified", Value: 0},
+		})
+
+		err := repo.TouchSession(context.Background(), uuid.New(), time.Now())
+		require.ErrorIs(t, err, ErrNoSession)
+	})
+}
+
+// vaultRevisionsResponse returns mocked response of the vault revision read
+// along with revisions still being written.
+func vaultRevisionsResponse(revision int64, pending ...pendingRevision) bson.D {
+	reserved := bson.A{}
+	for _, p := range pending {
+		reserved = append(reserved, bson.D{
+			{Key: "revision", Value: p.Revision},
+			{Key: "reserved_at", Value: p.ReservedAt},
+		})
+	}
+	return mtest.CreateCursorResponse(0, "gokeeper.users", mtest.FirstBatch, bson.D{
+		{Key: "revision", Value: revision},
+		{Key: "pending_revisions", Value: reserved},
+	})
+}
+
+// releaseResponse returns mocked response of the reserved vault revision release.
+func releaseResponse() bson.D {
+	return bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}}
+}
+
+// revisionResponse returns mocked response of the vault revision increment.
+func revisionResponse(revision int64) bson.D {
+	return bson.D{
+		{Key: "ok", Value: 1},
+		{Key: "value", Value: bson.D{{Key: "revision", Value: revision}}},
+	}
+}
+
+func TestPing(t *testing.T) {
+	logger := zerolog.Nop()
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{logger: logger, client: mt.Client}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		require.NoError(t, repo.Ping(context.Background()))
+	})
+
+	mt.Run("unreachable", func(mt *mtest.T) {
+		repo := &repository{logger: logger, client: mt.Client}
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+		require.Error(t, repo.Ping(context.Background()))
+	})
+}
+
+func TestClose(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("success", func(t *testing.T) {
+		client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://localhost:1"))
+		require.NoError(t, err)
+		repo := &repository{logger: logger, client: client}
+
+		require.NoError(t, repo.Close(context.Background()))
+		require.NoError(t, repo.Close(context.Background()))
+	})
+
+	t.Run("no client", func(t *testing.T) {
+		repo := &repository{logger: logger}
+		require.NoError(t, repo.Close(context.Background()))
+	})
+}
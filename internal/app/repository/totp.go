@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrCodeUsed is raised when one-time code of the time step, which
+// was already accepted, or recovery code, which was already used, is sent again.
+var ErrCodeUsed = errors.New("one-time code was already used")
+
+// UpdateTOTP replaces two-factor authentication settings
+// of the user with provided UUID, removing them if totp is nil.
+func (r *repository) UpdateTOTP(ctx context.Context, userID uuid.UUID, totp *models.TOTP) error {
+	id := userID.String()
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing filter")
+	filter := bson.D{{Key: "id", Value: userID}}
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing update")
+	update := bson.D{{Key: "$unset", Value: bson.D{{Key: "totp", Value: ""}}}}
+	if totp != nil {
+		update = bson.D{{Key: "$set", Value: bson.D{{Key: "totp", Value: totp}}}}
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("updating user's two-factor authentication")
+	result, err := r.users.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to update user's two-factor authentication")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		r.log(ctx).Debug().Str("user", id).Msg("no such user in the database")
+		return ErrNoUser
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("user's two-factor authentication was updated")
+	return nil
+}
+
+// UseTOTPStep marks one-time codes up to the provided time step as used,
+// unless code of this or later step was already accepted,
+// in which case ErrCodeUsed is returned.
+func (r *repository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	id := userID.String()
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing filter")
+	filter := bson.D{
+		{Key: "id", Value: userID},
+		{Key: "totp.last_step", Value: bson.D{{Key: "$lt", Value: step}}},
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing update")
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "totp.last_step", Value: step}}}}
+
+	r.log(ctx).Debug().Str("user", id).Int64("step", step).Msg("marking one-time code as used")
+	result, err := r.users.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to mark one-time code as used")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		r.log(ctx).Debug().Str("user", id).Int64("step", step).Msg("one-time code was already used")
+		return ErrCodeUsed
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("one-time code was marked as used")
+	return nil
+}
+
+// UseRecoveryCode removes recovery code with provided hash from the user's
+// unused ones, returning ErrCodeUsed if there is no such code.
+func (r *repository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string) error {
+	id := userID.String()
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing filter")
+	filter := bson.D{
+		{Key: "id", Value: userID},
+		{Key: "totp.recovery_codes", Value: hash},
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing update")
+	update := bson.D{{Key: "$pull", Value: bson.D{{Key: "totp.recovery_codes", Value: hash}}}}
+
+	r.log(ctx).Debug().Str("user", id).Msg("using recovery code")
+	result, err := r.users.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to use recovery code")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		r.log(ctx).Debug().Str("user", id).Msg("recovery code doesn't exist or was already used")
+		return ErrCodeUsed
+	}
+
+	r.log(ctx).Debug().Str("user", id).Msg("recovery code was used")
+	return nil
+}
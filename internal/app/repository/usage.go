@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReadUsage returns storage used by the user with provided UUID:
+// number of items, excluding tombstones, and total size of the items
+// along with blobs attached to them. Usage is counted by UpdateUsage
+// on every write of the user's items.
+func (r *repository) ReadUsage(ctx context.Context, userID uuid.UUID) (*models.Usage, error) {
+	id := userID.String()
+
+	r.log(ctx).Debug().Str("user", id).Msg("reading user's usage")
+	result := r.users.FindOne(
+		ctx,
+		bson.D{{Key: "id", Value: userID}},
+		options.FindOne().SetProjection(bson.D{{Key: "usage", Value: 1}}),
+	)
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			r.log(ctx).Debug().Str("user", id).Msg("no such user in the database")
+			return nil, ErrNoUser
+		}
+		r.log(ctx).
+			Err(result.Err()).
+			Caller().
+			Str("user", id).
+			Msg("unable to find user")
+		return nil, result.Err()
+	}
+
+	var user models.User
+	if err := result.Decode(&user); err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to decode user's usage")
+		return nil, err
+	}
+	return &user.Usage, nil
+}
+
+// UpdateUsage atomically adds delta to the usage of the user with provided UUID.
+// Positive parts of delta are added only while usage stays within
+// non-zero parts of limit, otherwise usage isn't changed
+// and ErrQuotaExceeded is returned. Negative parts aren't limited.
+func (r *repository) UpdateUsage(ctx context.Context, userID uuid.UUID, delta *models.Usage, limit *models.Usage) error {
+	if delta == nil {
+		r.log(ctx).Err(ErrNilArgument).Str("arg", "delta").Msg("delta can't be nil")
+		return ErrNilArgument
+	}
+	id := userID.String()
+
+	r.log(ctx).Debug().Str("user", id).Msg("preparing filter")
+	filter := bson.D{{Key: "id", Value: userID}}
+	limited := false
+	if limit != nil {
+		if (limit.Items > 0 && delta.Items > limit.Items) || (limit.Bytes > 0 && delta.Bytes > limit.Bytes) {
+			r.log(ctx).Debug().Str("user", id).Msg("delta exceeds user's quota")
+			return ErrQuotaExceeded
+		}
+		// usage of the user, who has none yet, is missing and matches as zero
+		if delta.Items > 0 && limit.Items > 0 {
+			filter = append(filter, bson.E{Key: "usage.items", Value: bson.D{{Key: "$not", Value: bson.D{
+				{Key: "$gt", Value: limit.Items - delta.Items},
+			}}}})
+			limited = true
+		}
+		if delta.Bytes > 0 && limit.Bytes > 0 {
+			filter = append(filter, bson.E{Key: "usage.bytes", Value: bson.D{{Key: "$not", Value: bson.D{
+				{Key: "$gt", Value: limit.Bytes - delta.Bytes},
+			}}}})
+			limited = true
+		}
+	}
+
+	r.log(ctx).Debug().Str("user", id).Int64("items", delta.Items).Int64("bytes", delta.Bytes).Msg("updating user's usage")
+	result, err := r.users.UpdateOne(ctx, filter, bson.D{{Key: "$inc", Value: bson.D{
+		{Key: "usage.items", Value: delta.Items},
+		{Key: "usage.bytes", Value: delta.Bytes},
+	}}})
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to update user's usage")
+		return err
+	}
+	if result.MatchedCount != 0 {
+		return nil
+	}
+	if limited {
+		r.log(ctx).Debug().Str("user", id).Msg("checking whether user exists")
+		count, err := r.users.CountDocuments(ctx, bson.D{{Key: "id", Value: userID}})
+		if err != nil {
+			r.log(ctx).
+				Err(err).
+				Caller().
+				Str("user", id).
+				Msg("unable to count users")
+			return err
+		}
+		if count != 0 {
+			r.log(ctx).Debug().Str("user", id).Msg("user's quota is exceeded")
+			return ErrQuotaExceeded
+		}
+	}
+	r.log(ctx).Debug().Str("user", id).Msg("no such user in the database")
+	return ErrNoUser
+}
+
+// ReadStats counts registered users and stored items of all users, excluding tombstones.
+func (r *repository) ReadStats(ctx context.Context) (*models.Stats, error) {
+	r.log(ctx).Debug().Msg("counting users")
+	users, err := r.users.EstimatedDocumentCount(ctx)
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to count users")
+		return nil, err
+	}
+
+	r.log(ctx).Debug().Msg("counting items")
+	items, err := r.items.CountDocuments(ctx, bson.D{{Key: "kind", Value: bson.D{{Key: "$ne", Value: kindTombstone}}}})
+	if err != nil {
+		r.log(ctx).
+			Err(err).
+			Caller().
+			Msg("unable to count items")
+		return nil, err
+	}
+
+	return &models.Stats{Users: users, Items: items}, nil
+}
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestReadUsage(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "gokeeper.users", mtest.FirstBatch, bson.D{
+			{Key: "usage", Value: bson.D{
+				{Key: "items", Value: int64(3)},
+				{Key: "bytes", Value: int64(4696)},
+			}},
+		}))
+
+		usage, err := repo.ReadUsage(context.Background(), uuid.New())
+		require.NoError(t, err)
+		require.Equal(t, int64(3), usage.Items)
+		require.Equal(t, int64(4696), usage.Bytes)
+	})
+
+	mt.Run("nothing counted", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "gokeeper.users", mtest.FirstBatch, bson.D{
+			{Key: "login", Value: "test"},
+		}))
+
+		usage, err := repo.ReadUsage(context.Background(), uuid.New())
+		require.NoError(t, err)
+		require.Equal(t, int64(0), usage.Items)
+		require.Equal(t, int64(0), usage.Bytes)
+	})
+
+	mt.Run("no user", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "gokeeper.users", mtest.FirstBatch))
+
+		_, err := repo.ReadUsage(context.Background(), uuid.New())
+		require.ErrorIs(t, err, ErrNoUser)
+	})
+}
+
+func TestUpdateUsage(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("within quota", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
+
+		err := repo.UpdateUsage(
+			context.Background(),
+			uuid.New(),
+			&models.Usage{Items: 1, Bytes: 100},
+			&models.Usage{Items: 10, Bytes: 1000},
+		)
+		require.NoError(t, err)
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		require.Equal(t, int64(9), update.Lookup("q", "usage.items", "$not", "$gt").Int64())
+		require.Equal(t, int64(900), update.Lookup("q", "usage.bytes", "$not", "$gt").Int64())
+		require.Equal(t, int64(100), update.Lookup("u", "$inc", "usage.bytes").Int64())
+	})
+
+	mt.Run("quota exceeded", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}, {Key: "nModified", Value: 0}},
+			mtest.CreateCursorResponse(0, "gokeeper.users", mtest.FirstBatch, bson.D{{Key: "n", Value: 1}}),
+		)
+
+		err := repo.UpdateUsage(
+			context.Background(),
+			uuid.New(),
+			&models.Usage{Items: 1, Bytes: 100},
+			&models.Usage{Items: 10, Bytes: 1000},
+		)
+		require.ErrorIs(t, err, ErrQuotaExceeded)
+	})
+
+	mt.Run("larger than quota", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		err := repo.UpdateUsage(
+			context.Background(),
+			uuid.New(),
+			&models.Usage{Bytes: 1001},
+			&models.Usage{Bytes: 1000},
+		)
+		require.ErrorIs(t, err, ErrQuotaExceeded)
+	})
+
+	mt.Run("release isn't limited", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
+
+		err := repo.UpdateUsage(
+			context.Background(),
+			uuid.New(),
+			&models.Usage{Items: -1, Bytes: -100},
+			&models.Usage{Items: 10, Bytes: 1000},
+		)
+		require.NoError(t, err)
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		_, err = update.LookupErr("q", "usage.bytes")
+		require.Error(t, err)
+	})
+
+	mt.Run("no user", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}, {Key: "nModified", Value: 0}})
+
+		err := repo.UpdateUsage(context.Background(), uuid.New(), &models.Usage{Items: -1}, nil)
+		require.ErrorIs(t, err, ErrNoUser)
+	})
+
+	mt.Run("nil delta", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+		}
+
+		err := repo.UpdateUsage(context.Background(), uuid.New(), nil, nil)
+		require.ErrorIs(t, err, ErrNilArgument)
+	})
+}
+
+func TestReadStats(t *testing.T) {
+	cfg := config.ServerConfig{}
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("success", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		mt.AddMockResponses(
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: int32(2)}},
+			mtest.CreateCursorResponse(0, "gokeeper.items", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: 1},
+				{Key: "n", Value: int32(7)},
+			}),
+		)
+
+		stats, err := repo.ReadStats(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, int64(2), stats.Users)
+		require.Equal(t, int64(7), stats.Items)
+	})
+
+	mt.Run("count err", func(mt *mtest.T) {
+		repo := &repository{
+			cfg:    cfg,
+			logger: logger,
+			client: nil,
+			users:  mt.Coll,
+			items:  mt.Coll,
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+
+		_, err := repo.ReadStats(context.Background())
+		require.Error(t, err)
+	})
+}
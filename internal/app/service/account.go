@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+)
+
+// ErrVaultKeyRequired is raised when user, who has vault key, changes password
+// without sending vault key wrapped with the new password.
+var ErrVaultKeyRequired = errors.New("vault key wrapped with the new password is required")
+
+// ChangePassword replaces password of the user after checking the old one.
+// User's vault key is replaced along with it by key wrapped with the new
+// password, and all user's sessions except the current one are revoked.
+func (s *service) ChangePassword(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID, oldPassword string, newPassword string, key *models.VaultKey) error {
+	ctx, span := tracer.Start(ctx, "service.ChangePassword")
+	defer span.End()
+
+	id := userID.String()
+	if newPassword == "" {
+		s.log(ctx).Err(ErrNilArgument).Str("arg", "newPassword").Msg("new password can't be empty")
+		return ErrNilArgument
+	}
+
+	user, err := s.repo.ReadUserByID(ctx, userID)
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to read user")
+		return err
+	}
+	if err := s.checkPassword(ctx, user, oldPassword); err != nil {
+		return err
+	}
+	if user.VaultKey != nil && key == nil {
+		s.log(ctx).Info().Str("user", id).Msg("vault key wrapped with the new password wasn't provided")
+		return ErrVaultKeyRequired
+	}
+
+	s.log(ctx).Debug().Str("user", id).Msg("hashing user's new password")
+	_, hashSpan := tracer.Start(ctx, "service.hashUserPassword")
+	hash, err := s.hashUserPassword(newPassword)
+	hashSpan.End()
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to hash user's password")
+		return err
+	}
+
+	s.log(ctx).Debug().Str("user", id).Msg("passing user's credentials to data layer")
+	if err := s.repo.UpdateCredentials(ctx, userID, hash, key); err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to update user's credentials")
+		return err
+	}
+
+	s.log(ctx).Debug().Str("user", id).Msg("revoking user's other sessions")
+	revoked, err := s.repo.RevokeSessions(ctx, userID, sessionID)
+	if err != nil {
+		// password is already changed, so failure is reported for client to retry
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to revoke user's other sessions")
+		return err
+	}
+
+	s.log(ctx).Info().Str("user", id).Int64("revoked", revoked).Msg("user's password was changed")
+	s.Audit(ctx, userID, models.AuditPasswordChanged, map[string]string{"revoked_sessions": strconv.FormatInt(revoked, 10)})
+	return nil
+}
+
+// DeleteAccount removes user along with all user's data
+// after checking user's password. Deletion is audited
+// beforehand, as audit events outlive the account.
+func (s *service) DeleteAccount(ctx context.Context, userID uuid.UUID, password string) error {
+	ctx, span := tracer.Start(ctx, "service.DeleteAccount")
+	defer span.End()
+
+	id := userID.String()
+	user, err := s.repo.ReadUserByID(ctx, userID)
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to read user")
+		return err
+	}
+	if err := s.checkPassword(ctx, user, password); err != nil {
+		return err
+	}
+
+	s.Audit(ctx, userID, models.AuditAccountDeleted, nil)
+	s.log(ctx).Debug().Str("user", id).Msg("removing user's data")
+	if err := s.repo.DeleteUser(ctx, userID); err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to remove user")
+		return err
+	}
+
+	s.log(ctx).Info().Str("user", id).Msg("user's account was deleted")
+	return nil
+}
+
+// checkPassword confirms that password belongs to the user. Wrong passwords
+// count as failed login attempts, so they can't be guessed with stolen session.
+func (s *service) checkPassword(ctx context.Context, user *models.User, password string) error {
+	ip := clientIP(ctx)
+	if wait := s.limiter.acquire(user.Login, ip); wait > 0 {
+		s.log(ctx).
+			Warn().
+			Str("user", user.Login).
+			Str("ip", ip).
+			Dur("retry_after", wait).
+			Msg("rejecting password of blocked login or address")
+		return &AttemptsError{RetryAfter: wait}
+	}
+
+	_, verifySpan := tracer.Start(ctx, "service.verifyUserPassword")
+	ok, _, err := s.verifyUserPassword(password, user.Password)
+	verifySpan.End()
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", user.Login).
+			Msg("unable to verify user's password")
+		s.limiter.release(user.Login, ip)
+		return err
+	}
+	if !ok {
+		s.log(ctx).Info().Str("user", user.Login).Msg("provided password isn't correct")
+		s.loginFailed(ctx, user.Login, ip)
+		return ErrInvalidCredentials
+	}
+	s.limiter.release(user.Login, ip)
+	return nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/mocks"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangePassword(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+	key := &models.VaultKey{WrappedKey: []byte("rewrapped")}
+
+	t.Run("success", func(t *testing.T) {
+		svc := &service{
+			cfg:    config.ServerConfig{Salt: "testsalt"},
+			repo:   mr,
+			logger: logger,
+		}
+		uid, sid := uuid.New(), uuid.New()
+		user := &models.User{
+			ID:       uid,
+			Login:    "test",
+			Password: hashPassword(t, svc, "oldpwd"),
+			VaultKey: &models.VaultKey{WrappedKey: []byte("wrapped")},
+		}
+
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(user, nil)
+		var hash string
+		update := mr.EXPECT().
+			UpdateCredentials(gomock.Any(), gomock.Eq(uid), gomock.Any(), gomock.Eq(key)).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, password string, _ *models.VaultKey) error {
+				hash = password
+				return nil
+			})
+		revoke := mr.EXPECT().RevokeSessions(gomock.Any(), gomock.Eq(uid), gomock.Eq(sid)).Return(int64(2), nil)
+		audit := mr.EXPECT().CreateAuditEvent(gomock.Any(), auditEvent(uid, models.AuditPasswordChanged)).Return(nil)
+		gomock.InOrder(read, update, revoke, audit)
+
+		err := svc.ChangePassword(context.Background(), uid, sid, "oldpwd", "newpwd", key)
+		require.NoError(t, err)
+		ok, _, err := svc.verifyUserPassword("newpwd", hash)
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		limiter, _ := testLimiter(t)
+		svc := &service{
+			cfg:     config.ServerConfig{Salt: "testsalt"},
+			repo:    mr,
+			logger:  logger,
+			limiter: limiter,
+		}
+		uid := uuid.New()
+		user := &models.User{
+			ID:       uid,
+			Login:    "test",
+			Password: hashPassword(t, svc, "oldpwd"),
+		}
+
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(user, nil)
+		gomock.InOrder(read)
+
+		err := svc.ChangePassword(context.Background(), uid, uuid.New(), "wrongpwd", "newpwd", nil)
+		require.ErrorIs(t, err, ErrInvalidCredentials)
+		require.Contains(t, limiter.entries, loginKey("test"))
+
+		// next attempt is rejected until the delay passes
+		read = mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(user, nil)
+		gomock.InOrder(read)
+		err = svc.ChangePassword(context.Background(), uid, uuid.New(), "oldpwd", "newpwd", nil)
+		require.ErrorIs(t, err, ErrTooManyAttempts)
+	})
+
+	t.Run("vault key required", func(t *testing.T) {
+		svc := &service{
+			cfg:    config.ServerConfig{Salt: "testsalt"},
+			repo:   mr,
+			logger: logger,
+		}
+		uid := uuid.New()
+		user := &models.User{
+			ID:       uid,
+			Login:    "test",
+			Password: hashPassword(t, svc, "oldpwd"),
+			VaultKey: &models.VaultKey{WrappedKey: []byte("wrapped")},
+		}
+
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(user, nil)
+		gomock.InOrder(read)
+
+		err := svc.ChangePassword(context.Background(), uid, uuid.New(), "oldpwd", "newpwd", nil)
+		require.ErrorIs(t, err, ErrVaultKeyRequired)
+	})
+
+	t.Run("empty password", func(t *testing.T) {
+		svc := &service{
+			cfg:    config.ServerConfig{Salt: "testsalt"},
+			repo:   mr,
+			logger: logger,
+		}
+
+		err := svc.ChangePassword(context.Background(), uuid.New(), uuid.New(), "oldpwd", "", key)
+		require.ErrorIs(t, err, ErrNilArgument)
+	})
+}
+
+func TestDeleteAccount(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+	svc := &service{
+		cfg:    config.ServerConfig{Salt: "testsalt"},
+		repo:   mr,
+		logger: logger,
+	}
+	uid := uuid.New()
+	user := &models.User{
+		ID:       uid,
+		Login:    "test",
+		Password: hashPassword(t, svc, "somepwd"),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(user, nil)
+		audit := mr.EXPECT().CreateAuditEvent(gomock.Any(), auditEvent(uid, models.AuditAccountDeleted)).Return(nil)
+		remove := mr.EXPECT().DeleteUser(gomock.Any(), gomock.Eq(uid)).Return(nil)
+		gomock.InOrder(read, audit, remove)
+
+		err := svc.DeleteAccount(context.Background(), uid, "somepwd")
+		require.NoError(t, err)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(user, nil)
+		gomock.InOrder(read)
+
+		err := svc.DeleteAccount(context.Background(), uid, "wrongpwd")
+		require.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("data layer error", func(t *testing.T) {
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(user, nil)
+		audit := mr.EXPECT().CreateAuditEvent(gomock.Any(), auditEvent(uid, models.AuditAccountDeleted)).Return(nil)
+		remove := mr.EXPECT().DeleteUser(gomock.Any(), gomock.Eq(uid)).Return(errors.New("unreachable"))
+		gomock.InOrder(read, audit, remove)
+
+		err := svc.DeleteAccount(context.Background(), uid, "somepwd")
+		require.Error(t, err)
+	})
+}
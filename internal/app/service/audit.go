@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"google.golang.org/grpc/peer"
+)
+
+// Audit appends event of the user with provided uuid to the audit log,
+// filling its time and address of the client, which made the request.
+// Audited operation has already happened, so failure to record
+// the event is logged rather than returned.
+func (s *service) Audit(ctx context.Context, userID uuid.UUID, eventType models.AuditEventType, details map[string]string) {
+	ctx, span := tracer.Start(ctx, "service.Audit")
+	defer span.End()
+
+	event := &models.AuditEvent{
+		ID:     uuid.New(),
+		UserID: userID,
+		Type:   eventType,
+		// database keeps milliseconds, and events are paged by their time
+		Time:     time.Now().UTC().Truncate(time.Millisecond),
+		ClientIP: clientIP(ctx),
+		Details:  details,
+	}
+
+	s.log(ctx).Debug().Str("user", userID.String()).Str("event", string(eventType)).Msg("recording audit event")
+	if err := s.repo.CreateAuditEvent(ctx, event); err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Str("event", string(eventType)).
+			Msg("unable to record audit event")
+	}
+}
+
+// ListAuditEvents returns at most limit audit events of the user with provided uuid,
+// the newest ones first, following the after event if it is set.
+func (s *service) ListAuditEvents(
+	ctx context.Context,
+	userID uuid.UUID,
+	after *models.AuditEvent,
+	limit int64,
+) ([]*models.AuditEvent, error) {
+	ctx, span := tracer.Start(ctx, "service.ListAuditEvents")
+	defer span.End()
+
+	s.log(ctx).Debug().Str("user", userID.String()).Int64("limit", limit).Msg("reading user's audit events")
+	events, err := s.repo.ReadAuditEvents(ctx, userID, after, limit)
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to read user's audit events")
+		return nil, err
+	}
+	return events, nil
+}
+
+// clientIP returns IP address of the client, which made the request, from gRPC peer info.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/mocks"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/peer"
+)
+
+// auditEventMatcher matches audit event of provided type recorded for the user.
+type auditEventMatcher struct {
+	userID    uuid.UUID
+	eventType models.AuditEventType
+}
+
+func auditEvent(userID uuid.UUID, eventType models.AuditEventType) gomock.Matcher {
+	return auditEventMatcher{userID: userID, eventType: eventType}
+}
+
+func (m auditEventMatcher) Matches(x interface{}) bool {
+	event, ok := x.(*models.AuditEvent)
+	return ok && event.UserID == m.userID && event.Type == m.eventType
+}
+
+func (m auditEventMatcher) String() string {
+	return fmt.Sprintf("is %s audit event of user %s", m.eventType, m.userID)
+}
+
+func TestAudit(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.10"), Port: 51234},
+		})
+		create := mr.EXPECT().CreateAuditEvent(
+			gomock.Any(),
+			auditEvent(uid, models.AuditItemCreated),
+		).DoAndReturn(func(_ context.Context, event *models.AuditEvent) error {
+			require.NotEqual(t, uuid.Nil, event.ID)
+			require.Equal(t, "192.0.2.10", event.ClientIP)
+			require.Equal(t, "item-id", event.Details["item"])
+			require.WithinDuration(t, time.Now(), event.Time, time.Second)
+			require.Equal(t, event.Time.Truncate(time.Millisecond), event.Time)
+			return nil
+		})
+		gomock.InOrder(create)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		svc.Audit(ctx, uid, models.AuditItemCreated, map[string]string{"item": "item-id"})
+	})
+
+	t.Run("without peer", func(t *testing.T) {
+		uid := uuid.New()
+		create := mr.EXPECT().CreateAuditEvent(
+			gomock.Any(),
+			auditEvent(uid, models.AuditSignUp),
+		).DoAndReturn(func(_ context.Context, event *models.AuditEvent) error {
+			require.Equal(t, "", event.ClientIP)
+			return nil
+		})
+		gomock.InOrder(create)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		svc.Audit(context.Background(), uid, models.AuditSignUp, nil)
+	})
+}
+
+func TestListAuditEvents(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		after := &models.AuditEvent{ID: uuid.New(), Time: time.Now()}
+		events := []*models.AuditEvent{{ID: uuid.New(), UserID: uid, Type: models.AuditSignUp}}
+		read := mr.EXPECT().
+			ReadAuditEvents(gomock.Any(), gomock.Eq(uid), gomock.Eq(after), int64(10)).
+			Return(events, nil)
+		gomock.InOrder(read)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		out, err := svc.ListAuditEvents(context.Background(), uid, after, 10)
+		require.NoError(t, err)
+		require.Equal(t, events, out)
+	})
+
+	t.Run("repo err", func(t *testing.T) {
+		uid := uuid.New()
+		read := mr.EXPECT().
+			ReadAuditEvents(gomock.Any(), gomock.Eq(uid), gomock.Nil(), int64(10)).
+			Return(nil, fmt.Errorf("some err"))
+		gomock.InOrder(read)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		_, err := svc.ListAuditEvents(context.Background(), uid, nil, 10)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+)
+
+// Default lockout parameters, used when lockout is not configured.
+const (
+	defaultMaxFailures   = 5
+	defaultMaxIPFailures = 50
+	defaultLockout       = 15 * time.Minute
+	defaultBaseDelay     = time.Second
+	defaultMaxDelay      = time.Minute
+	defaultFailureWindow = 15 * time.Minute
+)
+
+// ErrTooManyAttempts is raised when login attempt is rejected, because
+// there were too many failed attempts with the same login or from the same address.
+var ErrTooManyAttempts = errors.New("too many failed login attempts")
+
+// AttemptsError is returned instead of ErrTooManyAttempts,
+// carrying how long client has to wait before the next attempt.
+type AttemptsError struct {
+	RetryAfter time.Duration
+}
+
+// Error returns message of the error.
+func (e *AttemptsError) Error() string {
+	return fmt.Sprintf("%s, retry in %s", ErrTooManyAttempts, e.RetryAfter)
+}
+
+// Is reports whether target is ErrTooManyAttempts.
+func (e *AttemptsError) Is(target error) bool {
+	return target == ErrTooManyAttempts
+}
+
+// failures holds failed login attempts made with the same login or from the same address,
+// along with attempts which are still being checked.
+type failures struct {
+	count        int
+	pending      int
+	last         time.Time
+	blockedUntil time.Time
+}
+
+// loginLimiter tracks failed login attempts in memory and tells
+// how long logins and addresses are blocked for. Nil limiter allows all attempts.
+type loginLimiter struct {
+	mu            sync.Mutex
+	maxFailures   int
+	maxIPFailures int
+	lockout       time.Duration
+	baseDelay     time.Duration
+	maxDelay      time.Duration
+	window        time.Duration
+	now           func() time.Time
+	entries       map[string]*failures
+	lastPrune     time.Time
+}
+
+// newLoginLimiter returns limiter with configured lockout parameters.
+func newLoginLimiter(cfg config.ServerConfig) *loginLimiter {
+	l := &loginLimiter{
+		maxFailures:   cfg.Lockout.MaxFailures,
+		maxIPFailures: cfg.Lockout.MaxIPFailures,
+		lockout:       cfg.Lockout.Duration,
+		baseDelay:     cfg.Lockout.BaseDelay,
+		maxDelay:      cfg.Lockout.MaxDelay,
+		window:        cfg.Lockout.Window,
+		now:           time.Now,
+		entries:       make(map[string]*failures),
+	}
+	if l.maxFailures <= 0 {
+		l.maxFailures = defaultMaxFailures
+	}
+	if l.maxIPFailures <= 0 {
+		l.maxIPFailures = defaultMaxIPFailures
+	}
+	if l.lockout <= 0 {
+		l.lockout = defaultLockout
+	}
+	if l.baseDelay <= 0 {
+		l.baseDelay = defaultBaseDelay
+	}
+	if l.maxDelay <= 0 {
+		l.maxDelay = defaultMaxDelay
+	}
+	if l.window <= 0 {
+		l.window = defaultFailureWindow
+	}
+	return l
+}
+
+// loginKey returns key failures of the login are tracked by.
+func loginKey(login string) string {
+	return "login:" + login
+}
+
+// ipKey returns key failures from the IP address are tracked by,
+// or empty string if address is unknown.
+func ipKey(ip string) string {
+	if ip == "" {
+		return ""
+	}
+	return "ip:" + ip
+}
+
+// acquire reserves attempt with the login from the ip address, unless one
+// of them is blocked, returning how long it is blocked for instead. Reserved
+// attempts count as failed until they are checked, so parallel attempts can't
+// make more failures than allowed. Every reserved attempt has to be passed
+// to fail, succeed or release once it is checked.
+func (l *loginLimiter) acquire(login string, ip string) time.Duration {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+	keys := []string{loginKey(login), ipKey(ip)}
+	limits := []int{l.maxFailures, l.maxIPFailures}
+	var wait time.Duration
+	for i, key := range keys {
+		if key == "" {
+			continue
+		}
+		f, ok := l.entries[key]
+		if !ok {
+			continue
+		}
+		if left := f.blockedUntil.Sub(now); left > wait {
+			wait = left
+		}
+		if f.pending > 0 && f.recent(now, l.window)+f.pending >= limits[i] && wait < l.baseDelay {
+			// attempts being checked may still lock key out
+			wait = l.baseDelay
+		}
+	}
+	if wait > 0 {
+		return wait
+	}
+
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		f, ok := l.entries[key]
+		if !ok {
+			f = &failures{}
+			l.entries[key] = f
+		}
+		f.pending++
+	}
+	return 0
+}
+
+// fail records failed attempt with the key, blocking it until the delay
+// passes. It reports how long key is blocked and whether it was locked out
+// by this attempt, which happens when failures reach max.
+func (l *loginLimiter) fail(key string, max int) (time.Duration, bool) {
+	if l == nil || key == "" {
+		return 0, false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+	f, ok := l.entries[key]
+	if !ok {
+		f = &failures{}
+		l.entries[key] = f
+	}
+	f.count = f.recent(now, l.window) + 1
+	f.last = now
+	if f.pending > 0 {
+		f.pending--
+	}
+
+	if f.count >= max {
+		f.blockedUntil = now.Add(l.lockout)
+		return l.lockout, f.count == max
+	}
+	delay := l.delay(f.count)
+	f.blockedUntil = now.Add(delay)
+	return delay, false
+}
+
+// succeed forgets failed attempts made with the login and releases
+// attempt reserved with the login from the ip address.
+func (l *loginLimiter) succeed(login string, ip string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if f, ok := l.entries[loginKey(login)]; ok {
+		f.count = 0
+		f.blockedUntil = time.Time{}
+	}
+	l.releaseKeys(loginKey(login), ipKey(ip))
+}
+
+// release releases attempt reserved with the login from the ip address
+// without counting it, as it was neither failed nor succeeded.
+func (l *loginLimiter) release(login string, ip string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.releaseKeys(loginKey(login), ipKey(ip))
+}
+
+// releaseKeys releases attempts reserved with the keys,
+// forgetting keys which have neither failures nor attempts left.
+func (l *loginLimiter) releaseKeys(keys ...string) {
+	for _, key := range keys {
+		f, ok := l.entries[key]
+		if !ok {
+			continue
+		}
+		if f.pending > 0 {
+			f.pending--
+		}
+		if f.pending == 0 && f.count == 0 {
+			delete(l.entries, key)
+		}
+	}
+}
+
+// recent returns the number of failures made within the window.
+func (f *failures) recent(now time.Time, window time.Duration) int {
+	if now.Sub(f.last) > window {
+		return 0
+	}
+	return f.count
+}
+
+// delay returns delay after the count-th failure, doubling with every failure.
+func (l *loginLimiter) delay(count int) time.Duration {
+	delay := l.baseDelay
+	for i := 1; i < count; i++ {
+		delay *= 2
+		if delay >= l.maxDelay {
+			return l.maxDelay
+		}
+	}
+	if delay > l.maxDelay {
+		return l.maxDelay
+	}
+	return delay
+}
+
+// prune removes failures which are neither blocking nor remembered anymore,
+// doing so at most once per window, so memory is bounded by recent attempts.
+func (l *loginLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < l.window {
+		return
+	}
+	l.lastPrune = now
+	for key, f := range l.entries {
+		if f.pending == 0 && now.After(f.blockedUntil) && now.Sub(f.last) > l.window {
+			delete(l.entries, key)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+// testLimiter returns limiter with its clock set to the returned time,
+// which is advanced by moving the pointer's value.
+func testLimiter(t *testing.T) (*loginLimiter, *time.Time) {
+	t.Helper()
+	cfg := config.ServerConfig{}
+	cfg.Lockout.MaxFailures = 3
+	cfg.Lockout.MaxIPFailures = 5
+	cfg.Lockout.Duration = 10 * time.Minute
+	cfg.Lockout.BaseDelay = time.Second
+	cfg.Lockout.MaxDelay = 3 * time.Second
+	cfg.Lockout.Window = 15 * time.Minute
+
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	l := newLoginLimiter(cfg)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestLoginLimiter(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		l := newLoginLimiter(config.ServerConfig{})
+		require.Equal(t, defaultMaxFailures, l.maxFailures)
+		require.Equal(t, defaultMaxIPFailures, l.maxIPFailures)
+		require.Equal(t, defaultLockout, l.lockout)
+		require.Equal(t, defaultBaseDelay, l.baseDelay)
+		require.Equal(t, defaultMaxDelay, l.maxDelay)
+		require.Equal(t, defaultFailureWindow, l.window)
+	})
+
+	t.Run("backoff", func(t *testing.T) {
+		l, _ := testLimiter(t)
+		require.Equal(t, time.Second, l.delay(1))
+		require.Equal(t, 2*time.Second, l.delay(2))
+		require.Equal(t, 3*time.Second, l.delay(3))
+		require.Equal(t, 3*time.Second, l.delay(40))
+	})
+
+	t.Run("lockout", func(t *testing.T) {
+		l, now := testLimiter(t)
+		key := loginKey("test")
+
+		wait, locked := l.fail(key, l.maxFailures)
+		require.False(t, locked)
+		require.Equal(t, time.Second, wait)
+		require.Equal(t, time.Second, l.acquire("test", "192.0.2.10"))
+
+		*now = now.Add(time.Second)
+		require.Zero(t, l.acquire("test", ""))
+		wait, locked = l.fail(key, l.maxFailures)
+		require.False(t, locked)
+		require.Equal(t, 2*time.Second, wait)
+
+		*now = now.Add(2 * time.Second)
+		wait, locked = l.fail(key, l.maxFailures)
+		require.True(t, locked)
+		require.Equal(t, 10*time.Minute, wait)
+		require.Equal(t, 10*time.Minute, l.acquire("test", ""))
+
+		// lockout is only reported once
+		_, locked = l.fail(key, l.maxFailures)
+		require.False(t, locked)
+
+		*now = now.Add(10 * time.Minute)
+		require.Zero(t, l.acquire("test", ""))
+	})
+
+	t.Run("window", func(t *testing.T) {
+		l, now := testLimiter(t)
+		key := loginKey("test")
+
+		l.fail(key, l.maxFailures)
+		l.fail(key, l.maxFailures)
+		*now = now.Add(16 * time.Minute)
+		wait, locked := l.fail(key, l.maxFailures)
+		require.False(t, locked)
+		require.Equal(t, time.Second, wait)
+	})
+
+	t.Run("success resets", func(t *testing.T) {
+		l, now := testLimiter(t)
+		key := loginKey("test")
+
+		require.Zero(t, l.acquire("test", "192.0.2.10"))
+		l.fail(key, l.maxFailures)
+		l.fail(ipKey("192.0.2.10"), l.maxIPFailures)
+		*now = now.Add(time.Second)
+		require.Zero(t, l.acquire("test", "192.0.2.10"))
+		l.succeed("test", "192.0.2.10")
+		_, ok := l.entries[key]
+		require.False(t, ok)
+		// failures from the address are kept
+		require.Equal(t, 1, l.entries[ipKey("192.0.2.10")].count)
+	})
+
+	t.Run("parallel attempts", func(t *testing.T) {
+		l, _ := testLimiter(t)
+		key := loginKey("test")
+
+		for i := 0; i < l.maxFailures; i++ {
+			require.Zero(t, l.acquire("test", ""))
+		}
+		// attempts being checked would lock login out if they fail
+		require.Equal(t, time.Second, l.acquire("test", ""))
+
+		l.release("test", "")
+		require.Zero(t, l.acquire("test", ""))
+		for i := 0; i < l.maxFailures; i++ {
+			l.fail(key, l.maxFailures)
+		}
+		require.Equal(t, 10*time.Minute, l.acquire("test", ""))
+		require.Zero(t, l.entries[key].pending)
+	})
+
+	t.Run("release", func(t *testing.T) {
+		l, _ := testLimiter(t)
+
+		require.Zero(t, l.acquire("test", "192.0.2.10"))
+		l.release("test", "192.0.2.10")
+		require.Empty(t, l.entries)
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		l, now := testLimiter(t)
+
+		l.fail(loginKey("old"), l.maxFailures)
+		*now = now.Add(20 * time.Minute)
+		l.fail(loginKey("new"), l.maxFailures)
+		require.Len(t, l.entries, 1)
+		_, ok := l.entries[loginKey("new")]
+		require.True(t, ok)
+	})
+
+	t.Run("unknown address", func(t *testing.T) {
+		l, _ := testLimiter(t)
+
+		_, locked := l.fail(ipKey(""), l.maxIPFailures)
+		require.False(t, locked)
+		require.Empty(t, l.entries)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var l *loginLimiter
+		require.Zero(t, l.acquire("test", "192.0.2.10"))
+		wait, locked := l.fail(loginKey("test"), 1)
+		require.Zero(t, wait)
+		require.False(t, locked)
+		l.succeed("test", "192.0.2.10")
+		l.release("test", "192.0.2.10")
+	})
+}
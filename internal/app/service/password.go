@@ -0,0 +1,149 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Default argon2id parameters, used when hashing is not configured.
+const (
+	defaultHashTime       uint32 = 3
+	defaultHashMemory     uint32 = 64 * 1024
+	defaultHashThreads    uint8  = 2
+	defaultHashKeyLength  uint32 = 32
+	defaultHashSaltLength uint32 = 16
+)
+
+// ErrMalformedHash is raised when stored password hash can't be decoded.
+var ErrMalformedHash = errors.New("password hash is malformed")
+
+// hashParams holds argon2id parameters password hash was made with.
+type hashParams struct {
+	time      uint32
+	memory    uint32
+	threads   uint8
+	keyLength uint32
+}
+
+// hashUserPassword returns argon2id hash of the password made with
+// random salt, encoded in PHC string format along with hashing parameters.
+func (s *service) hashUserPassword(password string) (string, error) {
+	params, saltLength := s.hashParams()
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, params.keyLength)
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		params.memory,
+		params.time,
+		params.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// verifyUserPassword checks in constant time whether password matches
+// the stored hash, also reporting whether the hash is outdated
+// and should be replaced with the one made with current parameters.
+func (s *service) verifyUserPassword(password, hash string) (bool, bool, error) {
+	if !strings.HasPrefix(hash, "$argon2id$") {
+		legacy := s.hashLegacyUserPassword(password)
+		ok := subtle.ConstantTimeCompare([]byte(legacy), []byte(hash)) == 1
+		return ok, true, nil
+	}
+
+	params, salt, key, err := decodeHash(hash)
+	if err != nil {
+		return false, false, err
+	}
+
+	actual := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, params.keyLength)
+	if subtle.ConstantTimeCompare(actual, key) != 1 {
+		return false, false, nil
+	}
+
+	current, saltLength := s.hashParams()
+	return true, params != current || uint32(len(salt)) != saltLength, nil
+}
+
+// hashLegacyUserPassword returns hashed with sha256 algorythm password,
+// as it was done before switching to argon2id.
+func (s *service) hashLegacyUserPassword(password string) string {
+	pwd := sha256.New()
+	pwd.Write([]byte(password))
+	pwd.Write([]byte(s.cfg.Salt))
+	return fmt.Sprintf("%x", pwd.Sum(nil))
+}
+
+// hashParams returns configured argon2id parameters and salt length.
+func (s *service) hashParams() (hashParams, uint32) {
+	params := hashParams{
+		time:      s.cfg.Hashing.Time,
+		memory:    s.cfg.Hashing.Memory,
+		threads:   s.cfg.Hashing.Threads,
+		keyLength: s.cfg.Hashing.KeyLength,
+	}
+	if params.time == 0 {
+		params.time = defaultHashTime
+	}
+	if params.memory == 0 {
+		params.memory = defaultHashMemory
+	}
+	if params.threads == 0 {
+		params.threads = defaultHashThreads
+	}
+	if params.keyLength == 0 {
+		params.keyLength = defaultHashKeyLength
+	}
+	saltLength := s.cfg.Hashing.SaltLength
+	if saltLength == 0 {
+		saltLength = defaultHashSaltLength
+	}
+	return params, saltLength
+}
+
+// decodeHash parses argon2id hash encoded in PHC string format.
+func decodeHash(hash string) (hashParams, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return hashParams{}, nil, nil, ErrMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return hashParams{}, nil, nil, ErrMalformedHash
+	}
+
+	var params hashParams
+	if _, err := fmt.Sscanf(
+		parts[3],
+		"m=%d,t=%d,p=%d",
+		&params.memory,
+		&params.time,
+		&params.threads,
+	); err != nil {
+		return hashParams{}, nil, nil, ErrMalformedHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return hashParams{}, nil, nil, ErrMalformedHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return hashParams{}, nil, nil, ErrMalformedHash
+	}
+	params.keyLength = uint32(len(key))
+	return params, salt, key, nil
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/repository"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+)
+
+// GetUsage returns storage used by the user with provided uuid.
+func (s *service) GetUsage(ctx context.Context, userID uuid.UUID) (*models.Usage, error) {
+	ctx, span := tracer.Start(ctx, "service.GetUsage")
+	defer span.End()
+
+	s.log(ctx).Debug().Str("user", userID.String()).Msg("reading user's usage")
+	usage, err := s.repo.ReadUsage(ctx, userID)
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to read user's usage")
+		return nil, err
+	}
+	return usage, nil
+}
+
+// ReserveItem checks that new item with payload and blob of provided sizes
+// fits configured quotas and reserves it in the usage of the user with provided
+// uuid. Blob was counted when it was uploaded, so only the payload is reserved.
+// Reservation is returned with ReleaseItem if the item isn't stored after all.
+func (s *service) ReserveItem(ctx context.Context, userID uuid.UUID, payload int64, blobSize int64) error {
+	ctx, span := tracer.Start(ctx, "service.ReserveItem")
+	defer span.End()
+
+	if err := s.checkItemSize(ctx, userID, payload+blobSize); err != nil {
+		return err
+	}
+	return s.reserveUsage(ctx, userID, 1, payload)
+}
+
+// ReleaseItem removes item, which holds provided number of bytes, from the usage
+// of the user with provided uuid, once item is deleted or wasn't stored.
+func (s *service) ReleaseItem(ctx context.Context, userID uuid.UUID, size int64) {
+	ctx, span := tracer.Start(ctx, "service.ReleaseItem")
+	defer span.End()
+
+	s.adjustUsage(ctx, userID, -1, -size)
+}
+
+// ReserveItemUpdate checks that item fits configured quotas once it replaces
+// the stored version and reserves the growth of its payload, which may be
+// negative, in the usage of the user with provided uuid. Item, which doesn't
+// refer to blob, keeps the blob it has. Reserved bytes are returned to be
+// passed to SettleItemUpdate or CancelItemUpdate once the update is done.
+func (s *service) ReserveItemUpdate(ctx context.Context, userID uuid.UUID, item *models.Item) (int64, error) {
+	ctx, span := tracer.Start(ctx, "service.ReserveItemUpdate")
+	defer span.End()
+
+	payload := int64(len(item.Payload))
+	size := payload + item.BlobSize
+	var stored int64
+	if current := s.storedItem(ctx, userID, item.ID); current != nil {
+		stored = int64(len(current.Payload))
+		if item.BlobID == uuid.Nil {
+			size += current.BlobSize
+		}
+	}
+	if err := s.checkItemSize(ctx, userID, size); err != nil {
+		return 0, err
+	}
+
+	reserved := payload - stored
+	if err := s.reserveUsage(ctx, userID, 0, reserved); err != nil {
+		return 0, err
+	}
+	return reserved, nil
+}
+
+// SettleItemUpdate corrects bytes reserved for the update of the item with
+// bytes released by the replaced version, as it could be changed since
+// the reservation was made.
+func (s *service) SettleItemUpdate(ctx context.Context, userID uuid.UUID, item *models.Item, reserved int64, released int64) {
+	ctx, span := tracer.Start(ctx, "service.SettleItemUpdate")
+	defer span.End()
+
+	s.adjustUsage(ctx, userID, 0, int64(len(item.Payload))-released-reserved)
+}
+
+// CancelItemUpdate returns bytes reserved for the update, which failed.
+func (s *service) CancelItemUpdate(ctx context.Context, userID uuid.UUID, reserved int64) {
+	ctx, span := tracer.Start(ctx, "service.CancelItemUpdate")
+	defer span.End()
+
+	s.adjustUsage(ctx, userID, 0, -reserved)
+}
+
+// ReserveBlobChunk checks that blob, which the chunk is appended to, fits
+// configured quotas and reserves the chunk in the usage of the user with
+// provided uuid. Uploaded blob is counted from its first chunk until it expires
+// or its item is removed. Chunk, which isn't appended, is returned with ReleaseBlobChunk.
+func (s *service) ReserveBlobChunk(ctx context.Context, userID uuid.UUID, chunk *models.BlobChunk) error {
+	ctx, span := tracer.Start(ctx, "service.ReserveBlobChunk")
+	defer span.End()
+
+	size := int64(len(chunk.Data))
+	if err := s.checkItemSize(ctx, userID, chunk.Offset+size); err != nil {
+		return err
+	}
+	return s.reserveUsage(ctx, userID, 0, size)
+}
+
+// ReleaseBlobChunk returns chunk, which wasn't appended to its blob.
+func (s *service) ReleaseBlobChunk(ctx context.Context, userID uuid.UUID, chunk *models.BlobChunk) {
+	ctx, span := tracer.Start(ctx, "service.ReleaseBlobChunk")
+	defer span.End()
+
+	s.adjustUsage(ctx, userID, 0, -int64(len(chunk.Data)))
+}
+
+// CountBlob counts blob, which was stored, but wasn't attached to item,
+// in the usage of the user with provided uuid until it expires.
+func (s *service) CountBlob(ctx context.Context, userID uuid.UUID, blob *models.Blob) {
+	ctx, span := tracer.Start(ctx, "service.CountBlob")
+	defer span.End()
+
+	s.adjustUsage(ctx, userID, 0, blob.Size)
+}
+
+// checkItemSize checks that single item of provided size
+// doesn't exceed configured item size quota.
+func (s *service) checkItemSize(ctx context.Context, userID uuid.UUID, size int64) error {
+	max := s.cfg.Quotas.MaxItemSize
+	if max > 0 && size > max {
+		s.log(ctx).Info().Str("user", userID.String()).Int64("size", size).Int64("max", max).Msg("item is too large")
+		return fmt.Errorf("%w: item size is limited to %d bytes", ErrResourceExhausted, max)
+	}
+	return nil
+}
+
+// reserveUsage atomically adds provided number of items and bytes
+// to the usage of the user with provided uuid, unless it makes
+// the user exceed configured quotas.
+func (s *service) reserveUsage(ctx context.Context, userID uuid.UUID, items int64, bytes int64) error {
+	quotas := s.cfg.Quotas
+	s.log(ctx).Debug().Str("user", userID.String()).Int64("items", items).Int64("bytes", bytes).Msg("reserving user's usage")
+	err := s.repo.UpdateUsage(ctx, userID, &models.Usage{Items: items, Bytes: bytes}, &models.Usage{
+		Items: quotas.MaxItems,
+		Bytes: quotas.TotalBytes,
+	})
+	if errors.Is(err, repository.ErrQuotaExceeded) {
+		s.log(ctx).Info().Str("user", userID.String()).Msg("user's quota exceeded")
+		return fmt.Errorf("%w: %s", ErrResourceExhausted, quotaLimits(quotas.MaxItems, quotas.TotalBytes))
+	}
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to reserve user's usage")
+		return err
+	}
+	return nil
+}
+
+// adjustUsage adds provided number of items and bytes, which may be negative,
+// to the usage of the user with provided uuid regardless of configured quotas.
+// Failure is only logged, as the write adjustment follows is already done
+// or failed on its own.
+func (s *service) adjustUsage(ctx context.Context, userID uuid.UUID, items int64, bytes int64) {
+	if items == 0 && bytes == 0 {
+		return
+	}
+
+	s.log(ctx).Debug().Str("user", userID.String()).Int64("items", items).Int64("bytes", bytes).Msg("adjusting user's usage")
+	if err := s.repo.UpdateUsage(ctx, userID, &models.Usage{Items: items, Bytes: bytes}, nil); err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to adjust user's usage")
+	}
+}
+
+// storedItem returns current version of the user's item,
+// which is being replaced, or nil if it can't be read.
+func (s *service) storedItem(ctx context.Context, userID uuid.UUID, itemID uuid.UUID) *models.Item {
+	item, err := s.repo.ReadItem(ctx, userID, itemID)
+	if err != nil {
+		s.log(ctx).
+			Debug().
+			Err(err).
+			Str("user", userID.String()).
+			Str("item", itemID.String()).
+			Msg("unable to read current version of item")
+		return nil
+	}
+	return item
+}
+
+// quotaLimits describes configured quotas for error messages, zero quota means no limit.
+func quotaLimits(maxItems int64, totalBytes int64) string {
+	limits := make([]string, 0, 2)
+	if maxItems > 0 {
+		limits = append(limits, fmt.Sprintf("number of items is limited to %d", maxItems))
+	}
+	if totalBytes > 0 {
+		limits = append(limits, fmt.Sprintf("storage is limited to %d bytes", totalBytes))
+	}
+	return strings.Join(limits, ", ")
+}
@@ -0,0 +1,381 @@
+package service
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/mocks"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/repository"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckItemSize(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	cfg := config.ServerConfig{}
+	cfg.Quotas.MaxItemSize = 100
+	svc := &service{
+		cfg:    cfg,
+		logger: logger,
+	}
+	require.NoError(t, svc.checkItemSize(context.Background(), uuid.New(), 100))
+	require.ErrorIs(t, svc.checkItemSize(context.Background(), uuid.New(), 101), ErrResourceExhausted)
+
+	svc.cfg = config.ServerConfig{}
+	require.NoError(t, svc.checkItemSize(context.Background(), uuid.New(), 1<<40))
+}
+
+func TestReserveUsage(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	cfg := config.ServerConfig{}
+	cfg.Quotas.MaxItems = 10
+	cfg.Quotas.TotalBytes = 1000
+
+	t.Run("reserved", func(t *testing.T) {
+		uid := uuid.New()
+		update := mr.EXPECT().
+			UpdateUsage(
+				gomock.Any(),
+				gomock.Eq(uid),
+				gomock.Eq(&models.Usage{Items: 1, Bytes: 100}),
+				gomock.Eq(&models.Usage{Items: 10, Bytes: 1000}),
+			).
+			Return(nil)
+		gomock.InOrder(update)
+
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		require.NoError(t, svc.reserveUsage(context.Background(), uid, 1, 100))
+	})
+
+	t.Run("quota exceeded", func(t *testing.T) {
+		uid := uuid.New()
+		update := mr.EXPECT().
+			UpdateUsage(gomock.Any(), gomock.Eq(uid), gomock.Any(), gomock.Any()).
+			Return(repository.ErrQuotaExceeded)
+		gomock.InOrder(update)
+
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		err := svc.reserveUsage(context.Background(), uid, 1, 100)
+		require.ErrorIs(t, err, ErrResourceExhausted)
+		require.Contains(t, err.Error(), "limited to 10")
+	})
+
+	t.Run("repo err", func(t *testing.T) {
+		uid := uuid.New()
+		update := mr.EXPECT().
+			UpdateUsage(gomock.Any(), gomock.Eq(uid), gomock.Any(), gomock.Any()).
+			Return(repository.ErrNoUser)
+		gomock.InOrder(update)
+
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		require.ErrorIs(t, svc.reserveUsage(context.Background(), uid, 1, 100), repository.ErrNoUser)
+	})
+}
+
+func TestAdjustUsage(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	cfg := config.ServerConfig{}
+	cfg.Quotas.MaxItems = 10
+
+	t.Run("adjusted without quotas", func(t *testing.T) {
+		uid := uuid.New()
+		update := mr.EXPECT().
+			UpdateUsage(gomock.Any(), gomock.Eq(uid), gomock.Eq(&models.Usage{Items: -1, Bytes: -100}), gomock.Nil()).
+			Return(nil)
+		gomock.InOrder(update)
+
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		svc.adjustUsage(context.Background(), uid, -1, -100)
+	})
+
+	t.Run("nothing changed", func(t *testing.T) {
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		svc.adjustUsage(context.Background(), uuid.New(), 0, 0)
+	})
+}
+
+func TestReserveItem(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	cfg := config.ServerConfig{}
+	cfg.Quotas.MaxItemSize = 110
+
+	t.Run("reserves payload only", func(t *testing.T) {
+		uid := uuid.New()
+		update := mr.EXPECT().
+			UpdateUsage(gomock.Any(), gomock.Eq(uid), gomock.Eq(&models.Usage{Items: 1, Bytes: 10}), gomock.Any()).
+			Return(nil)
+		gomock.InOrder(update)
+
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		require.NoError(t, svc.ReserveItem(context.Background(), uid, 10, 100))
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		err := svc.ReserveItem(context.Background(), uuid.New(), 11, 100)
+		require.ErrorIs(t, err, ErrResourceExhausted)
+	})
+
+	t.Run("released", func(t *testing.T) {
+		uid := uuid.New()
+		update := mr.EXPECT().
+			UpdateUsage(gomock.Any(), gomock.Eq(uid), gomock.Eq(&models.Usage{Items: -1, Bytes: -10}), gomock.Nil()).
+			Return(nil)
+		gomock.InOrder(update)
+
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		svc.ReleaseItem(context.Background(), uid, 10)
+	})
+}
+
+func TestReserveItemUpdate(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	cfg := config.ServerConfig{}
+	cfg.Quotas.MaxItemSize = 110
+
+	t.Run("reserves difference", func(t *testing.T) {
+		uid := uuid.New()
+		itemID := uuid.New()
+		read := mr.EXPECT().
+			ReadItem(gomock.Any(), gomock.Eq(uid), gomock.Eq(itemID)).
+			Return(&models.Item{ID: itemID, Payload: []byte("old")}, nil)
+		update := mr.EXPECT().
+			UpdateUsage(gomock.Any(), gomock.Eq(uid), gomock.Eq(&models.Usage{Bytes: 7}), gomock.Any()).
+			Return(nil)
+		gomock.InOrder(read, update)
+
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		reserved, err := svc.ReserveItemUpdate(context.Background(), uid, &models.Item{
+			ID:      itemID,
+			Payload: []byte("ciphertext"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(7), reserved)
+	})
+
+	t.Run("keeps blob counted", func(t *testing.T) {
+		uid := uuid.New()
+		itemID := uuid.New()
+		read := mr.EXPECT().
+			ReadItem(gomock.Any(), gomock.Eq(uid), gomock.Eq(itemID)).
+			Return(&models.Item{ID: itemID, Payload: []byte("old"), BlobID: uuid.New(), BlobSize: 101}, nil)
+		gomock.InOrder(read)
+
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		_, err := svc.ReserveItemUpdate(context.Background(), uid, &models.Item{
+			ID:      itemID,
+			Payload: []byte("ciphertext"),
+		})
+		require.ErrorIs(t, err, ErrResourceExhausted)
+	})
+
+	t.Run("unreadable stored item", func(t *testing.T) {
+		uid := uuid.New()
+		itemID := uuid.New()
+		read := mr.EXPECT().
+			ReadItem(gomock.Any(), gomock.Eq(uid), gomock.Eq(itemID)).
+			Return(nil, repository.ErrNoItem)
+		update := mr.EXPECT().
+			UpdateUsage(gomock.Any(), gomock.Eq(uid), gomock.Eq(&models.Usage{Bytes: 10}), gomock.Any()).
+			Return(nil)
+		gomock.InOrder(read, update)
+
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		reserved, err := svc.ReserveItemUpdate(context.Background(), uid, &models.Item{
+			ID:      itemID,
+			Payload: []byte("ciphertext"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(10), reserved)
+	})
+
+	t.Run("settled after item changed", func(t *testing.T) {
+		uid := uuid.New()
+		// item was changed to 5 bytes since 7 bytes were reserved over 3 stored ones
+		update := mr.EXPECT().
+			UpdateUsage(gomock.Any(), gomock.Eq(uid), gomock.Eq(&models.Usage{Bytes: -2}), gomock.Nil()).
+			Return(nil)
+		gomock.InOrder(update)
+
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		svc.SettleItemUpdate(context.Background(), uid, &models.Item{Payload: []byte("ciphertext")}, 7, 5)
+	})
+
+	t.Run("settled as reserved", func(t *testing.T) {
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		svc.SettleItemUpdate(context.Background(), uuid.New(), &models.Item{Payload: []byte("ciphertext")}, 7, 3)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		uid := uuid.New()
+		update := mr.EXPECT().
+			UpdateUsage(gomock.Any(), gomock.Eq(uid), gomock.Eq(&models.Usage{Bytes: -7}), gomock.Nil()).
+			Return(nil)
+		gomock.InOrder(update)
+
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		svc.CancelItemUpdate(context.Background(), uid, 7)
+	})
+}
+
+func TestReserveBlobChunk(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	cfg := config.ServerConfig{}
+	cfg.Quotas.MaxItemSize = 5
+
+	t.Run("reserved", func(t *testing.T) {
+		uid := uuid.New()
+		update := mr.EXPECT().
+			UpdateUsage(gomock.Any(), gomock.Eq(uid), gomock.Eq(&models.Usage{Bytes: 3}), gomock.Any()).
+			Return(nil)
+		gomock.InOrder(update)
+
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		require.NoError(t, svc.ReserveBlobChunk(context.Background(), uid, &models.BlobChunk{Data: []byte("one")}))
+	})
+
+	t.Run("blob too large", func(t *testing.T) {
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		err := svc.ReserveBlobChunk(context.Background(), uuid.New(), &models.BlobChunk{Offset: 3, Data: []byte("two")})
+		require.ErrorIs(t, err, ErrResourceExhausted)
+	})
+
+	t.Run("released", func(t *testing.T) {
+		uid := uuid.New()
+		update := mr.EXPECT().
+			UpdateUsage(gomock.Any(), gomock.Eq(uid), gomock.Eq(&models.Usage{Bytes: -3}), gomock.Nil()).
+			Return(nil)
+		gomock.InOrder(update)
+
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		svc.ReleaseBlobChunk(context.Background(), uid, &models.BlobChunk{Data: []byte("one")})
+	})
+
+	t.Run("blob counted", func(t *testing.T) {
+		uid := uuid.New()
+		update := mr.EXPECT().
+			UpdateUsage(gomock.Any(), gomock.Eq(uid), gomock.Eq(&models.Usage{Bytes: 100}), gomock.Nil()).
+			Return(nil)
+		gomock.InOrder(update)
+
+		svc := &service{
+			cfg:    cfg,
+			repo:   mr,
+			logger: logger,
+		}
+		svc.CountBlob(context.Background(), uid, &models.Blob{Size: 100})
+	})
+}
@@ -3,75 +3,139 @@ package service
 
 import (
 	"context"
-	"crypto/sha256"
+	"crypto/subtle"
 	"errors"
-	"fmt"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/auth"
 	"github.com/serjyuriev/yandex-diploma-2/internal/app/repository"
 	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/logging"
 	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"go.opentelemetry.io/otel"
 )
 
+// tracer starts spans of service layer methods.
+var tracer = otel.Tracer("github.com/serjyuriev/yandex-diploma-2/internal/app/service")
+
 var (
 	// ErrInvalidCredentails is raised when provided user's credentials are not correct.
+	// It is also raised for logins which don't exist, so they can't be told apart.
 	ErrInvalidCredentials = errors.New("login and/or password incorrect")
-	// ErrUserNotExists is raised when client tries to login with non-existing user.
-	ErrUserNotExists = errors.New("user doesn't exist")
 	// ErrNilArgument is raised when client makes a call for a method without
 	// providing enough information.
 	ErrNilArgument = errors.New("argument can't be empty")
+	// ErrInvalidSession is raised when client uses session which doesn't exist,
+	// already expired or was revoked.
+	ErrInvalidSession = errors.New("session is invalid, expired or revoked")
+	// ErrResourceExhausted is raised when user exceeds one of the storage quotas.
+	ErrResourceExhausted = errors.New("storage quota exceeded")
+)
+
+const (
+	// defaultRefreshTokenTTL is used when refresh token lifetime is not configured.
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+	// lastSeenGranularity limits how often session's last seen moment
+	// is written to the database.
+	lastSeenGranularity = time.Minute
 )
 
 // Service provides service layer methods.
 type Service interface {
 	SignUpUser(ctx context.Context, user *models.User) (string, error)
 	LoginUser(ctx context.Context, user *models.User) (string, error)
+	CreateSession(ctx context.Context, userID uuid.UUID, device string) (*models.Session, string, error)
+	RefreshSession(ctx context.Context, refreshToken string) (*models.Session, string, error)
+	ValidateSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]*models.Session, error)
+	RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error
+	GetUsage(ctx context.Context, userID uuid.UUID) (*models.Usage, error)
+	ReserveItem(ctx context.Context, userID uuid.UUID, payload int64, blobSize int64) error
+	ReleaseItem(ctx context.Context, userID uuid.UUID, size int64)
+	ReserveItemUpdate(ctx context.Context, userID uuid.UUID, item *models.Item) (int64, error)
+	SettleItemUpdate(ctx context.Context, userID uuid.UUID, item *models.Item, reserved int64, released int64)
+	CancelItemUpdate(ctx context.Context, userID uuid.UUID, reserved int64)
+	ReserveBlobChunk(ctx context.Context, userID uuid.UUID, chunk *models.BlobChunk) error
+	ReleaseBlobChunk(ctx context.Context, userID uuid.UUID, chunk *models.BlobChunk)
+	CountBlob(ctx context.Context, userID uuid.UUID, blob *models.Blob)
+	Audit(ctx context.Context, userID uuid.UUID, eventType models.AuditEventType, details map[string]string)
+	ListAuditEvents(ctx context.Context, userID uuid.UUID, after *models.AuditEvent, limit int64) ([]*models.AuditEvent, error)
+	EnableTOTP(ctx context.Context, userID uuid.UUID) (string, string, error)
+	ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error
+	VerifyTOTP(ctx context.Context, userID uuid.UUID, code string) error
+	ChangePassword(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID, oldPassword string, newPassword string, key *models.VaultKey) error
+	DeleteAccount(ctx context.Context, userID uuid.UUID, password string) error
 }
 
 // Service holds objects for service layer implementation.
 type service struct {
-	cfg    config.ServerConfig
-	repo   repository.Repository
-	logger zerolog.Logger
+	cfg     config.ServerConfig
+	repo    repository.Repository
+	logger  zerolog.Logger
+	limiter *loginLimiter
+	// dummyHash is verified against when login doesn't exist,
+	// so such attempts take as long as the ones with wrong password.
+	dummyHash     string
+	dummyHashOnce sync.Once
+}
+
+// log returns logger of the request ctx belongs to,
+// falling back to service layer's logger.
+func (s *service) log(ctx context.Context) *zerolog.Logger {
+	return logging.FromContext(ctx, &s.logger)
 }
 
 // NewService initializes app's service layer.
-func NewService(logger zerolog.Logger, repo repository.Repository) (Service, error) {
+func NewService(cfg config.ServerConfig, logger zerolog.Logger, repo repository.Repository) (Service, error) {
 	if repo == nil {
 		logger.Err(ErrNilArgument).Str("arg", "repo").Msg("repository can't be nil")
 		return nil, ErrNilArgument
 	}
 
-	logger.Debug().Str("module", "service").Msg("getting app's configuration")
-	cfg := config.GetServerConfig()
-
 	logger.Info().Msg("service layer was successfully initialized")
 	return &service{
-		cfg:    cfg,
-		repo:   repo,
-		logger: logger,
+		cfg:     cfg,
+		repo:    repo,
+		logger:  logger,
+		limiter: newLoginLimiter(cfg),
 	}, nil
 }
 
 // SignUpUser hashes user password and adds user to the database,
 // returning user's uuid.
 func (s *service) SignUpUser(ctx context.Context, user *models.User) (string, error) {
+	ctx, span := tracer.Start(ctx, "service.SignUpUser")
+	defer span.End()
+
 	if user == nil {
-		s.logger.Err(ErrNilArgument).Str("arg", "user").Msg("user can't be nil")
+		s.log(ctx).Err(ErrNilArgument).Str("arg", "user").Msg("user can't be nil")
 		return "", ErrNilArgument
 	}
 
-	s.logger.Debug().Str("user", user.Login).Msg("generating user's uuid")
+	s.log(ctx).Debug().Str("user", user.Login).Msg("generating user's uuid")
 	user.ID = uuid.New()
 
-	s.logger.Debug().Str("user", user.Login).Msg("hashing user's password")
-	user.Password = s.hashUserPassword(user.Password)
+	s.log(ctx).Debug().Str("user", user.Login).Msg("hashing user's password")
+	_, hashSpan := tracer.Start(ctx, "service.hashUserPassword")
+	hash, err := s.hashUserPassword(user.Password)
+	hashSpan.End()
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", user.Login).
+			Msg("unable to hash user's password")
+		return "", err
+	}
+	user.Password = hash
 
-	s.logger.Debug().Str("user", user.Login).Msg("passing user's info to data layer")
+	s.log(ctx).Debug().Str("user", user.Login).Msg("passing user's info to data layer")
 	if err := s.repo.CreateUser(ctx, user); err != nil {
-		s.logger.
+		s.log(ctx).
 			Err(err).
 			Caller().
 			Str("user", user.Login).
@@ -79,51 +143,390 @@ func (s *service) SignUpUser(ctx context.Context, user *models.User) (string, er
 		return "", err
 	}
 
+	s.Audit(ctx, user.ID, models.AuditSignUp, nil)
 	return user.ID.String(), nil
 }
 
 // LoginUser checks whether user exists in the database and
-// if user's credentials are equals, logins user.
+// if user's credentials are equals, logins user. Attempts with
+// unknown login and wrong password fail the same way, and repeated
+// failures of the login or the client's address block further attempts.
+// When user has two-factor authentication on, user's uuid is returned
+// along with ErrTOTPRequired, and login is completed by VerifyTOTP.
 func (s *service) LoginUser(ctx context.Context, user *models.User) (string, error) {
+	ctx, span := tracer.Start(ctx, "service.LoginUser")
+	defer span.End()
+
 	if user == nil {
-		s.logger.Err(ErrNilArgument).Str("arg", "user").Msg("user can't be nil")
+		s.log(ctx).Err(ErrNilArgument).Str("arg", "user").Msg("user can't be nil")
 		return "", ErrNilArgument
 	}
 
-	s.logger.Debug().Str("user", user.Login).Msg("checking if such user exists")
+	ip := clientIP(ctx)
+	if wait := s.limiter.acquire(user.Login, ip); wait > 0 {
+		s.log(ctx).
+			Warn().
+			Str("user", user.Login).
+			Str("ip", ip).
+			Dur("retry_after", wait).
+			Msg("rejecting login attempt of blocked login or address")
+		return "", &AttemptsError{RetryAfter: wait}
+	}
+
+	s.log(ctx).Debug().Str("user", user.Login).Msg("checking if such user exists")
 	dbUser, err := s.repo.ReadUserByLogin(ctx, user.Login)
 	if err != nil {
 		if err != repository.ErrNoUser {
-			s.logger.
+			s.log(ctx).
 				Err(err).
 				Caller().
 				Str("user", user.Login).
 				Msg("unable to check if user exists")
+			s.limiter.release(user.Login, ip)
 			return "", err
 		} else {
-			s.logger.Info().Str("user", user.Login).Msg("user with provided login doesn't exist in the system")
-			return "", ErrUserNotExists
+			s.log(ctx).Info().Str("user", user.Login).Msg("user with provided login doesn't exist in the system")
+			// password is still verified, so response time doesn't reveal that login is unknown
+			s.verifyUserPassword(user.Password, s.unknownUserHash())
+			// attempt doesn't belong to any user, so login is kept to tell attempts apart
+			s.Audit(ctx, uuid.Nil, models.AuditLoginFailed, map[string]string{"login": user.Login, "reason": "unknown_user"})
+			s.loginFailed(ctx, user.Login, ip)
+			return "", ErrInvalidCredentials
+		}
+	}
+	s.log(ctx).Debug().Str("user", user.Login).Msg("user with provided login exists")
+
+	s.log(ctx).Debug().Str("user", user.Login).Msg("checking credentials")
+	_, verifySpan := tracer.Start(ctx, "service.verifyUserPassword")
+	ok, rehash, err := s.verifyUserPassword(user.Password, dbUser.Password)
+	verifySpan.End()
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", user.Login).
+			Msg("unable to verify user's password")
+		s.limiter.release(user.Login, ip)
+		return "", err
+	}
+	if !ok || user.Login != dbUser.Login {
+		s.log(ctx).Debug().Str("user", user.Login).Msg("provided credentials aren't correct")
+		s.Audit(ctx, dbUser.ID, models.AuditLoginFailed, map[string]string{"reason": "invalid_credentials"})
+		s.loginFailed(ctx, user.Login, ip)
+		return "", ErrInvalidCredentials
+	}
+	s.log(ctx).Debug().Str("user", user.Login).Msg("provided credentials are correct")
+
+	if rehash {
+		s.rehashUserPassword(ctx, dbUser, user.Password)
+	}
+	if dbUser.TOTP != nil && dbUser.TOTP.Enabled {
+		// failures are kept until one-time code is checked as well,
+		// so codes can't be guessed in between correct passwords
+		s.log(ctx).Debug().Str("user", user.Login).Msg("one-time code is required to complete login")
+		s.limiter.release(user.Login, ip)
+		return dbUser.ID.String(), ErrTOTPRequired
+	}
+	s.limiter.succeed(user.Login, ip)
+	s.Audit(ctx, dbUser.ID, models.AuditLoginSucceeded, nil)
+	return dbUser.ID.String(), nil
+}
+
+// loginFailed records failed login attempt of the login from the ip address,
+// logging lockouts caused by it.
+func (s *service) loginFailed(ctx context.Context, login string, ip string) {
+	if s.limiter == nil {
+		return
+	}
+	if wait, locked := s.limiter.fail(loginKey(login), s.limiter.maxFailures); locked {
+		s.log(ctx).
+			Warn().
+			Str("user", login).
+			Str("ip", ip).
+			Dur("duration", wait).
+			Msg("login is locked out after repeated failed attempts")
+	}
+	if wait, locked := s.limiter.fail(ipKey(ip), s.limiter.maxIPFailures); locked {
+		s.log(ctx).
+			Warn().
+			Str("ip", ip).
+			Dur("duration", wait).
+			Msg("address is locked out after repeated failed attempts")
+	}
+}
+
+// unknownUserHash returns hash attempts with unknown login are verified against,
+// making it with current hashing parameters on first use.
+func (s *service) unknownUserHash() string {
+	s.dummyHashOnce.Do(func() {
+		hash, err := s.hashUserPassword(uuid.NewString())
+		if err != nil {
+			s.logger.Err(err).Caller().Msg("unable to hash dummy password")
+			return
 		}
+		s.dummyHash = hash
+	})
+	return s.dummyHash
+}
+
+// rehashUserPassword replaces outdated hash of user's password
+// with the one made with current hashing parameters. Hash is replaced
+// only while it is still the verified one, so password changed
+// concurrently isn't overwritten with the old one.
+// Failing to do so doesn't prevent user from logging in.
+func (s *service) rehashUserPassword(ctx context.Context, user *models.User, password string) {
+	s.log(ctx).Info().Str("user", user.Login).Msg("upgrading outdated password hash")
+	_, hashSpan := tracer.Start(ctx, "service.hashUserPassword")
+	hash, err := s.hashUserPassword(password)
+	hashSpan.End()
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", user.Login).
+			Msg("unable to hash user's password")
+		return
 	}
-	s.logger.Debug().Str("user", user.Login).Msg("user with provided login exists")
 
-	s.logger.Debug().Str("user", user.Login).Msg("hashing provided user's password")
-	user.Password = s.hashUserPassword(user.Password)
+	if err := s.repo.UpdateUserPassword(ctx, user.ID, user.Password, hash); err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", user.Login).
+			Msg("unable to update user's password hash")
+	}
+}
+
+// CreateSession starts new login session of the user on provided device,
+// returning the session and its refresh token.
+func (s *service) CreateSession(ctx context.Context, userID uuid.UUID, device string) (*models.Session, string, error) {
+	ctx, span := tracer.Start(ctx, "service.CreateSession")
+	defer span.End()
 
-	s.logger.Debug().Str("user", user.Login).Msg("checking credentials")
-	if user.Login == dbUser.Login && user.Password == dbUser.Password {
-		s.logger.Debug().Str("user", user.Login).Msg("provided credentials are correct")
-		return dbUser.ID.String(), nil
+	id := userID.String()
+
+	s.log(ctx).Debug().Str("user", id).Msg("generating session's refresh token")
+	session := &models.Session{
+		ID:     uuid.New(),
+		UserID: userID,
+		Device: device,
+	}
+	refreshToken, refreshHash, err := auth.NewRefreshToken(session.ID)
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to generate refresh token")
+		return nil, "", err
 	}
+	now := time.Now().UTC()
+	session.RefreshHash = refreshHash
+	session.CreatedAt = now
+	session.LastSeenAt = now
+	session.ExpiresAt = now.Add(s.refreshTokenTTL())
 
-	s.logger.Debug().Str("user", user.Login).Msg("provided credentials aren't correct")
-	return "", ErrInvalidCredentials
+	s.log(ctx).Debug().Str("user", id).Msg("passing session's info to data layer")
+	if err := s.repo.CreateSession(ctx, session); err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to create session entry in database")
+		return nil, "", err
+	}
+
+	s.log(ctx).Info().Str("user", id).Str("session", session.ID.String()).Msg("new session was started")
+	return session, refreshToken, nil
 }
 
-// hashUserPassword returns hashed with sha256 algorythm password.
-func (s *service) hashUserPassword(password string) string {
-	pwd := sha256.New()
-	pwd.Write([]byte(password))
-	pwd.Write([]byte(s.cfg.Salt))
-	return fmt.Sprintf("%x", pwd.Sum(nil))
+// RefreshSession checks provided refresh token and rotates it,
+// returning refreshed session and its new refresh token.
+// Reusing already rotated refresh token revokes the whole session,
+// any other token, which doesn't match the session, is just rejected.
+func (s *service) RefreshSession(ctx context.Context, refreshToken string) (*models.Session, string, error) {
+	ctx, span := tracer.Start(ctx, "service.RefreshSession")
+	defer span.End()
+
+	s.log(ctx).Debug().Msg("parsing refresh token")
+	sessionID, refreshHash, err := auth.ParseRefreshToken(refreshToken)
+	if err != nil {
+		s.log(ctx).Info().Err(err).Msg("malformed refresh token was provided")
+		return nil, "", ErrInvalidSession
+	}
+	id := sessionID.String()
+
+	s.log(ctx).Debug().Str("session", id).Msg("checking if such session exists")
+	session, err := s.repo.ReadSession(ctx, sessionID)
+	if err != nil {
+		if err == repository.ErrNoSession {
+			s.log(ctx).Info().Str("session", id).Msg("session with provided id doesn't exist in the system")
+			return nil, "", ErrInvalidSession
+		}
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("session", id).
+			Msg("unable to check if session exists")
+		return nil, "", err
+	}
+	if session.Revoked || time.Now().After(session.ExpiresAt) {
+		s.log(ctx).Info().Str("session", id).Msg("session was revoked or already expired")
+		return nil, "", ErrInvalidSession
+	}
+
+	if subtle.ConstantTimeCompare([]byte(refreshHash), []byte(session.RefreshHash)) != 1 {
+		if session.PreviousRefreshHash == "" ||
+			subtle.ConstantTimeCompare([]byte(refreshHash), []byte(session.PreviousRefreshHash)) != 1 {
+			s.log(ctx).Info().Str("session", id).Msg("refresh token doesn't match the session")
+			return nil, "", ErrInvalidSession
+		}
+		s.log(ctx).Warn().Str("session", id).Msg("outdated refresh token was reused, revoking session")
+		return nil, "", s.revokeReused(ctx, session)
+	}
+
+	s.log(ctx).Debug().Str("session", id).Msg("rotating refresh token")
+	newToken, newHash, err := auth.NewRefreshToken(session.ID)
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("session", id).
+			Msg("unable to generate refresh token")
+		return nil, "", err
+	}
+	now := time.Now().UTC()
+	session.RefreshHash = newHash
+	session.LastSeenAt = now
+	session.ExpiresAt = now.Add(s.refreshTokenTTL())
+
+	s.log(ctx).Debug().Str("session", id).Msg("passing session's info to data layer")
+	if err := s.repo.RotateSession(ctx, session, refreshHash); err != nil {
+		if err == repository.ErrSessionRotated {
+			s.log(ctx).Warn().Str("session", id).Msg("refresh token was concurrently reused, revoking session")
+			return nil, "", s.revokeReused(ctx, session)
+		}
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("session", id).
+			Msg("unable to update session entry in database")
+		return nil, "", err
+	}
+
+	s.log(ctx).Info().Str("session", id).Msg("session was refreshed")
+	return session, newToken, nil
+}
+
+// revokeReused revokes session, which refresh token was presented
+// after being rotated, returning ErrInvalidSession if it succeeds.
+func (s *service) revokeReused(ctx context.Context, session *models.Session) error {
+	if err := s.repo.RevokeSession(ctx, session.UserID, session.ID); err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("session", session.ID.String()).
+			Msg("unable to revoke session")
+		return err
+	}
+	return ErrInvalidSession
+}
+
+// ValidateSession checks whether session of the user is still active,
+// updating its last seen moment.
+func (s *service) ValidateSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "service.ValidateSession")
+	defer span.End()
+
+	id := sessionID.String()
+
+	s.log(ctx).Debug().Str("session", id).Msg("checking if such session exists")
+	session, err := s.repo.ReadSession(ctx, sessionID)
+	if err != nil {
+		if err == repository.ErrNoSession {
+			s.log(ctx).Info().Str("session", id).Msg("session with provided id doesn't exist in the system")
+			return ErrInvalidSession
+		}
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("session", id).
+			Msg("unable to check if session exists")
+		return err
+	}
+	now := time.Now().UTC()
+	if session.UserID != userID || session.Revoked || now.After(session.ExpiresAt) {
+		s.log(ctx).Info().Str("session", id).Msg("session was revoked or already expired")
+		return ErrInvalidSession
+	}
+
+	if now.Sub(session.LastSeenAt) < lastSeenGranularity {
+		return nil
+	}
+
+	s.log(ctx).Debug().Str("session", id).Msg("updating session's last seen moment")
+	if err := s.repo.TouchSession(ctx, sessionID, now); err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("session", id).
+			Msg("unable to update session entry in database")
+		return err
+	}
+	return nil
+}
+
+// ListSessions returns all active sessions of the user.
+func (s *service) ListSessions(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	ctx, span := tracer.Start(ctx, "service.ListSessions")
+	defer span.End()
+
+	id := userID.String()
+
+	s.log(ctx).Debug().Str("user", id).Msg("getting user's sessions from data layer")
+	sessions, err := s.repo.ReadSessionsByUser(ctx, userID)
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", id).
+			Msg("unable to get user's sessions")
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeSession terminates session of the user,
+// so neither its access nor refresh tokens are accepted anymore.
+func (s *service) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "service.RevokeSession")
+	defer span.End()
+
+	id := sessionID.String()
+
+	s.log(ctx).Debug().Str("session", id).Msg("passing session's revocation to data layer")
+	if err := s.repo.RevokeSession(ctx, userID, sessionID); err != nil {
+		if err == repository.ErrNoSession {
+			s.log(ctx).Info().Str("session", id).Msg("user has no session with provided id")
+			return ErrInvalidSession
+		}
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("session", id).
+			Msg("unable to revoke session")
+		return err
+	}
+
+	s.log(ctx).Info().Str("user", userID.String()).Str("session", id).Msg("session was revoked")
+	s.Audit(ctx, userID, models.AuditSessionRevoked, map[string]string{"session": id})
+	return nil
+}
+
+// refreshTokenTTL returns configured refresh token lifetime.
+func (s *service) refreshTokenTTL() time.Duration {
+	if s.cfg.Auth.RefreshTokenTTL <= 0 {
+		return defaultRefreshTokenTTL
+	}
+	return s.cfg.Auth.RefreshTokenTTL
 }
@@ -2,13 +2,17 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/auth"
 	"github.com/serjyuriev/yandex-diploma-2/internal/app/mocks"
 	"github.com/serjyuriev/yandex-diploma-2/internal/app/repository"
 	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
@@ -17,17 +21,23 @@ import (
 )
 
 func TestNewService(t *testing.T) {
-	os.Args = []string{"test", "-c", "A:\\dev\\yandex\\yandex-diploma-2\\dev_srv_config.yaml"}
 	output := zerolog.ConsoleWriter{
 		Out:        os.Stdout,
 		TimeFormat: "02-01-2006 15:04:05 MST",
 	}
 	logger := zerolog.New(output).With().Timestamp().Logger()
-	repo, err := repository.NewRepository(logger)
-	require.NoError(t, err)
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
 
-	_, err = NewService(logger, repo)
-	require.NoError(t, err)
+	t.Run("success", func(t *testing.T) {
+		_, err := NewService(config.DefaultServerConfig(), logger, mr)
+		require.NoError(t, err)
+	})
+
+	t.Run("nil repository", func(t *testing.T) {
+		_, err := NewService(config.DefaultServerConfig(), logger, nil)
+		require.ErrorIs(t, err, ErrNilArgument)
+	})
 }
 
 func TestSignUpUser(t *testing.T) {
@@ -41,19 +51,23 @@ func TestSignUpUser(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		newUser := &models.User{
-			Login:     "test",
-			Password:  "somepwd",
-			Logins:    make([]*models.LoginPasswordItem, 0),
-			BankCards: make([]*models.BankCardItem, 0),
-			Texts:     make([]*models.TextItem, 0),
-			Binaries:  make([]*models.BinaryItem, 0),
+			Login:    "test",
+			Password: "somepwd",
 		}
 		create := mr.EXPECT().CreateUser(
-			context.Background(),
+			gomock.Any(),
 			gomock.Any(),
 		).Return(nil)
+		audit := mr.EXPECT().CreateAuditEvent(
+			gomock.Any(),
+			gomock.Any(),
+		).DoAndReturn(func(_ context.Context, event *models.AuditEvent) error {
+			require.Equal(t, models.AuditSignUp, event.Type)
+			require.Equal(t, newUser.ID, event.UserID)
+			return nil
+		})
 
-		gomock.InOrder(create)
+		gomock.InOrder(create, audit)
 
 		svc := &service{
 			cfg:    config.ServerConfig{Salt: "testsalt"},
@@ -66,15 +80,11 @@ func TestSignUpUser(t *testing.T) {
 
 	t.Run("create err", func(t *testing.T) {
 		newUser := &models.User{
-			Login:     "test",
-			Password:  "somepwd",
-			Logins:    make([]*models.LoginPasswordItem, 0),
-			BankCards: make([]*models.BankCardItem, 0),
-			Texts:     make([]*models.TextItem, 0),
-			Binaries:  make([]*models.BinaryItem, 0),
+			Login:    "test",
+			Password: "somepwd",
 		}
 		create := mr.EXPECT().CreateUser(
-			context.Background(),
+			gomock.Any(),
 			newUser,
 		).Return(fmt.Errorf("some err"))
 
@@ -107,29 +117,25 @@ func TestLoginUser(t *testing.T) {
 		}
 
 		user := &models.User{
-			Login:     "test",
-			Password:  "somepwd",
-			Logins:    make([]*models.LoginPasswordItem, 0),
-			BankCards: make([]*models.BankCardItem, 0),
-			Texts:     make([]*models.TextItem, 0),
-			Binaries:  make([]*models.BinaryItem, 0),
+			Login:    "test",
+			Password: "somepwd",
 		}
 		uid := uuid.New()
 
 		read := mr.EXPECT().ReadUserByLogin(
-			context.Background(),
+			gomock.Any(),
 			gomock.Eq("test"),
 		).Return(&models.User{
-			ID:        uid,
-			Login:     "test",
-			Password:  svc.hashUserPassword("somepwd"),
-			Logins:    make([]*models.LoginPasswordItem, 0),
-			BankCards: make([]*models.BankCardItem, 0),
-			Texts:     make([]*models.TextItem, 0),
-			Binaries:  make([]*models.BinaryItem, 0),
+			ID:       uid,
+			Login:    "test",
+			Password: hashPassword(t, svc, "somepwd"),
 		}, nil)
+		audit := mr.EXPECT().CreateAuditEvent(
+			gomock.Any(),
+			auditEvent(uid, models.AuditLoginSucceeded),
+		).Return(nil)
 
-		gomock.InOrder(read)
+		gomock.InOrder(read, audit)
 		userID, err := svc.LoginUser(context.Background(), user)
 		require.NoError(t, err)
 		require.Equal(t, uid.String(), userID)
@@ -141,7 +147,7 @@ func TestLoginUser(t *testing.T) {
 		}
 
 		read := mr.EXPECT().ReadUserByLogin(
-			context.Background(),
+			gomock.Any(),
 			gomock.Eq("test"),
 		).Return(nil, fmt.Errorf("some err"))
 		gomock.InOrder(read)
@@ -161,10 +167,14 @@ func TestLoginUser(t *testing.T) {
 		}
 
 		read := mr.EXPECT().ReadUserByLogin(
-			context.Background(),
+			gomock.Any(),
 			gomock.Eq("test"),
 		).Return(nil, repository.ErrNoUser)
-		gomock.InOrder(read)
+		audit := mr.EXPECT().CreateAuditEvent(
+			gomock.Any(),
+			auditEvent(uuid.Nil, models.AuditLoginFailed),
+		).Return(nil)
+		gomock.InOrder(read, audit)
 
 		svc := &service{
 			cfg:    config.ServerConfig{Salt: "testsalt"},
@@ -172,7 +182,7 @@ func TestLoginUser(t *testing.T) {
 			logger: logger,
 		}
 		_, err := svc.LoginUser(context.Background(), newUser)
-		require.ErrorIs(t, err, ErrUserNotExists)
+		require.ErrorIs(t, err, ErrInvalidCredentials)
 	})
 
 	t.Run("invalid creds", func(t *testing.T) {
@@ -183,31 +193,691 @@ func TestLoginUser(t *testing.T) {
 		}
 
 		user := &models.User{
-			Login:     "test",
-			Password:  "somepwd",
-			Logins:    make([]*models.LoginPasswordItem, 0),
-			BankCards: make([]*models.BankCardItem, 0),
-			Texts:     make([]*models.TextItem, 0),
-			Binaries:  make([]*models.BinaryItem, 0),
+			Login:    "test",
+			Password: "somepwd",
 		}
 		uid := uuid.New()
 
 		read := mr.EXPECT().ReadUserByLogin(
-			context.Background(),
+			gomock.Any(),
 			gomock.Eq("test"),
 		).Return(&models.User{
-			ID:        uid,
-			Login:     "test",
-			Password:  svc.hashUserPassword("s0m3pwd"),
-			Logins:    make([]*models.LoginPasswordItem, 0),
-			BankCards: make([]*models.BankCardItem, 0),
-			Texts:     make([]*models.TextItem, 0),
-			Binaries:  make([]*models.BinaryItem, 0),
+			ID:       uid,
+			Login:    "test",
+			Password: hashPassword(t, svc, "s0m3pwd"),
 		}, nil)
+		audit := mr.EXPECT().CreateAuditEvent(
+			gomock.Any(),
+			auditEvent(uid, models.AuditLoginFailed),
+		).Return(fmt.Errorf("some err"))
+
+		gomock.InOrder(read, audit)
+		_, err := svc.LoginUser(context.Background(), user)
+		require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	})
+
+	t.Run("totp required", func(t *testing.T) {
+		limiter, now := testLimiter(t)
+		svc := &service{
+			cfg:     config.ServerConfig{Salt: "testsalt"},
+			repo:    mr,
+			logger:  logger,
+			limiter: limiter,
+		}
+		user := &models.User{
+			Login:    "test",
+			Password: "somepwd",
+		}
+		uid := uuid.New()
+		limiter.fail(loginKey("test"), limiter.maxFailures)
+		*now = now.Add(time.Second)
+		dbUser := totpUser(uid)
+		dbUser.Password = hashPassword(t, svc, "somepwd")
 
+		read := mr.EXPECT().ReadUserByLogin(
+			gomock.Any(),
+			gomock.Eq("test"),
+		).Return(dbUser, nil)
 		gomock.InOrder(read)
+
+		userID, err := svc.LoginUser(context.Background(), user)
+		require.ErrorIs(t, err, ErrTOTPRequired)
+		require.Equal(t, uid.String(), userID)
+		// failures are only forgotten once one-time code is checked
+		require.Contains(t, limiter.entries, loginKey("test"))
+	})
+
+	t.Run("blocked", func(t *testing.T) {
+		limiter, now := testLimiter(t)
+		svc := &service{
+			cfg:     config.ServerConfig{Salt: "testsalt"},
+			repo:    mr,
+			logger:  logger,
+			limiter: limiter,
+		}
+		user := &models.User{
+			Login:    "test",
+			Password: "somepwd",
+		}
+
+		read := mr.EXPECT().ReadUserByLogin(
+			gomock.Any(),
+			gomock.Eq("test"),
+		).Return(nil, repository.ErrNoUser)
+		audit := mr.EXPECT().CreateAuditEvent(
+			gomock.Any(),
+			auditEvent(uuid.Nil, models.AuditLoginFailed),
+		).Return(nil)
+		gomock.InOrder(read, audit)
+
 		_, err := svc.LoginUser(context.Background(), user)
 		require.ErrorIs(t, err, ErrInvalidCredentials)
 
+		// next attempt is rejected until the delay passes without reaching the database
+		_, err = svc.LoginUser(context.Background(), user)
+		require.ErrorIs(t, err, ErrTooManyAttempts)
+		var attemptsErr *AttemptsError
+		require.ErrorAs(t, err, &attemptsErr)
+		require.Equal(t, time.Second, attemptsErr.RetryAfter)
+
+		*now = now.Add(time.Second)
+		uid := uuid.New()
+		read = mr.EXPECT().ReadUserByLogin(
+			gomock.Any(),
+			gomock.Eq("test"),
+		).Return(&models.User{
+			ID:       uid,
+			Login:    "test",
+			Password: hashPassword(t, svc, "somepwd"),
+		}, nil)
+		audit = mr.EXPECT().CreateAuditEvent(
+			gomock.Any(),
+			auditEvent(uid, models.AuditLoginSucceeded),
+		).Return(nil)
+		gomock.InOrder(read, audit)
+
+		_, err = svc.LoginUser(context.Background(), user)
+		require.NoError(t, err)
+		require.NotContains(t, limiter.entries, loginKey("test"))
+	})
+
+	t.Run("parallel wrong passwords", func(t *testing.T) {
+		mr := mocks.NewMockRepository(gomock.NewController(t))
+		limiter, _ := testLimiter(t)
+		svc := &service{
+			cfg:     config.ServerConfig{Salt: "testsalt"},
+			repo:    mr,
+			logger:  logger,
+			limiter: limiter,
+		}
+		uid := uuid.New()
+		dbUser := &models.User{
+			ID:       uid,
+			Login:    "test",
+			Password: hashPassword(t, svc, "somepwd"),
+		}
+
+		// attempts reaching password verification wait for the rest to be checked
+		var verified int32
+		proceed := make(chan struct{})
+		mr.EXPECT().ReadUserByLogin(gomock.Any(), gomock.Eq("test")).
+			DoAndReturn(func(context.Context, string) (*models.User, error) {
+				atomic.AddInt32(&verified, 1)
+				<-proceed
+				return dbUser, nil
+			}).
+			AnyTimes()
+		mr.EXPECT().CreateAuditEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+		const attempts = 10
+		results := make(chan error, attempts)
+		for i := 0; i < attempts; i++ {
+			go func() {
+				_, err := svc.LoginUser(context.Background(), &models.User{Login: "test", Password: "guess"})
+				results <- err
+			}()
+		}
+
+		var errs []error
+		timeout := time.After(time.Second)
+	collect:
+		for len(errs) < attempts-limiter.maxFailures {
+			select {
+			case err := <-results:
+				errs = append(errs, err)
+			case <-timeout:
+				break collect
+			}
+		}
+		close(proceed)
+		for len(errs) < attempts {
+			errs = append(errs, <-results)
+		}
+
+		require.LessOrEqual(t, int(atomic.LoadInt32(&verified)), limiter.maxFailures)
+		var invalid int
+		for _, err := range errs {
+			if errors.Is(err, ErrInvalidCredentials) {
+				invalid++
+				continue
+			}
+			require.ErrorIs(t, err, ErrTooManyAttempts)
+		}
+		require.Equal(t, int(atomic.LoadInt32(&verified)), invalid)
+		require.Equal(t, 10*time.Minute, limiter.acquire("test", ""))
+	})
+
+	t.Run("legacy hash", func(t *testing.T) {
+		svc := &service{
+			cfg:    config.ServerConfig{Salt: "testsalt"},
+			repo:   mr,
+			logger: logger,
+		}
+
+		user := &models.User{
+			Login:    "test",
+			Password: "somepwd",
+		}
+		uid := uuid.New()
+
+		read := mr.EXPECT().ReadUserByLogin(
+			gomock.Any(),
+			gomock.Eq("test"),
+		).Return(&models.User{
+			ID:       uid,
+			Login:    "test",
+			Password: svc.hashLegacyUserPassword("somepwd"),
+		}, nil)
+		update := mr.EXPECT().UpdateUserPassword(
+			gomock.Any(),
+			gomock.Eq(uid),
+			gomock.Eq(svc.hashLegacyUserPassword("somepwd")),
+			gomock.Any(),
+		).DoAndReturn(func(_ context.Context, _ uuid.UUID, _ string, hash string) error {
+			ok, rehash, err := svc.verifyUserPassword("somepwd", hash)
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.False(t, rehash)
+			return nil
+		})
+		audit := mr.EXPECT().CreateAuditEvent(
+			gomock.Any(),
+			auditEvent(uid, models.AuditLoginSucceeded),
+		).Return(nil)
+
+		gomock.InOrder(read, update, audit)
+		userID, err := svc.LoginUser(context.Background(), user)
+		require.NoError(t, err)
+		require.Equal(t, uid.String(), userID)
+	})
+
+	t.Run("invalid legacy creds", func(t *testing.T) {
+		svc := &service{
+			cfg:    config.ServerConfig{Salt: "testsalt"},
+			repo:   mr,
+			logger: logger,
+		}
+
+		user := &models.User{
+			Login:    "test",
+			Password: "somepwd",
+		}
+
+		uid := uuid.New()
+
+		read := mr.EXPECT().ReadUserByLogin(
+			gomock.Any(),
+			gomock.Eq("test"),
+		).Return(&models.User{
+			ID:       uid,
+			Login:    "test",
+			Password: svc.hashLegacyUserPassword("s0m3pwd"),
+		}, nil)
+		audit := mr.EXPECT().CreateAuditEvent(
+			gomock.Any(),
+			auditEvent(uid, models.AuditLoginFailed),
+		).Return(nil)
+
+		gomock.InOrder(read, audit)
+		_, err := svc.LoginUser(context.Background(), user)
+		require.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+}
+
+func TestVerifyUserPassword(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	t.Run("per-user salt", func(t *testing.T) {
+		svc := &service{logger: logger}
+		first := hashPassword(t, svc, "somepwd")
+		second := hashPassword(t, svc, "somepwd")
+		require.NotEqual(t, first, second)
+
+		ok, rehash, err := svc.verifyUserPassword("somepwd", first)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.False(t, rehash)
+	})
+
+	t.Run("outdated params", func(t *testing.T) {
+		old := &service{logger: logger}
+		old.cfg.Hashing.Time = 1
+		old.cfg.Hashing.Memory = 1024
+		hash := hashPassword(t, old, "somepwd")
+
+		svc := &service{logger: logger}
+		ok, rehash, err := svc.verifyUserPassword("somepwd", hash)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.True(t, rehash)
+	})
+
+	t.Run("malformed hash", func(t *testing.T) {
+		svc := &service{logger: logger}
+		_, _, err := svc.verifyUserPassword("somepwd", "$argon2id$v=19$m=abc$$")
+		require.ErrorIs(t, err, ErrMalformedHash)
+	})
+}
+
+// hashPassword returns argon2id hash of the password or fails the test.
+func hashPassword(t *testing.T, svc *service, password string) string {
+	t.Helper()
+	hash, err := svc.hashUserPassword(password)
+	require.NoError(t, err)
+	return hash
+}
+
+func TestCreateSession(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		create := mr.EXPECT().CreateSession(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(nil)
+		gomock.InOrder(create)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		session, refreshToken, err := svc.CreateSession(context.Background(), uid, "laptop")
+		require.NoError(t, err)
+		require.Equal(t, uid, session.UserID)
+		require.Equal(t, "laptop", session.Device)
+		require.NotEmpty(t, refreshToken)
+		require.NotEqual(t, refreshToken, session.RefreshHash)
+		require.True(t, session.ExpiresAt.After(time.Now()))
+	})
+
+	t.Run("create err", func(t *testing.T) {
+		create := mr.EXPECT().CreateSession(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(fmt.Errorf("some err"))
+		gomock.InOrder(create)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		_, _, err := svc.CreateSession(context.Background(), uuid.New(), "laptop")
+		require.Error(t, err)
+	})
+}
+
+func TestRefreshSession(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		sid := uuid.New()
+		refreshToken, refreshHash, err := auth.NewRefreshToken(sid)
+		require.NoError(t, err)
+		session := &models.Session{
+			ID:          sid,
+			UserID:      uuid.New(),
+			RefreshHash: refreshHash,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}
+
+		read := mr.EXPECT().ReadSession(
+			gomock.Any(),
+			gomock.Eq(sid),
+		).Return(session, nil)
+		rotate := mr.EXPECT().RotateSession(
+			gomock.Any(),
+			gomock.Any(),
+			gomock.Eq(refreshHash),
+		).Return(nil)
+		gomock.InOrder(read, rotate)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		refreshed, newToken, err := svc.RefreshSession(context.Background(), refreshToken)
+		require.NoError(t, err)
+		require.NotEqual(t, refreshToken, newToken)
+		require.NotEqual(t, refreshHash, refreshed.RefreshHash)
+	})
+
+	t.Run("reused token", func(t *testing.T) {
+		sid := uuid.New()
+		uid := uuid.New()
+		refreshToken, previousHash, err := auth.NewRefreshToken(sid)
+		require.NoError(t, err)
+		_, currentHash, err := auth.NewRefreshToken(sid)
+		require.NoError(t, err)
+
+		read := mr.EXPECT().ReadSession(
+			gomock.Any(),
+			gomock.Eq(sid),
+		).Return(&models.Session{
+			ID:                  sid,
+			UserID:              uid,
+			RefreshHash:         currentHash,
+			PreviousRefreshHash: previousHash,
+			ExpiresAt:           time.Now().Add(time.Hour),
+		}, nil)
+		revoke := mr.EXPECT().RevokeSession(
+			gomock.Any(),
+			gomock.Eq(uid),
+			gomock.Eq(sid),
+		).Return(nil)
+		gomock.InOrder(read, revoke)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		_, _, err = svc.RefreshSession(context.Background(), refreshToken)
+		require.ErrorIs(t, err, ErrInvalidSession)
+	})
+
+	t.Run("concurrently reused token", func(t *testing.T) {
+		sid := uuid.New()
+		uid := uuid.New()
+		refreshToken, refreshHash, err := auth.NewRefreshToken(sid)
+		require.NoError(t, err)
+
+		read := mr.EXPECT().ReadSession(
+			gomock.Any(),
+			gomock.Eq(sid),
+		).Return(&models.Session{
+			ID:          sid,
+			UserID:      uid,
+			RefreshHash: refreshHash,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}, nil)
+		rotate := mr.EXPECT().RotateSession(
+			gomock.Any(),
+			gomock.Any(),
+			gomock.Eq(refreshHash),
+		).Return(repository.ErrSessionRotated)
+		revoke := mr.EXPECT().RevokeSession(
+			gomock.Any(),
+			gomock.Eq(uid),
+			gomock.Eq(sid),
+		).Return(nil)
+		gomock.InOrder(read, rotate, revoke)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		_, _, err = svc.RefreshSession(context.Background(), refreshToken)
+		require.ErrorIs(t, err, ErrInvalidSession)
+	})
+
+	t.Run("forged token", func(t *testing.T) {
+		sid := uuid.New()
+		_, previousHash, err := auth.NewRefreshToken(sid)
+		require.NoError(t, err)
+		_, currentHash, err := auth.NewRefreshToken(sid)
+		require.NoError(t, err)
+
+		// session isn't changed, knowing its id is not enough to revoke it
+		read := mr.EXPECT().ReadSession(
+			gomock.Any(),
+			gomock.Eq(sid),
+		).Return(&models.Session{
+			ID:                  sid,
+			UserID:              uuid.New(),
+			RefreshHash:         currentHash,
+			PreviousRefreshHash: previousHash,
+			ExpiresAt:           time.Now().Add(time.Hour),
+		}, nil)
+		gomock.InOrder(read)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		_, _, err = svc.RefreshSession(context.Background(), sid.String()+".garbage")
+		require.ErrorIs(t, err, ErrInvalidSession)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		sid := uuid.New()
+		refreshToken, refreshHash, err := auth.NewRefreshToken(sid)
+		require.NoError(t, err)
+
+		read := mr.EXPECT().ReadSession(
+			gomock.Any(),
+			gomock.Eq(sid),
+		).Return(&models.Session{
+			ID:          sid,
+			RefreshHash: refreshHash,
+			ExpiresAt:   time.Now().Add(time.Hour),
+			Revoked:     true,
+		}, nil)
+		gomock.InOrder(read)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		_, _, err = svc.RefreshSession(context.Background(), refreshToken)
+		require.ErrorIs(t, err, ErrInvalidSession)
+	})
+
+	t.Run("no session", func(t *testing.T) {
+		sid := uuid.New()
+		refreshToken, _, err := auth.NewRefreshToken(sid)
+		require.NoError(t, err)
+
+		read := mr.EXPECT().ReadSession(
+			gomock.Any(),
+			gomock.Eq(sid),
+		).Return(nil, repository.ErrNoSession)
+		gomock.InOrder(read)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		_, _, err = svc.RefreshSession(context.Background(), refreshToken)
+		require.ErrorIs(t, err, ErrInvalidSession)
+	})
+
+	t.Run("malformed token", func(t *testing.T) {
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		_, _, err := svc.RefreshSession(context.Background(), "kj3n4kj")
+		require.ErrorIs(t, err, ErrInvalidSession)
+	})
+}
+
+func TestValidateSession(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		sid := uuid.New()
+		uid := uuid.New()
+
+		read := mr.EXPECT().ReadSession(
+			gomock.Any(),
+			gomock.Eq(sid),
+		).Return(&models.Session{
+			ID:         sid,
+			UserID:     uid,
+			LastSeenAt: time.Now().Add(-time.Hour),
+			ExpiresAt:  time.Now().Add(time.Hour),
+		}, nil)
+		touch := mr.EXPECT().TouchSession(
+			gomock.Any(),
+			gomock.Eq(sid),
+			gomock.Any(),
+		).Return(nil)
+		gomock.InOrder(read, touch)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		err := svc.ValidateSession(context.Background(), uid, sid)
+		require.NoError(t, err)
+	})
+
+	t.Run("recently seen", func(t *testing.T) {
+		sid := uuid.New()
+		uid := uuid.New()
+
+		read := mr.EXPECT().ReadSession(
+			gomock.Any(),
+			gomock.Eq(sid),
+		).Return(&models.Session{
+			ID:         sid,
+			UserID:     uid,
+			LastSeenAt: time.Now(),
+			ExpiresAt:  time.Now().Add(time.Hour),
+		}, nil)
+		gomock.InOrder(read)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		err := svc.ValidateSession(context.Background(), uid, sid)
+		require.NoError(t, err)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		sid := uuid.New()
+
+		read := mr.EXPECT().ReadSession(
+			gomock.Any(),
+			gomock.Eq(sid),
+		).Return(&models.Session{
+			ID:        sid,
+			UserID:    uuid.New(),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		gomock.InOrder(read)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		err := svc.ValidateSession(context.Background(), uuid.New(), sid)
+		require.ErrorIs(t, err, ErrInvalidSession)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		sid := uuid.New()
+		uid := uuid.New()
+
+		read := mr.EXPECT().ReadSession(
+			gomock.Any(),
+			gomock.Eq(sid),
+		).Return(&models.Session{
+			ID:        sid,
+			UserID:    uid,
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}, nil)
+		gomock.InOrder(read)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		err := svc.ValidateSession(context.Background(), uid, sid)
+		require.ErrorIs(t, err, ErrInvalidSession)
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		sid := uuid.New()
+		revoke := mr.EXPECT().RevokeSession(
+			gomock.Any(),
+			gomock.Eq(uid),
+			gomock.Eq(sid),
+		).Return(nil)
+		audit := mr.EXPECT().CreateAuditEvent(
+			gomock.Any(),
+			auditEvent(uid, models.AuditSessionRevoked),
+		).Return(nil)
+		gomock.InOrder(revoke, audit)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		err := svc.RevokeSession(context.Background(), uid, sid)
+		require.NoError(t, err)
+	})
+
+	t.Run("no session", func(t *testing.T) {
+		uid := uuid.New()
+		sid := uuid.New()
+		revoke := mr.EXPECT().RevokeSession(
+			gomock.Any(),
+			gomock.Eq(uid),
+			gomock.Eq(sid),
+		).Return(repository.ErrNoSession)
+		gomock.InOrder(revoke)
+
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		err := svc.RevokeSession(context.Background(), uid, sid)
+		require.ErrorIs(t, err, ErrInvalidSession)
 	})
 }
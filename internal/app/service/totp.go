@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/repository"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+)
+
+const (
+	// totpIssuer names the app in user's authenticator.
+	totpIssuer = "gokeeper"
+	// totpPeriod is how long in seconds every one-time code is valid.
+	totpPeriod = 30
+	// totpSkew is how many periods before and after current one codes
+	// are accepted from, so clocks of client and server may drift apart.
+	totpSkew = 1
+	// recoveryCodes is how many recovery codes user gets.
+	recoveryCodes = 10
+	// recoveryCodeBytes is the size of random part of every recovery code.
+	recoveryCodeBytes = 10
+)
+
+var (
+	// ErrTOTPRequired is raised along with user's uuid when password is correct,
+	// but user has two-factor authentication on and is yet to send one-time code.
+	ErrTOTPRequired = errors.New("one-time code is required")
+	// ErrInvalidCode is raised when one-time or recovery code is wrong or already used.
+	ErrInvalidCode = errors.New("one-time code is invalid or already used")
+	// ErrTOTPEnabled is raised when user enrolls in two-factor authentication twice.
+	ErrTOTPEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrTOTPNotEnabled is raised when user without two-factor authentication tries to disable it.
+	ErrTOTPNotEnabled = errors.New("two-factor authentication isn't enabled")
+	// ErrTOTPNotPending is raised when user confirms two-factor authentication
+	// without starting enrollment first.
+	ErrTOTPNotPending = errors.New("two-factor authentication enrollment wasn't started")
+)
+
+// totpOpts are parameters codes are generated with, which are supported by common authenticators.
+var totpOpts = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// EnableTOTP starts enrollment of the user with provided uuid in two-factor
+// authentication, returning new secret and otpauth URI to be added to authenticator.
+// Two-factor authentication is turned on once ConfirmTOTP accepts code generated with it.
+func (s *service) EnableTOTP(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	ctx, span := tracer.Start(ctx, "service.EnableTOTP")
+	defer span.End()
+
+	user, err := s.repo.ReadUserByID(ctx, userID)
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to read user")
+		return "", "", err
+	}
+	if user.TOTP != nil && user.TOTP.Enabled {
+		s.log(ctx).Info().Str("user", userID.String()).Msg("user already has two-factor authentication on")
+		return "", "", ErrTOTPEnabled
+	}
+
+	s.log(ctx).Debug().Str("user", userID.String()).Msg("generating totp secret")
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      totpIssuer,
+		AccountName: user.Login,
+		Period:      totpOpts.Period,
+		Digits:      totpOpts.Digits,
+		Algorithm:   totpOpts.Algorithm,
+	})
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to generate totp secret")
+		return "", "", err
+	}
+
+	if err := s.repo.UpdateTOTP(ctx, userID, &models.TOTP{Secret: key.Secret()}); err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to save pending totp secret")
+		return "", "", err
+	}
+	return key.Secret(), key.URL(), nil
+}
+
+// ConfirmTOTP turns two-factor authentication of the user with provided uuid on,
+// if code was generated with the secret returned by EnableTOTP, returning
+// recovery codes, which are not stored in plain form and can't be shown again.
+func (s *service) ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "service.ConfirmTOTP")
+	defer span.End()
+
+	user, err := s.repo.ReadUserByID(ctx, userID)
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to read user")
+		return nil, err
+	}
+	if user.TOTP == nil {
+		s.log(ctx).Info().Str("user", userID.String()).Msg("user didn't start two-factor authentication enrollment")
+		return nil, ErrTOTPNotPending
+	}
+	if user.TOTP.Enabled {
+		s.log(ctx).Info().Str("user", userID.String()).Msg("user already has two-factor authentication on")
+		return nil, ErrTOTPEnabled
+	}
+
+	step, ok := matchCode(user.TOTP.Secret, normalizeCode(code), time.Now())
+	if !ok {
+		s.log(ctx).Info().Str("user", userID.String()).Msg("provided one-time code is wrong")
+		return nil, ErrInvalidCode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to generate recovery codes")
+		return nil, err
+	}
+
+	if err := s.repo.UpdateTOTP(ctx, userID, &models.TOTP{
+		Secret:        user.TOTP.Secret,
+		Enabled:       true,
+		LastStep:      step,
+		RecoveryCodes: hashes,
+	}); err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to enable two-factor authentication")
+		return nil, err
+	}
+
+	s.log(ctx).Info().Str("user", userID.String()).Msg("two-factor authentication was enabled")
+	s.Audit(ctx, userID, models.AuditTOTPEnabled, nil)
+	return codes, nil
+}
+
+// DisableTOTP turns two-factor authentication of the user
+// with provided uuid off, if one-time or recovery code is valid.
+func (s *service) DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error {
+	ctx, span := tracer.Start(ctx, "service.DisableTOTP")
+	defer span.End()
+
+	user, err := s.repo.ReadUserByID(ctx, userID)
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to read user")
+		return err
+	}
+	if user.TOTP == nil || !user.TOTP.Enabled {
+		s.log(ctx).Info().Str("user", userID.String()).Msg("user doesn't have two-factor authentication on")
+		return ErrTOTPNotEnabled
+	}
+
+	if _, err := s.useCode(ctx, user, code); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateTOTP(ctx, userID, nil); err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to disable two-factor authentication")
+		return err
+	}
+
+	s.log(ctx).Info().Str("user", userID.String()).Msg("two-factor authentication was disabled")
+	s.Audit(ctx, userID, models.AuditTOTPDisabled, nil)
+	return nil
+}
+
+// VerifyTOTP completes login of the user with provided uuid, who has
+// two-factor authentication on, checking one-time or recovery code.
+// Wrong codes count as failed login attempts.
+func (s *service) VerifyTOTP(ctx context.Context, userID uuid.UUID, code string) error {
+	ctx, span := tracer.Start(ctx, "service.VerifyTOTP")
+	defer span.End()
+
+	user, err := s.repo.ReadUserByID(ctx, userID)
+	if err != nil {
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", userID.String()).
+			Msg("unable to read user")
+		return err
+	}
+
+	ip := clientIP(ctx)
+	if wait := s.limiter.acquire(user.Login, ip); wait > 0 {
+		s.log(ctx).
+			Warn().
+			Str("user", user.Login).
+			Str("ip", ip).
+			Dur("retry_after", wait).
+			Msg("rejecting one-time code of blocked login or address")
+		return &AttemptsError{RetryAfter: wait}
+	}
+
+	if user.TOTP == nil || !user.TOTP.Enabled {
+		s.log(ctx).Info().Str("user", userID.String()).Msg("user doesn't have two-factor authentication on")
+		s.limiter.release(user.Login, ip)
+		return ErrInvalidCode
+	}
+
+	method, err := s.useCode(ctx, user, code)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCode) {
+			s.Audit(ctx, userID, models.AuditLoginFailed, map[string]string{"reason": "invalid_code"})
+			s.loginFailed(ctx, user.Login, ip)
+		} else {
+			s.limiter.release(user.Login, ip)
+		}
+		return err
+	}
+
+	s.limiter.succeed(user.Login, ip)
+	s.Audit(ctx, userID, models.AuditLoginSucceeded, map[string]string{"second_factor": method})
+	return nil
+}
+
+// useCode checks one-time or recovery code of the user and marks it as used,
+// so it can't be sent again, returning which kind of code it was.
+func (s *service) useCode(ctx context.Context, user *models.User, code string) (string, error) {
+	code = normalizeCode(code)
+	if isOneTimeCode(code) {
+		step, ok := matchCode(user.TOTP.Secret, code, time.Now())
+		if !ok {
+			s.log(ctx).Info().Str("user", user.ID.String()).Msg("provided one-time code is wrong")
+			return "", ErrInvalidCode
+		}
+		if err := s.repo.UseTOTPStep(ctx, user.ID, step); err != nil {
+			if errors.Is(err, repository.ErrCodeUsed) {
+				s.log(ctx).Warn().Str("user", user.ID.String()).Msg("provided one-time code was already used")
+				return "", ErrInvalidCode
+			}
+			s.log(ctx).
+				Err(err).
+				Caller().
+				Str("user", user.ID.String()).
+				Msg("unable to mark one-time code as used")
+			return "", err
+		}
+		return "totp", nil
+	}
+
+	if err := s.repo.UseRecoveryCode(ctx, user.ID, hashRecoveryCode(code)); err != nil {
+		if errors.Is(err, repository.ErrCodeUsed) {
+			s.log(ctx).Info().Str("user", user.ID.String()).Msg("provided recovery code is wrong or was already used")
+			return "", ErrInvalidCode
+		}
+		s.log(ctx).
+			Err(err).
+			Caller().
+			Str("user", user.ID.String()).
+			Msg("unable to use recovery code")
+		return "", err
+	}
+	s.log(ctx).Info().Str("user", user.ID.String()).Msg("recovery code was used")
+	return "recovery_code", nil
+}
+
+// matchCode reports whether code was generated with the secret within allowed skew
+// of the provided moment, returning time step it was generated for.
+func matchCode(secret, code string, now time.Time) (int64, bool) {
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*totpPeriod, 0), totpOpts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// normalizeCode removes separators and spaces users may type in codes.
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// isOneTimeCode reports whether normalized code is one-time code rather than recovery one.
+func isOneTimeCode(code string) bool {
+	if len(code) != int(totpOpts.Digits) {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRecoveryCodes returns random recovery codes along with their hashes to be stored.
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodes)
+	hashes := make([]string, recoveryCodes)
+	for i := range codes {
+		b := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))
+		// grouped by four characters to be easier to copy by hand
+		codes[i] = fmt.Sprintf("%s-%s-%s-%s", code[0:4], code[4:8], code[8:12], code[12:16])
+		hashes[i] = hashRecoveryCode(code)
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode returns hex encoded sha256 hash of normalized recovery code.
+func hashRecoveryCode(code string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(code)))
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pquerna/otp/totp"
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/mocks"
+	"github.com/serjyuriev/yandex-diploma-2/internal/app/repository"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+// testSecret is TOTP secret of users in tests.
+const testSecret = "JBSWY3DPEHPK3PXP"
+
+// currentCode returns one-time code of testSecret valid right now.
+func currentCode(t *testing.T) string {
+	t.Helper()
+	code, err := totp.GenerateCodeCustom(testSecret, time.Now(), totpOpts)
+	require.NoError(t, err)
+	return code
+}
+
+// totpUser returns user with two-factor authentication on.
+func totpUser(uid uuid.UUID) *models.User {
+	return &models.User{
+		ID:    uid,
+		Login: "test",
+		TOTP: &models.TOTP{
+			Secret:        testSecret,
+			Enabled:       true,
+			RecoveryCodes: []string{hashRecoveryCode("aaaabbbbccccdddd")},
+		},
+	}
+}
+
+func TestEnableTOTP(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+	svc := &service{
+		repo:   mr,
+		logger: logger,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(&models.User{ID: uid, Login: "test"}, nil)
+		var saved *models.TOTP
+		update := mr.EXPECT().
+			UpdateTOTP(gomock.Any(), gomock.Eq(uid), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, totp *models.TOTP) error {
+				saved = totp
+				return nil
+			})
+		gomock.InOrder(read, update)
+
+		secret, uri, err := svc.EnableTOTP(context.Background(), uid)
+		require.NoError(t, err)
+		require.NotEmpty(t, secret)
+		require.True(t, strings.HasPrefix(uri, "otpauth://totp/gokeeper:test?"))
+		require.Contains(t, uri, "secret="+secret)
+		require.Equal(t, secret, saved.Secret)
+		require.False(t, saved.Enabled)
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		uid := uuid.New()
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(totpUser(uid), nil)
+		gomock.InOrder(read)
+
+		_, _, err := svc.EnableTOTP(context.Background(), uid)
+		require.ErrorIs(t, err, ErrTOTPEnabled)
+	})
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+	svc := &service{
+		repo:   mr,
+		logger: logger,
+	}
+	pending := func(uid uuid.UUID) *models.User {
+		return &models.User{ID: uid, Login: "test", TOTP: &models.TOTP{Secret: testSecret}}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		uid := uuid.New()
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(pending(uid), nil)
+		var saved *models.TOTP
+		update := mr.EXPECT().
+			UpdateTOTP(gomock.Any(), gomock.Eq(uid), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, totp *models.TOTP) error {
+				saved = totp
+				return nil
+			})
+		audit := mr.EXPECT().CreateAuditEvent(gomock.Any(), auditEvent(uid, models.AuditTOTPEnabled)).Return(nil)
+		gomock.InOrder(read, update, audit)
+
+		codes, err := svc.ConfirmTOTP(context.Background(), uid, currentCode(t))
+		require.NoError(t, err)
+		require.Len(t, codes, recoveryCodes)
+		require.True(t, saved.Enabled)
+		require.Equal(t, testSecret, saved.Secret)
+		require.InDelta(t, time.Now().Unix()/totpPeriod, saved.LastStep, 1)
+		require.Len(t, saved.RecoveryCodes, recoveryCodes)
+		require.Equal(t, hashRecoveryCode(normalizeCode(codes[0])), saved.RecoveryCodes[0])
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		uid := uuid.New()
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(pending(uid), nil)
+		gomock.InOrder(read)
+
+		_, err := svc.ConfirmTOTP(context.Background(), uid, "000000")
+		require.ErrorIs(t, err, ErrInvalidCode)
+	})
+
+	t.Run("not pending", func(t *testing.T) {
+		uid := uuid.New()
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(&models.User{ID: uid}, nil)
+		gomock.InOrder(read)
+
+		_, err := svc.ConfirmTOTP(context.Background(), uid, currentCode(t))
+		require.ErrorIs(t, err, ErrTOTPNotPending)
+	})
+}
+
+func TestDisableTOTP(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+	svc := &service{
+		repo:   mr,
+		logger: logger,
+	}
+
+	t.Run("one-time code", func(t *testing.T) {
+		uid := uuid.New()
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(totpUser(uid), nil)
+		use := mr.EXPECT().UseTOTPStep(gomock.Any(), gomock.Eq(uid), gomock.Any()).Return(nil)
+		update := mr.EXPECT().UpdateTOTP(gomock.Any(), gomock.Eq(uid), gomock.Nil()).Return(nil)
+		audit := mr.EXPECT().CreateAuditEvent(gomock.Any(), auditEvent(uid, models.AuditTOTPDisabled)).Return(nil)
+		gomock.InOrder(read, use, update, audit)
+
+		err := svc.DisableTOTP(context.Background(), uid, currentCode(t))
+		require.NoError(t, err)
+	})
+
+	t.Run("recovery code", func(t *testing.T) {
+		uid := uuid.New()
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(totpUser(uid), nil)
+		use := mr.EXPECT().
+			UseRecoveryCode(gomock.Any(), gomock.Eq(uid), gomock.Eq(hashRecoveryCode("aaaabbbbccccdddd"))).
+			Return(nil)
+		update := mr.EXPECT().UpdateTOTP(gomock.Any(), gomock.Eq(uid), gomock.Nil()).Return(nil)
+		audit := mr.EXPECT().CreateAuditEvent(gomock.Any(), auditEvent(uid, models.AuditTOTPDisabled)).Return(nil)
+		gomock.InOrder(read, use, update, audit)
+
+		err := svc.DisableTOTP(context.Background(), uid, "AAAA-BBBB-CCCC-DDDD")
+		require.NoError(t, err)
+	})
+
+	t.Run("used code", func(t *testing.T) {
+		uid := uuid.New()
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(totpUser(uid), nil)
+		use := mr.EXPECT().UseTOTPStep(gomock.Any(), gomock.Eq(uid), gomock.Any()).Return(repository.ErrCodeUsed)
+		gomock.InOrder(read, use)
+
+		err := svc.DisableTOTP(context.Background(), uid, currentCode(t))
+		require.ErrorIs(t, err, ErrInvalidCode)
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		uid := uuid.New()
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(&models.User{ID: uid}, nil)
+		gomock.InOrder(read)
+
+		err := svc.DisableTOTP(context.Background(), uid, currentCode(t))
+		require.ErrorIs(t, err, ErrTOTPNotEnabled)
+	})
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	output := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "02-01-2006 15:04:05 MST",
+	}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+	ctrl := gomock.NewController(t)
+	mr := mocks.NewMockRepository(ctrl)
+
+	t.Run("success", func(t *testing.T) {
+		limiter, _ := testLimiter(t)
+		svc := &service{
+			repo:    mr,
+			logger:  logger,
+			limiter: limiter,
+		}
+		uid := uuid.New()
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(totpUser(uid), nil)
+		use := mr.EXPECT().UseTOTPStep(gomock.Any(), gomock.Eq(uid), gomock.Any()).Return(nil)
+		audit := mr.EXPECT().CreateAuditEvent(gomock.Any(), auditEvent(uid, models.AuditLoginSucceeded)).Return(nil)
+		gomock.InOrder(read, use, audit)
+
+		err := svc.VerifyTOTP(context.Background(), uid, currentCode(t))
+		require.NoError(t, err)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		limiter, _ := testLimiter(t)
+		svc := &service{
+			repo:    mr,
+			logger:  logger,
+			limiter: limiter,
+		}
+		uid := uuid.New()
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(totpUser(uid), nil)
+		use := mr.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Eq(uid), gomock.Any()).Return(repository.ErrCodeUsed)
+		audit := mr.EXPECT().CreateAuditEvent(gomock.Any(), auditEvent(uid, models.AuditLoginFailed)).Return(nil)
+		gomock.InOrder(read, use, audit)
+
+		err := svc.VerifyTOTP(context.Background(), uid, "guess")
+		require.ErrorIs(t, err, ErrInvalidCode)
+		require.Equal(t, time.Second, limiter.acquire("test", ""))
+
+		// next code is rejected until the delay passes
+		read = mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(totpUser(uid), nil)
+		gomock.InOrder(read)
+		err = svc.VerifyTOTP(context.Background(), uid, currentCode(t))
+		require.ErrorIs(t, err, ErrTooManyAttempts)
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		svc := &service{
+			repo:   mr,
+			logger: logger,
+		}
+		uid := uuid.New()
+		read := mr.EXPECT().ReadUserByID(gomock.Any(), gomock.Eq(uid)).Return(&models.User{ID: uid}, nil)
+		gomock.InOrder(read)
+
+		err := svc.VerifyTOTP(context.Background(), uid, currentCode(t))
+		require.ErrorIs(t, err, ErrInvalidCode)
+	})
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := newRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodes)
+	require.Len(t, hashes, recoveryCodes)
+
+	seen := make(map[string]struct{})
+	for i, code := range codes {
+		require.Regexp(t, `^[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}$`, code)
+		require.False(t, isOneTimeCode(normalizeCode(code)))
+		require.Equal(t, hashes[i], hashRecoveryCode(normalizeCode(strings.ToUpper(code))))
+		seen[code] = struct{}{}
+	}
+	require.Len(t, seen, recoveryCodes)
+}
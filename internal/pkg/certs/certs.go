@@ -0,0 +1,191 @@
+// Package certs provides loading of TLS certificates
+// and their reloading without restarting the server.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultReloadInterval is how often certificate files are checked
+// for changes when reload interval isn't configured.
+const DefaultReloadInterval = time.Minute
+
+var (
+	// ErrNoCertificates is raised when CA file holds no PEM certificates.
+	ErrNoCertificates = errors.New("no certificates found in CA file")
+	// ErrNoClientCA is raised when client certificates are required,
+	// but there are no CAs to verify them with.
+	ErrNoClientCA = errors.New("client CA file is required to verify client certificates")
+)
+
+// LoadCertPool reads PEM certificates of CAs from file.
+func LoadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, ErrNoCertificates
+	}
+	return pool, nil
+}
+
+// Reloader holds server certificate and CAs of client certificates,
+// rereading them once their files are changed, so certificates
+// can be renewed while server is running.
+type Reloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+	interval time.Duration
+	logger   zerolog.Logger
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+// NewReloader loads server certificate and, if caFile is set, CAs of client
+// certificates. Files are checked for changes at most once per interval.
+func NewReloader(certFile, keyFile, caFile string, interval time.Duration, logger zerolog.Logger) (*Reloader, error) {
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+	r := &Reloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+		interval: interval,
+		logger:   logger,
+	}
+
+	modTime, err := r.latestModTime()
+	if err != nil {
+		logger.
+			Err(err).
+			Caller().
+			Msg("unable to stat certificate files")
+		return nil, err
+	}
+	if err := r.load(modTime); err != nil {
+		return nil, err
+	}
+	r.checkedAt = time.Now()
+	return r, nil
+}
+
+// ServerConfig returns TLS configuration, which serves the latest
+// loaded certificate on every handshake. With requireClientCert
+// clients must present certificate signed by one of client CAs,
+// otherwise presented certificates are verified but not required.
+func (r *Reloader) ServerConfig(requireClientCert bool) (*tls.Config, error) {
+	if requireClientCert && r.caFile == "" {
+		r.logger.Err(ErrNoClientCA).Msg("mutual TLS can't be enabled")
+		return nil, ErrNoClientCA
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, clientCAs := r.current()
+			cfg := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+				NextProtos:   []string{"h2"},
+			}
+			switch {
+			case requireClientCert:
+				cfg.ClientCAs = clientCAs
+				cfg.ClientAuth = tls.RequireAndVerifyClientCert
+			case clientCAs != nil:
+				cfg.ClientCAs = clientCAs
+				cfg.ClientAuth = tls.VerifyClientCertIfGiven
+			}
+			return cfg, nil
+		},
+	}, nil
+}
+
+// current returns loaded certificate and client CAs,
+// reloading them first if their files were changed.
+func (r *Reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checkedAt) >= r.interval {
+		r.checkedAt = time.Now()
+		modTime, err := r.latestModTime()
+		if err != nil {
+			r.logger.
+				Err(err).
+				Caller().
+				Msg("unable to stat certificate files, keeping loaded certificate")
+		} else if modTime.After(r.modTime) {
+			r.logger.Info().Msg("certificate files were changed, reloading")
+			if err := r.load(modTime); err != nil {
+				r.logger.Warn().Msg("keeping previously loaded certificate")
+			}
+		}
+	}
+	return r.cert, r.clientCAs
+}
+
+// load reads certificate files, replacing loaded certificate
+// and client CAs only if all of them were read successfully.
+func (r *Reloader) load(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		r.logger.
+			Err(err).
+			Caller().
+			Str("cert", r.certFile).
+			Msg("unable to load certificate")
+		return err
+	}
+
+	var clientCAs *x509.CertPool
+	if r.caFile != "" {
+		clientCAs, err = LoadCertPool(r.caFile)
+		if err != nil {
+			r.logger.
+				Err(err).
+				Caller().
+				Str("ca", r.caFile).
+				Msg("unable to load client CAs")
+			return err
+		}
+	}
+
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTime = modTime
+	return nil
+}
+
+// latestModTime returns modification time of the most recently changed certificate file.
+func (r *Reloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{r.certFile, r.keyFile, r.caFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
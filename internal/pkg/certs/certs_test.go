@@ -0,0 +1,184 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// testCA issues certificates for tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns PEM certificate and key with provided serial number.
+func (ca *testCA) issue(t *testing.T, serial int64, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeFile writes file and sets its modification time.
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// served returns serial number of the certificate served by config.
+func served(t *testing.T, cfg *tls.Config) int64 {
+	clientCfg, err := cfg.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(clientCfg.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+	return cert.SerialNumber.Int64()
+}
+
+func TestReloader(t *testing.T) {
+	logger := zerolog.Nop()
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	past := time.Now().Add(-time.Minute)
+
+	certPEM, keyPEM := ca.issue(t, 2, x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, certPEM, past)
+	writeFile(t, keyFile, keyPEM, past)
+	writeFile(t, caFile, ca.pem, past)
+
+	t.Run("reload", func(t *testing.T) {
+		r, err := NewReloader(certFile, keyFile, "", time.Nanosecond, logger)
+		require.NoError(t, err)
+		cfg, err := r.ServerConfig(false)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), served(t, cfg))
+
+		certPEM, keyPEM := ca.issue(t, 3, x509.ExtKeyUsageServerAuth)
+		writeFile(t, certFile, certPEM, time.Now())
+		writeFile(t, keyFile, keyPEM, time.Now())
+		require.Equal(t, int64(3), served(t, cfg))
+	})
+
+	t.Run("broken files", func(t *testing.T) {
+		r, err := NewReloader(certFile, keyFile, "", time.Nanosecond, logger)
+		require.NoError(t, err)
+		cfg, err := r.ServerConfig(false)
+		require.NoError(t, err)
+		serial := served(t, cfg)
+
+		keyPEM, err := os.ReadFile(keyFile)
+		require.NoError(t, err)
+		writeFile(t, keyFile, []byte("not a key"), time.Now().Add(time.Minute))
+		require.Equal(t, serial, served(t, cfg))
+		writeFile(t, keyFile, keyPEM, time.Now().Add(time.Minute))
+	})
+
+	t.Run("no client ca", func(t *testing.T) {
+		r, err := NewReloader(certFile, keyFile, "", 0, logger)
+		require.NoError(t, err)
+		_, err = r.ServerConfig(true)
+		require.ErrorIs(t, err, ErrNoClientCA)
+	})
+
+	t.Run("missing files", func(t *testing.T) {
+		_, err := NewReloader(filepath.Join(dir, "missing.crt"), keyFile, "", 0, logger)
+		require.Error(t, err)
+	})
+
+	t.Run("mutual tls", func(t *testing.T) {
+		r, err := NewReloader(certFile, keyFile, caFile, 0, logger)
+		require.NoError(t, err)
+		cfg, err := r.ServerConfig(true)
+		require.NoError(t, err)
+		roots, err := LoadCertPool(caFile)
+		require.NoError(t, err)
+
+		handshake := func(certs []tls.Certificate) error {
+			clientConn, serverConn := net.Pipe()
+			defer clientConn.Close()
+			defer serverConn.Close()
+
+			serverErr := make(chan error, 1)
+			go func() {
+				serverErr <- tls.Server(serverConn, cfg).Handshake()
+			}()
+			client := tls.Client(clientConn, &tls.Config{
+				RootCAs:      roots,
+				ServerName:   "localhost",
+				Certificates: certs,
+			})
+			if err := client.Handshake(); err != nil {
+				return err
+			}
+			// TLS 1.3 client finishes before server verifies its certificate,
+			// so server's verdict is awaited, draining alert it may send
+			go io.Copy(io.Discard, clientConn)
+			return <-serverErr
+		}
+
+		clientPEM, clientKeyPEM := ca.issue(t, 4, x509.ExtKeyUsageClientAuth)
+		clientCert, err := tls.X509KeyPair(clientPEM, clientKeyPEM)
+		require.NoError(t, err)
+		require.NoError(t, handshake([]tls.Certificate{clientCert}))
+		require.Error(t, handshake(nil))
+	})
+}
+
+func TestLoadCertPool(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(file, []byte("not a certificate"), 0600))
+
+	_, err := LoadCertPool(file)
+	require.ErrorIs(t, err, ErrNoCertificates)
+}
@@ -1,15 +1,10 @@
 // Package config provides a server and client configuration
-// structures and methods for it initialization.
+// structures and methods for it initialization. Configuration is
+// layered: defaults are overridden by yaml file, which is overridden
+// by environment variables, which are overridden by command line flags.
 package config
 
-import (
-	"flag"
-	"os"
-	"sync"
-
-	"github.com/rs/zerolog/log"
-	"gopkg.in/yaml.v3"
-)
+import "time"
 
 // ServerConfig holds server application's configuration.
 type ServerConfig struct {
@@ -21,8 +16,94 @@ type ServerConfig struct {
 		Address string `yaml:"address"`
 		Port    int    `yaml:"port"`
 	} `yaml:"listen"`
-	Salt    string `yaml:"salt"`
-	IsDebug bool   `yaml:"is_debug"`
+	// TLS secures connections with clients. Without certificate
+	// server accepts plaintext connections, which is only fit for development.
+	TLS struct {
+		CertFile string `yaml:"cert_file"`
+		KeyFile  string `yaml:"key_file"`
+		// ClientCAFile holds CAs client certificates are verified with.
+		ClientCAFile string `yaml:"client_ca_file"`
+		// RequireClientCert rejects clients without valid certificate (mutual TLS).
+		RequireClientCert bool `yaml:"require_client_cert"`
+		// ReloadInterval is how often certificate files are checked for changes.
+		ReloadInterval time.Duration `yaml:"reload_interval"`
+	} `yaml:"tls"`
+	Shutdown struct {
+		// DrainTimeout is how long in-flight requests are awaited
+		// on shutdown before remaining connections are closed.
+		DrainTimeout time.Duration `yaml:"drain_timeout"`
+	} `yaml:"shutdown"`
+	Health struct {
+		// PingInterval is how often database is pinged to report readiness.
+		PingInterval time.Duration `yaml:"ping_interval"`
+		PingTimeout  time.Duration `yaml:"ping_timeout"`
+		// ProbeCertFile and ProbeKeyFile hold client certificate healthcheck
+		// command presents to server requiring one. Server certificate is
+		// presented instead when they are unset and it allows client auth.
+		ProbeCertFile string `yaml:"probe_cert_file"`
+		ProbeKeyFile  string `yaml:"probe_key_file"`
+		// ProbeCAFile holds CAs healthcheck command verifies server with.
+		// When it is unset, server must present its configured certificate.
+		ProbeCAFile string `yaml:"probe_ca_file"`
+	} `yaml:"health"`
+	Metrics struct {
+		// Address is where /metrics HTTP endpoint listens, empty disables it.
+		Address string `yaml:"address"`
+		// StatsInterval is how often users and items are counted.
+		StatsInterval time.Duration `yaml:"stats_interval"`
+	} `yaml:"metrics"`
+	Auth struct {
+		Secret          string        `yaml:"secret"`
+		AccessTokenTTL  time.Duration `yaml:"access_token_ttl"`
+		RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl"`
+	} `yaml:"auth"`
+	// Lockout protects logins from brute force. Every failed attempt
+	// delays the next one of the same login and IP address exponentially,
+	// and too many failures lock them out. Zero values fall back to defaults.
+	Lockout struct {
+		// MaxFailures is the number of failures login is locked out after.
+		MaxFailures int `yaml:"max_failures"`
+		// MaxIPFailures is the number of failures IP address is locked out after.
+		MaxIPFailures int `yaml:"max_ip_failures"`
+		// Duration is how long locked out login or IP address stays locked.
+		Duration time.Duration `yaml:"duration"`
+		// BaseDelay is the delay after the first failure, doubled on every next one.
+		BaseDelay time.Duration `yaml:"base_delay"`
+		// MaxDelay caps the delay between attempts.
+		MaxDelay time.Duration `yaml:"max_delay"`
+		// Window is how long failures are remembered after the last one.
+		Window time.Duration `yaml:"window"`
+	} `yaml:"lockout"`
+	Hashing struct {
+		Time       uint32 `yaml:"time"`
+		Memory     uint32 `yaml:"memory"`
+		Threads    uint8  `yaml:"threads"`
+		KeyLength  uint32 `yaml:"key_length"`
+		SaltLength uint32 `yaml:"salt_length"`
+	} `yaml:"hashing"`
+	Blobs struct {
+		// Store is the storage of binary items' content:
+		// "gridfs" (default) or "fs" for single-node deployments.
+		Store string `yaml:"store"`
+		// Dir is the directory "fs" store keeps content in.
+		Dir string `yaml:"dir"`
+		// Expiry is how long uploaded blob is kept
+		// before it is completed and attached to an item.
+		Expiry time.Duration `yaml:"expiry"`
+	} `yaml:"blobs"`
+	// Quotas limit storage available to every user. Zero means no limit.
+	Quotas struct {
+		TotalBytes  int64 `yaml:"total_bytes"`
+		MaxItems    int64 `yaml:"max_items"`
+		MaxItemSize int64 `yaml:"max_item_size"`
+	} `yaml:"quotas"`
+	Log     Log     `yaml:"log"`
+	Tracing Tracing `yaml:"tracing"`
+	Salt    string  `yaml:"salt"`
+	// ZeroKnowledge disables deprecated RPCs which accept
+	// items with plaintext fields.
+	ZeroKnowledge bool `yaml:"zero_knowledge"`
+	IsDebug       bool `yaml:"is_debug"`
 }
 
 // ClientConfig holds client application's configuration.
@@ -31,56 +112,49 @@ type ClientConfig struct {
 		Address string `yaml:"address"`
 		Port    int    `yaml:"port"`
 	} `yaml:"server"`
-	IsDebug bool   `yaml:"is_debug"`
-	Key     string `yaml:"key"`
+	// TLS configures connection with server. Server certificate is verified
+	// with system CAs unless CAFile is set. Client certificate is only
+	// needed when server requires mutual TLS.
+	TLS struct {
+		CAFile     string `yaml:"ca_file"`
+		CertFile   string `yaml:"cert_file"`
+		KeyFile    string `yaml:"key_file"`
+		ServerName string `yaml:"server_name"`
+		// Insecure connects without TLS, which is only fit for development.
+		Insecure bool `yaml:"insecure"`
+	} `yaml:"tls"`
+	Session struct {
+		Device string `yaml:"device"`
+		Dir    string `yaml:"dir"`
+	} `yaml:"session"`
+	Tracing Tracing `yaml:"tracing"`
+	IsDebug bool    `yaml:"is_debug"`
+	// Key is the static key items were encrypted with before
+	// vault keys were introduced, it is only used to read such items.
+	Key string `yaml:"key"`
 }
 
-var (
-	once      sync.Once
-	clientCfg *ClientConfig
-	serverCfg *ServerConfig
-)
-
-// GetServerConfig once parses yaml configuration file
-// and then always returns copy of filled server configuration object.
-func GetServerConfig() ServerConfig {
-	once.Do(func() {
-		var configPath string
-		flag.StringVar(&configPath, "c", "", "yaml config file")
-		flag.Parse()
-
-		serverCfg = &ServerConfig{}
-		file, err := os.ReadFile(configPath)
-		if err != nil {
-			log.Fatal().Err(err).Msg("unable to open config file")
-		}
-
-		if err = yaml.Unmarshal(file, serverCfg); err != nil {
-			log.Fatal().Err(err).Msg("unable to unmarshal yaml in config file")
-		}
-	})
-
-	return *serverCfg
+// Log configures log output.
+type Log struct {
+	// Format is "console" (default) or "json".
+	Format string `yaml:"format"`
+	// Level is the least level of logged events, "debug"
+	// when is_debug is set and "info" otherwise by default.
+	Level string `yaml:"level"`
+	// File is the path log is appended to instead of stdout.
+	File string `yaml:"file"`
 }
 
-// GetClientConfig once parses yaml configuration file
-// and then always returns copy of filled client configuration object.
-func GetClientConfig() ClientConfig {
-	once.Do(func() {
-		var configPath string
-		flag.StringVar(&configPath, "c", "A:\\dev\\yandex\\yandex-diploma-2\\dev_clt_config.yaml", "yaml config file")
-		flag.Parse()
-
-		clientCfg = &ClientConfig{}
-		file, err := os.ReadFile(configPath)
-		if err != nil {
-			log.Fatal().Err(err).Msg("unable to open config file")
-		}
-
-		if err = yaml.Unmarshal(file, clientCfg); err != nil {
-			log.Fatal().Err(err).Msg("unable to unmarshal yaml in config file")
-		}
-	})
-
-	return *clientCfg
+// Tracing configures export of OpenTelemetry traces.
+type Tracing struct {
+	// Exporter is "otlp", "stdout" or "file", empty disables export.
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the address of OTLP gRPC collector.
+	Endpoint string `yaml:"endpoint"`
+	// Insecure connects to collector without TLS.
+	Insecure bool `yaml:"insecure"`
+	// File is the path "file" exporter writes spans to.
+	File string `yaml:"file"`
+	// SampleRatio is the share of traces recorded, zero records all of them.
+	SampleRatio float64 `yaml:"sample_ratio"`
 }
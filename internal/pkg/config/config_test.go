@@ -0,0 +1,193 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// env returns LookupEnv reading from provided variables.
+func env(vars map[string]string) LookupEnv {
+	return func(key string) (string, bool) {
+		value, ok := vars[key]
+		return value, ok
+	}
+}
+
+// flagSet returns flag set, which reports errors instead of exiting.
+func flagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// writeConfig writes yaml file to the test's directory, returning its path.
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+// validServer is yaml of server configuration, which passes validation.
+const validServer = `
+database:
+  address: mongodb://mongo:27017
+listen:
+  port: 9000
+auth:
+  secret: file-signing-secret
+salt: filesalt
+`
+
+func TestLoadServerConfig(t *testing.T) {
+	t.Run("layers", func(t *testing.T) {
+		path := writeConfig(t, validServer)
+		cfg, err := LoadServerConfig(
+			flagSet(),
+			[]string{"-c", path, "-port", "9100", "-debug"},
+			env(map[string]string{
+				"GOKEEPER_DB_ADDRESS":  "mongodb://env:27017",
+				"GOKEEPER_LISTEN_PORT": "9050",
+				"GOKEEPER_SALT":        "envsalt",
+			}),
+		)
+		require.NoError(t, err)
+		// default
+		require.Equal(t, "gokeeper", cfg.Database.Name)
+		// file
+		require.Equal(t, "file-signing-secret", cfg.Auth.Secret)
+		// environment over file
+		require.Equal(t, "mongodb://env:27017", cfg.Database.Address)
+		require.Equal(t, "envsalt", cfg.Salt)
+		// flags over environment
+		require.Equal(t, 9100, cfg.Listen.Port)
+		require.True(t, cfg.IsDebug)
+	})
+
+	t.Run("path from environment", func(t *testing.T) {
+		path := writeConfig(t, validServer)
+		cfg, err := LoadServerConfig(flagSet(), nil, env(map[string]string{"GOKEEPER_CONFIG": path}))
+		require.NoError(t, err)
+		require.Equal(t, 9000, cfg.Listen.Port)
+	})
+
+	t.Run("without file", func(t *testing.T) {
+		cfg, err := LoadServerConfig(flagSet(), nil, env(map[string]string{
+			"GOKEEPER_AUTH_SECRET": "env-signing-secret",
+			"GOKEEPER_SALT":        "envsalt",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, "mongodb://localhost:27017", cfg.Database.Address)
+		require.Equal(t, 8080, cfg.Listen.Port)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadServerConfig(flagSet(), []string{"-c", filepath.Join(t.TempDir(), "missing.yaml")}, env(nil))
+		require.Error(t, err)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		path := writeConfig(t, validServer+"sallt: typo\n")
+		_, err := LoadServerConfig(flagSet(), []string{"-c", path}, env(nil))
+		require.ErrorContains(t, err, "sallt")
+	})
+
+	t.Run("malformed environment", func(t *testing.T) {
+		path := writeConfig(t, validServer)
+		_, err := LoadServerConfig(flagSet(), []string{"-c", path}, env(map[string]string{"GOKEEPER_LISTEN_PORT": "http"}))
+		require.ErrorContains(t, err, "GOKEEPER_LISTEN_PORT")
+	})
+
+	t.Run("malformed flag", func(t *testing.T) {
+		path := writeConfig(t, validServer)
+		_, err := LoadServerConfig(flagSet(), []string{"-c", path, "-port", "http"}, env(nil))
+		require.ErrorContains(t, err, "-port")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := LoadServerConfig(flagSet(), nil, env(nil))
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
+
+func TestServerConfigValidate(t *testing.T) {
+	valid := DefaultServerConfig()
+	valid.Auth.Secret = "some-signing-secret"
+	valid.Salt = "somesalt"
+	require.NoError(t, valid.Validate())
+
+	tt := []struct {
+		name    string
+		change  func(cfg *ServerConfig)
+		problem string
+	}{
+		{"missing salt", func(cfg *ServerConfig) { cfg.Salt = "" }, "salt is required"},
+		{"missing secret", func(cfg *ServerConfig) { cfg.Auth.Secret = "" }, "auth.secret is required"},
+		{"short secret", func(cfg *ServerConfig) { cfg.Auth.Secret = "short" }, "auth.secret must be at least 16 bytes long, got 5"},
+		{"bad port", func(cfg *ServerConfig) { cfg.Listen.Port = 70000 }, "listen.port must be between 1 and 65535, got 70000"},
+		{"short key", func(cfg *ServerConfig) { cfg.Hashing.KeyLength = 8 }, "hashing.key_length must be at least 16 bytes, got 8"},
+		{"cert without key", func(cfg *ServerConfig) { cfg.TLS.CertFile = "cert.pem" }, "tls.cert_file and tls.key_file must be set together"},
+		{"probe cert without key", func(cfg *ServerConfig) {
+			cfg.TLS.CertFile, cfg.TLS.KeyFile = "cert.pem", "key.pem"
+			cfg.Health.ProbeCertFile = "probe.pem"
+		}, "health.probe_cert_file and health.probe_key_file must be set together"},
+		{"probe CA without TLS", func(cfg *ServerConfig) { cfg.Health.ProbeCAFile = "ca.pem" }, "health probe certificates require tls.cert_file"},
+		{"fs store without dir", func(cfg *ServerConfig) { cfg.Blobs.Store = "fs" }, "blobs.dir is required"},
+		{"negative blob expiry", func(cfg *ServerConfig) { cfg.Blobs.Expiry = -time.Hour }, "blobs.expiry can't be negative, got -1h0m0s"},
+		{"unknown log level", func(cfg *ServerConfig) { cfg.Log.Level = "loud" }, `log.level "loud" is not a log level`},
+		{"unknown exporter", func(cfg *ServerConfig) { cfg.Tracing.Exporter = "zipkin" }, `tracing.exporter must be one of ["otlp" "stdout" "file"], got "zipkin"`},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := valid
+			tc.change(&cfg)
+			err := cfg.Validate()
+			require.ErrorIs(t, err, ErrInvalidConfig)
+			var invalid *ValidationError
+			require.ErrorAs(t, err, &invalid)
+			require.Equal(t, []string{tc.problem}, invalid.Problems)
+		})
+	}
+
+	t.Run("all problems", func(t *testing.T) {
+		err := ServerConfig{}.Validate()
+		var invalid *ValidationError
+		require.ErrorAs(t, err, &invalid)
+		require.Len(t, invalid.Problems, 5)
+	})
+}
+
+func TestLoadClientConfig(t *testing.T) {
+	// user's own config must not affect the test
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	t.Run("missing default file", func(t *testing.T) {
+		cfg, err := LoadClientConfig(flagSet(), []string{"-server", "keeper.example.com"}, env(nil))
+		require.NoError(t, err)
+		require.Equal(t, "keeper.example.com", cfg.Server.Address)
+		require.Equal(t, 8080, cfg.Server.Port)
+	})
+
+	t.Run("file and environment", func(t *testing.T) {
+		path := writeConfig(t, "server:\n  address: file.example.com\n  port: 9000\nkey: 1q2w3e4r5t6y7u8i\n")
+		cfg, err := LoadClientConfig(flagSet(), nil, env(map[string]string{
+			"GOKEEPER_CLIENT_CONFIG": path,
+			"GOKEEPER_SERVER_PORT":   "9100",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, "file.example.com", cfg.Server.Address)
+		require.Equal(t, 9100, cfg.Server.Port)
+	})
+
+	t.Run("bad key length", func(t *testing.T) {
+		_, err := LoadClientConfig(flagSet(), nil, env(map[string]string{"GOKEEPER_LEGACY_KEY": "short"}))
+		require.ErrorContains(t, err, "key must be 16, 24 or 32 bytes long, got 5")
+	})
+}
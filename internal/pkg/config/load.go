@@ -0,0 +1,285 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// envServerConfig holds path of the server's yaml file, unless -c flag is set.
+	envServerConfig = "GOKEEPER_CONFIG"
+	// envClientConfig holds path of the client's yaml file, unless -c flag is set.
+	envClientConfig = "GOKEEPER_CLIENT_CONFIG"
+)
+
+// LookupEnv returns value of the environment variable and whether it is set.
+// Apps use os.LookupEnv, tests provide their own environment.
+type LookupEnv func(key string) (string, bool)
+
+// setting is configuration value, which can be set with
+// environment variable and, if flag is not empty, command line flag.
+type setting[T any] struct {
+	env   string
+	flag  string
+	usage string
+	bool  bool
+	set   func(cfg *T, value string) error
+}
+
+// serverSettings are server's configuration values,
+// which can be overridden without changing yaml file.
+// Secrets are only read from environment, so they don't show up in process list.
+var serverSettings = []setting[ServerConfig]{
+	{env: "GOKEEPER_DB_ADDRESS", flag: "db-address", usage: "MongoDB connection string",
+		set: stringField(func(c *ServerConfig) *string { return &c.Database.Address })},
+	{env: "GOKEEPER_DB_NAME", flag: "db-name", usage: "database name",
+		set: stringField(func(c *ServerConfig) *string { return &c.Database.Name })},
+	{env: "GOKEEPER_LISTEN_ADDRESS", flag: "listen-address", usage: "address server listens on",
+		set: stringField(func(c *ServerConfig) *string { return &c.Listen.Address })},
+	{env: "GOKEEPER_LISTEN_PORT", flag: "port", usage: "port server listens on",
+		set: intField(func(c *ServerConfig) *int { return &c.Listen.Port })},
+	{env: "GOKEEPER_TLS_CERT_FILE", flag: "tls-cert", usage: "server certificate file",
+		set: stringField(func(c *ServerConfig) *string { return &c.TLS.CertFile })},
+	{env: "GOKEEPER_TLS_KEY_FILE", flag: "tls-key", usage: "server private key file",
+		set: stringField(func(c *ServerConfig) *string { return &c.TLS.KeyFile })},
+	{env: "GOKEEPER_TLS_CLIENT_CA_FILE", flag: "tls-client-ca", usage: "file with CAs client certificates are verified with",
+		set: stringField(func(c *ServerConfig) *string { return &c.TLS.ClientCAFile })},
+	{env: "GOKEEPER_METRICS_ADDRESS", flag: "metrics-address", usage: "address of /metrics endpoint, empty disables it",
+		set: stringField(func(c *ServerConfig) *string { return &c.Metrics.Address })},
+	{env: "GOKEEPER_AUTH_SECRET",
+		set: stringField(func(c *ServerConfig) *string { return &c.Auth.Secret })},
+	{env: "GOKEEPER_SALT",
+		set: stringField(func(c *ServerConfig) *string { return &c.Salt })},
+	{env: "GOKEEPER_BLOBS_STORE", flag: "blobs-store", usage: `storage of binary items: "gridfs" or "fs"`,
+		set: stringField(func(c *ServerConfig) *string { return &c.Blobs.Store })},
+	{env: "GOKEEPER_BLOBS_DIR", flag: "blobs-dir", usage: `directory "fs" store keeps binary items in`,
+		set: stringField(func(c *ServerConfig) *string { return &c.Blobs.Dir })},
+	{env: "GOKEEPER_LOG_FORMAT", flag: "log-format", usage: `log format: "console" or "json"`,
+		set: stringField(func(c *ServerConfig) *string { return &c.Log.Format })},
+	{env: "GOKEEPER_LOG_LEVEL", flag: "log-level", usage: "least level of logged events",
+		set: stringField(func(c *ServerConfig) *string { return &c.Log.Level })},
+	{env: "GOKEEPER_LOG_FILE", flag: "log-file", usage: "file log is appended to instead of stdout",
+		set: stringField(func(c *ServerConfig) *string { return &c.Log.File })},
+	{env: "GOKEEPER_TRACING_EXPORTER", flag: "tracing-exporter", usage: `trace exporter: "otlp", "stdout" or "file"`,
+		set: stringField(func(c *ServerConfig) *string { return &c.Tracing.Exporter })},
+	{env: "GOKEEPER_TRACING_ENDPOINT", flag: "tracing-endpoint", usage: "address of OTLP collector",
+		set: stringField(func(c *ServerConfig) *string { return &c.Tracing.Endpoint })},
+	{env: "GOKEEPER_ZERO_KNOWLEDGE", flag: "zero-knowledge", usage: "disable RPCs accepting plaintext items", bool: true,
+		set: boolField(func(c *ServerConfig) *bool { return &c.ZeroKnowledge })},
+	{env: "GOKEEPER_DEBUG", flag: "debug", usage: "enable debug mode", bool: true,
+		set: boolField(func(c *ServerConfig) *bool { return &c.IsDebug })},
+}
+
+// clientSettings are client's configuration values,
+// which can be overridden without changing yaml file.
+var clientSettings = []setting[ClientConfig]{
+	{env: "GOKEEPER_SERVER_ADDRESS", flag: "server", usage: "address of go-keeper server",
+		set: stringField(func(c *ClientConfig) *string { return &c.Server.Address })},
+	{env: "GOKEEPER_SERVER_PORT", flag: "port", usage: "port of go-keeper server",
+		set: intField(func(c *ClientConfig) *int { return &c.Server.Port })},
+	{env: "GOKEEPER_TLS_CA_FILE", flag: "tls-ca", usage: "file with CAs server certificate is verified with",
+		set: stringField(func(c *ClientConfig) *string { return &c.TLS.CAFile })},
+	{env: "GOKEEPER_TLS_INSECURE", flag: "insecure", usage: "connect to server without TLS", bool: true,
+		set: boolField(func(c *ClientConfig) *bool { return &c.TLS.Insecure })},
+	{env: "GOKEEPER_SESSION_DIR", flag: "session-dir", usage: "directory sessions and vault copies are saved in",
+		set: stringField(func(c *ClientConfig) *string { return &c.Session.Dir })},
+	{env: "GOKEEPER_DEVICE", flag: "device", usage: "label of this device in sessions list",
+		set: stringField(func(c *ClientConfig) *string { return &c.Session.Device })},
+	{env: "GOKEEPER_LEGACY_KEY",
+		set: stringField(func(c *ClientConfig) *string { return &c.Key })},
+	{env: "GOKEEPER_DEBUG", flag: "debug", usage: "enable debug mode", bool: true,
+		set: boolField(func(c *ClientConfig) *bool { return &c.IsDebug })},
+}
+
+// DefaultServerConfig returns server configuration used when nothing else
+// is configured. Durations and limits left zero fall back to defaults
+// of the packages using them.
+func DefaultServerConfig() ServerConfig {
+	cfg := ServerConfig{}
+	cfg.Database.Address = "mongodb://localhost:27017"
+	cfg.Database.Name = "gokeeper"
+	cfg.Listen.Port = 8080
+	cfg.Blobs.Store = "gridfs"
+	cfg.Log.Format = "console"
+	return cfg
+}
+
+// DefaultClientConfig returns client configuration used when nothing else is configured.
+func DefaultClientConfig() ClientConfig {
+	cfg := ClientConfig{}
+	cfg.Server.Address = "localhost"
+	cfg.Server.Port = 8080
+	if dir, err := os.UserConfigDir(); err == nil {
+		cfg.Session.Dir = filepath.Join(dir, "gokeeper")
+	}
+	return cfg
+}
+
+// DefaultClientConfigPath returns path client's yaml file is read from
+// when neither -c flag nor GOKEEPER_CLIENT_CONFIG is set.
+func DefaultClientConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gokeeper", "client.yaml")
+}
+
+// LoadServerConfig registers configuration flags in fs, parses args with it
+// and returns validated server configuration. Yaml file is read from path
+// set with -c flag or GOKEEPER_CONFIG, and is optional.
+func LoadServerConfig(fs *flag.FlagSet, args []string, lookupEnv LookupEnv) (ServerConfig, error) {
+	cfg := DefaultServerConfig()
+	if err := load(&cfg, fs, args, lookupEnv, serverSettings, envServerConfig, ""); err != nil {
+		return ServerConfig{}, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// LoadClientConfig registers configuration flags in fs, parses args with it
+// and returns validated client configuration. Yaml file is read from path
+// set with -c flag or GOKEEPER_CLIENT_CONFIG, falling back to the file
+// in user's configuration directory, which may be missing.
+func LoadClientConfig(fs *flag.FlagSet, args []string, lookupEnv LookupEnv) (ClientConfig, error) {
+	cfg := DefaultClientConfig()
+	if err := load(&cfg, fs, args, lookupEnv, clientSettings, envClientConfig, DefaultClientConfigPath()); err != nil {
+		return ClientConfig{}, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// load parses args and fills cfg from yaml file, environment
+// and flags in that order, so the later ones take precedence.
+// Missing file is only an error when its path was set explicitly.
+func load[T any](cfg *T, fs *flag.FlagSet, args []string, lookupEnv LookupEnv, settings []setting[T], pathEnv string, path string) error {
+	explicit := false
+	if value, ok := lookupEnv(pathEnv); ok && value != "" {
+		path, explicit = value, true
+	}
+	fs.Func("c", "yaml config file", func(value string) error {
+		path, explicit = value, true
+		return nil
+	})
+
+	// flags are applied once file and environment are read
+	overrides := make([]func() error, 0)
+	for _, s := range settings {
+		if s.flag == "" {
+			continue
+		}
+		s := s
+		fs.Var(&settingValue{bool: s.bool, set: func(value string) error {
+			overrides = append(overrides, func() error {
+				if err := s.set(cfg, value); err != nil {
+					return fmt.Errorf("flag -%s: %w", s.flag, err)
+				}
+				return nil
+			})
+			return nil
+		}}, s.flag, s.usage)
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if path != "" {
+		if err := readFile(cfg, path, explicit); err != nil {
+			return err
+		}
+	}
+	for _, s := range settings {
+		value, ok := lookupEnv(s.env)
+		if !ok {
+			continue
+		}
+		if err := s.set(cfg, value); err != nil {
+			return fmt.Errorf("environment variable %s: %w", s.env, err)
+		}
+	}
+	for _, apply := range overrides {
+		if err := apply(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readFile fills cfg from yaml file. Unknown keys are rejected,
+// so misspelled options don't go unnoticed.
+func readFile[T any](cfg *T, path string, explicit bool) error {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return nil
+		}
+		return fmt.Errorf("unable to open config file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("unable to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// settingValue is flag.Value of the setting, which hands flag's value over to set.
+type settingValue struct {
+	bool bool
+	set  func(value string) error
+}
+
+// String returns empty default value of the flag.
+func (v *settingValue) String() string {
+	return ""
+}
+
+// Set passes flag's value to the setting.
+func (v *settingValue) Set(value string) error {
+	return v.set(value)
+}
+
+// IsBoolFlag reports whether flag may be set without value.
+func (v *settingValue) IsBoolFlag() bool {
+	return v.bool
+}
+
+// stringField returns setter of the string configuration value.
+func stringField[T any](field func(cfg *T) *string) func(cfg *T, value string) error {
+	return func(cfg *T, value string) error {
+		*field(cfg) = value
+		return nil
+	}
+}
+
+// intField returns setter of the integer configuration value.
+func intField[T any](field func(cfg *T) *int) func(cfg *T, value string) error {
+	return func(cfg *T, value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		*field(cfg) = parsed
+		return nil
+	}
+}
+
+// boolField returns setter of the boolean configuration value.
+func boolField[T any](field func(cfg *T) *bool) func(cfg *T, value string) error {
+	return func(cfg *T, value string) error {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		*field(cfg) = parsed
+		return nil
+	}
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// minSecretLength is the least length of the token signing secret.
+	minSecretLength = 16
+	// minHashKeyLength is the least length of password hash.
+	minHashKeyLength = 16
+	// minHashSaltLength is the least length of password hash salt.
+	minHashSaltLength = 8
+)
+
+// ErrInvalidConfig is raised when configuration has values app can't work with.
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// ValidationError is returned instead of ErrInvalidConfig,
+// listing every problem found in configuration.
+type ValidationError struct {
+	Problems []string
+}
+
+// Error returns message of the error.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInvalidConfig, strings.Join(e.Problems, "; "))
+}
+
+// Is reports whether target is ErrInvalidConfig.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidConfig
+}
+
+// problems collects problems found in configuration.
+type problems []string
+
+// addf records problem described by format.
+func (p *problems) addf(format string, args ...interface{}) {
+	*p = append(*p, fmt.Sprintf(format, args...))
+}
+
+// required records problem if value is empty.
+func (p *problems) required(name string, value string) {
+	if value == "" {
+		p.addf("%s is required", name)
+	}
+}
+
+// port records problem if value isn't valid port number.
+func (p *problems) port(name string, value int) {
+	if value < 1 || value > 65535 {
+		p.addf("%s must be between 1 and 65535, got %d", name, value)
+	}
+}
+
+// oneOf records problem if value is set, but isn't one of allowed ones.
+func (p *problems) oneOf(name string, value string, allowed ...string) {
+	if value == "" {
+		return
+	}
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	p.addf("%s must be one of %q, got %q", name, allowed, value)
+}
+
+// nonNegative records problem if duration is negative.
+func (p *problems) nonNegative(name string, value time.Duration) {
+	if value < 0 {
+		p.addf("%s can't be negative, got %s", name, value)
+	}
+}
+
+// pair records problem if only one of two values is set.
+func (p *problems) pair(name string, value string, otherName string, otherValue string) {
+	if (value == "") != (otherValue == "") {
+		p.addf("%s and %s must be set together", name, otherName)
+	}
+}
+
+// err returns ValidationError listing the problems, or nil if there are none.
+func (p problems) err() error {
+	if len(p) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: p}
+}
+
+// Validate checks that server can start with the configuration,
+// returning ValidationError which lists every problem found.
+func (c ServerConfig) Validate() error {
+	var p problems
+
+	p.required("database.address", c.Database.Address)
+	p.required("database.name", c.Database.Name)
+	p.port("listen.port", c.Listen.Port)
+	p.required("salt", c.Salt)
+
+	if c.Auth.Secret == "" {
+		p.addf("auth.secret is required")
+	} else if len(c.Auth.Secret) < minSecretLength {
+		p.addf("auth.secret must be at least %d bytes long, got %d", minSecretLength, len(c.Auth.Secret))
+	}
+	p.nonNegative("auth.access_token_ttl", c.Auth.AccessTokenTTL)
+	p.nonNegative("auth.refresh_token_ttl", c.Auth.RefreshTokenTTL)
+
+	p.pair("tls.cert_file", c.TLS.CertFile, "tls.key_file", c.TLS.KeyFile)
+	if c.TLS.ClientCAFile != "" && c.TLS.CertFile == "" {
+		p.addf("tls.client_ca_file requires tls.cert_file")
+	}
+	if c.TLS.RequireClientCert && c.TLS.ClientCAFile == "" {
+		p.addf("tls.require_client_cert requires tls.client_ca_file")
+	}
+	p.nonNegative("tls.reload_interval", c.TLS.ReloadInterval)
+
+	p.nonNegative("shutdown.drain_timeout", c.Shutdown.DrainTimeout)
+	p.nonNegative("health.ping_interval", c.Health.PingInterval)
+	p.nonNegative("health.ping_timeout", c.Health.PingTimeout)
+	p.pair("health.probe_cert_file", c.Health.ProbeCertFile, "health.probe_key_file", c.Health.ProbeKeyFile)
+	if (c.Health.ProbeCertFile != "" || c.Health.ProbeCAFile != "") && c.TLS.CertFile == "" {
+		p.addf("health probe certificates require tls.cert_file")
+	}
+	p.nonNegative("metrics.stats_interval", c.Metrics.StatsInterval)
+
+	if c.Lockout.MaxFailures < 0 {
+		p.addf("lockout.max_failures can't be negative, got %d", c.Lockout.MaxFailures)
+	}
+	if c.Lockout.MaxIPFailures < 0 {
+		p.addf("lockout.max_ip_failures can't be negative, got %d", c.Lockout.MaxIPFailures)
+	}
+	p.nonNegative("lockout.duration", c.Lockout.Duration)
+	p.nonNegative("lockout.base_delay", c.Lockout.BaseDelay)
+	p.nonNegative("lockout.max_delay", c.Lockout.MaxDelay)
+	p.nonNegative("lockout.window", c.Lockout.Window)
+
+	if c.Hashing.KeyLength != 0 && c.Hashing.KeyLength < minHashKeyLength {
+		p.addf("hashing.key_length must be at least %d bytes, got %d", minHashKeyLength, c.Hashing.KeyLength)
+	}
+	if c.Hashing.SaltLength != 0 && c.Hashing.SaltLength < minHashSaltLength {
+		p.addf("hashing.salt_length must be at least %d bytes, got %d", minHashSaltLength, c.Hashing.SaltLength)
+	}
+
+	p.oneOf("blobs.store", c.Blobs.Store, "gridfs", "fs")
+	if c.Blobs.Store == "fs" {
+		p.required("blobs.dir", c.Blobs.Dir)
+	}
+	p.nonNegative("blobs.expiry", c.Blobs.Expiry)
+
+	if c.Quotas.TotalBytes < 0 || c.Quotas.MaxItems < 0 || c.Quotas.MaxItemSize < 0 {
+		p.addf("quotas can't be negative")
+	}
+
+	p.oneOf("log.format", c.Log.Format, "console", "json")
+	if c.Log.Level != "" {
+		if level, err := zerolog.ParseLevel(strings.ToLower(c.Log.Level)); err != nil || level == zerolog.NoLevel {
+			p.addf("log.level %q is not a log level", c.Log.Level)
+		}
+	}
+
+	p.tracing(c.Tracing)
+	return p.err()
+}
+
+// Validate checks that client can start with the configuration,
+// returning ValidationError which lists every problem found.
+func (c ClientConfig) Validate() error {
+	var p problems
+
+	p.required("server.address", c.Server.Address)
+	p.port("server.port", c.Server.Port)
+	p.pair("tls.cert_file", c.TLS.CertFile, "tls.key_file", c.TLS.KeyFile)
+	switch len(c.Key) {
+	case 0, 16, 24, 32:
+	default:
+		p.addf("key must be 16, 24 or 32 bytes long, got %d", len(c.Key))
+	}
+
+	p.tracing(c.Tracing)
+	return p.err()
+}
+
+// tracing records problems of tracing configuration.
+func (p *problems) tracing(cfg Tracing) {
+	p.oneOf("tracing.exporter", cfg.Exporter, "otlp", "stdout", "file")
+	if cfg.Exporter == "file" {
+		p.required("tracing.file", cfg.File)
+	}
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		p.addf("tracing.sample_ratio must be between 0 and 1, got %g", cfg.SampleRatio)
+	}
+}
@@ -0,0 +1,185 @@
+// Package encryption provides client-side key derivation and
+// authenticated encryption of gokeeper vault data.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// Version is the format version byte every ciphertext is prefixed with.
+	Version byte = 1
+	// KeyLength is the length of vault and key-encryption keys in bytes.
+	KeyLength = 32
+	// SaltLength is the length of key derivation salt in bytes.
+	SaltLength = 16
+)
+
+var (
+	// ErrMalformedCiphertext is raised when ciphertext is too short
+	// to hold version byte, nonce and authentication tag.
+	ErrMalformedCiphertext = errors.New("ciphertext is malformed")
+	// ErrUnsupportedVersion is raised when ciphertext is prefixed
+	// with unknown format version.
+	ErrUnsupportedVersion = errors.New("ciphertext format version is not supported")
+	// ErrInvalidKDFParams is raised when key derivation parameters
+	// are out of the range keys are derived with.
+	ErrInvalidKDFParams = errors.New("key derivation parameters are out of range")
+)
+
+// Bounds of key derivation parameters, which keep derivation
+// from being too weak or too expensive for the client.
+const (
+	MinKDFTime    = 1
+	MaxKDFTime    = 16
+	MinKDFMemory  = 19 * 1024
+	MaxKDFMemory  = 1024 * 1024
+	MinKDFThreads = 1
+	MaxKDFThreads = 64
+)
+
+// KDFParams holds argon2id parameters key-encryption key is derived with.
+type KDFParams struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+}
+
+// DefaultKDFParams are used to derive key-encryption keys for new vaults.
+var DefaultKDFParams = KDFParams{
+	Time:    3,
+	Memory:  64 * 1024,
+	Threads: 4,
+}
+
+// NewKDFParams returns key derivation parameters,
+// checking that they are within the bounds.
+func NewKDFParams(time uint32, memory uint32, threads uint32) (KDFParams, error) {
+	if time < MinKDFTime || time > MaxKDFTime ||
+		memory < MinKDFMemory || memory > MaxKDFMemory ||
+		threads < MinKDFThreads || threads > MaxKDFThreads {
+		return KDFParams{}, ErrInvalidKDFParams
+	}
+	return KDFParams{Time: time, Memory: memory, Threads: uint8(threads)}, nil
+}
+
+// NewSalt returns random key derivation salt.
+func NewSalt() ([]byte, error) {
+	return random(SaltLength)
+}
+
+// NewKey returns random vault key.
+func NewKey() ([]byte, error) {
+	return random(KeyLength)
+}
+
+// DeriveKey derives key-encryption key from user's master password.
+func DeriveKey(password string, salt []byte, params KDFParams) []byte {
+	return argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, KeyLength)
+}
+
+// Sealer encrypts and decrypts data with AES-256-GCM,
+// using fresh random nonce for every ciphertext.
+type Sealer struct {
+	aead cipher.AEAD
+}
+
+// NewSealer initializes sealer with provided key.
+func NewSealer(key []byte) (*Sealer, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Sealer{aead: aead}, nil
+}
+
+// Seal encrypts plaintext, returning ciphertext
+// prefixed with format version and nonce.
+func (s *Sealer) Seal(plaintext []byte) ([]byte, error) {
+	return s.seal(plaintext, []byte{Version})
+}
+
+// Open decrypts ciphertext previously encrypted with Seal.
+func (s *Sealer) Open(ciphertext []byte) ([]byte, error) {
+	return s.open(ciphertext, []byte{Version})
+}
+
+// SealChunk encrypts index-th chunk of the stream with provided id.
+// Ciphertext is bound to the stream, to the chunk's position in it
+// and to whether the chunk is the last one, so chunks can't be reordered,
+// moved between streams or dropped from the end of the stream.
+func (s *Sealer) SealChunk(plaintext []byte, streamID []byte, index uint64, last bool) ([]byte, error) {
+	return s.seal(plaintext, chunkData(streamID, index, last))
+}
+
+// OpenChunk decrypts chunk previously encrypted with SealChunk.
+func (s *Sealer) OpenChunk(ciphertext []byte, streamID []byte, index uint64, last bool) ([]byte, error) {
+	return s.open(ciphertext, chunkData(streamID, index, last))
+}
+
+// Overhead returns the difference between lengths of ciphertext and plaintext.
+func (s *Sealer) Overhead() int {
+	return 1 + s.aead.NonceSize() + s.aead.Overhead()
+}
+
+// seal encrypts plaintext, authenticating additional data along with it.
+// Additional data must start with format version.
+func (s *Sealer) seal(plaintext []byte, additionalData []byte) ([]byte, error) {
+	nonce, err := random(s.aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(plaintext)+s.Overhead())
+	out = append(out, Version)
+	out = append(out, nonce...)
+	return s.aead.Seal(out, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts ciphertext, checking that it was encrypted
+// with the same additional data.
+func (s *Sealer) open(ciphertext []byte, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < s.Overhead() {
+		return nil, ErrMalformedCiphertext
+	}
+	if ciphertext[0] != Version {
+		return nil, ErrUnsupportedVersion
+	}
+
+	nonce := ciphertext[1 : 1+s.aead.NonceSize()]
+	return s.aead.Open(nil, nonce, ciphertext[1+s.aead.NonceSize():], additionalData)
+}
+
+// chunkData returns additional data chunk of the stream is authenticated with.
+func chunkData(streamID []byte, index uint64, last bool) []byte {
+	var position [8]byte
+	binary.BigEndian.PutUint64(position[:], index)
+
+	data := make([]byte, 0, 1+len(streamID)+len(position)+1)
+	data = append(data, Version)
+	data = append(data, streamID...)
+	data = append(data, position[:]...)
+	if last {
+		return append(data, 1)
+	}
+	return append(data, 0)
+}
+
+// random returns n cryptographically secure random bytes.
+func random(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
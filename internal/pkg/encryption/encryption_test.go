@@ -0,0 +1,161 @@
+package encryption
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealer(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	sealer, err := NewSealer(key)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		ciphertext, err := sealer.Seal([]byte("somepwd"))
+		require.NoError(t, err)
+		require.Equal(t, Version, ciphertext[0])
+
+		plaintext, err := sealer.Open(ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, []byte("somepwd"), plaintext)
+	})
+
+	t.Run("random nonce", func(t *testing.T) {
+		first, err := sealer.Seal([]byte("somepwd"))
+		require.NoError(t, err)
+		second, err := sealer.Seal([]byte("somepwd"))
+		require.NoError(t, err)
+		require.NotEqual(t, first, second)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		ciphertext, err := sealer.Seal([]byte("somepwd"))
+		require.NoError(t, err)
+
+		other, err := NewKey()
+		require.NoError(t, err)
+		otherSealer, err := NewSealer(other)
+		require.NoError(t, err)
+
+		_, err = otherSealer.Open(ciphertext)
+		require.Error(t, err)
+	})
+
+	t.Run("tampered version", func(t *testing.T) {
+		ciphertext, err := sealer.Seal([]byte("somepwd"))
+		require.NoError(t, err)
+		ciphertext[0] = Version + 1
+
+		_, err = sealer.Open(ciphertext)
+		require.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		_, err := sealer.Open([]byte{Version, 1, 2, 3})
+		require.ErrorIs(t, err, ErrMalformedCiphertext)
+	})
+}
+
+func TestDeriveKey(t *testing.T) {
+	params := KDFParams{Time: 1, Memory: 1024, Threads: 1}
+	salt, err := NewSalt()
+	require.NoError(t, err)
+
+	t.Run("deterministic", func(t *testing.T) {
+		first := DeriveKey("master", salt, params)
+		second := DeriveKey("master", salt, params)
+		require.Len(t, first, KeyLength)
+		require.Equal(t, first, second)
+	})
+
+	t.Run("depends on salt", func(t *testing.T) {
+		other, err := NewSalt()
+		require.NoError(t, err)
+		require.NotEqual(t, DeriveKey("master", salt, params), DeriveKey("master", other, params))
+	})
+
+	t.Run("wraps vault key", func(t *testing.T) {
+		vaultKey, err := NewKey()
+		require.NoError(t, err)
+		kek, err := NewSealer(DeriveKey("master", salt, params))
+		require.NoError(t, err)
+
+		wrapped, err := kek.Seal(vaultKey)
+		require.NoError(t, err)
+
+		wrong, err := NewSealer(DeriveKey("wrong", salt, params))
+		require.NoError(t, err)
+		_, err = wrong.Open(wrapped)
+		require.Error(t, err)
+
+		unwrapped, err := kek.Open(wrapped)
+		require.NoError(t, err)
+		require.Equal(t, vaultKey, unwrapped)
+	})
+}
+
+func TestNewKDFParams(t *testing.T) {
+	params, err := NewKDFParams(DefaultKDFParams.Time, DefaultKDFParams.Memory, uint32(DefaultKDFParams.Threads))
+	require.NoError(t, err)
+	require.Equal(t, DefaultKDFParams, params)
+
+	tt := []struct {
+		name    string
+		time    uint32
+		memory  uint32
+		threads uint32
+	}{
+		{"zero time", 0, 64 * 1024, 4},
+		{"too many passes", MaxKDFTime + 1, 64 * 1024, 4},
+		{"too little memory", 3, 1024, 4},
+		{"too much memory", 3, MaxKDFMemory + 1, 4},
+		{"zero threads", 3, 64 * 1024, 0},
+		{"threads overflow", 3, 64 * 1024, 260},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewKDFParams(tc.time, tc.memory, tc.threads)
+			require.ErrorIs(t, err, ErrInvalidKDFParams)
+		})
+	}
+}
+
+func TestSealChunk(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	sealer, err := NewSealer(key)
+	require.NoError(t, err)
+	stream := []byte("stream")
+
+	ciphertext, err := sealer.SealChunk([]byte("chunk"), stream, 3, false)
+	require.NoError(t, err)
+	require.Len(t, ciphertext, len("chunk")+sealer.Overhead())
+
+	t.Run("success", func(t *testing.T) {
+		plaintext, err := sealer.OpenChunk(ciphertext, stream, 3, false)
+		require.NoError(t, err)
+		require.Equal(t, []byte("chunk"), plaintext)
+	})
+
+	t.Run("wrong position", func(t *testing.T) {
+		_, err := sealer.OpenChunk(ciphertext, stream, 2, false)
+		require.Error(t, err)
+	})
+
+	t.Run("truncated stream", func(t *testing.T) {
+		_, err := sealer.OpenChunk(ciphertext, stream, 3, true)
+		require.Error(t, err)
+	})
+
+	t.Run("other stream", func(t *testing.T) {
+		_, err := sealer.OpenChunk(ciphertext, []byte("other"), 3, false)
+		require.Error(t, err)
+	})
+
+	t.Run("not a chunk", func(t *testing.T) {
+		_, err := sealer.Open(ciphertext)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,95 @@
+// Package logging builds loggers of gokeeper applications, which never write
+// user's secrets, and carries request-scoped loggers in contexts.
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+)
+
+// Supported log formats.
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+)
+
+// consoleTimeFormat is the timestamp format of console output.
+const consoleTimeFormat = "02-01-2006 15:04:05 MST"
+
+var (
+	// ErrUnknownFormat is raised when configuration refers to log format which is not supported.
+	ErrUnknownFormat = errors.New("unknown log format")
+	// ErrUnknownLevel is raised when configuration refers to log level which doesn't exist.
+	ErrUnknownLevel = errors.New("unknown log level")
+)
+
+// ctxKey is the key request-scoped logger is stored in context with.
+type ctxKey struct{}
+
+// New builds logger according to configuration. Without configured level
+// it logs debug events when debug is set and info events otherwise.
+// Returned function closes log file, if any.
+func New(cfg config.Log, debug bool) (zerolog.Logger, func() error, error) {
+	nop := func() error { return nil }
+
+	level := zerolog.InfoLevel
+	if debug {
+		level = zerolog.DebugLevel
+	}
+	if cfg.Level != "" {
+		parsed, err := zerolog.ParseLevel(strings.ToLower(cfg.Level))
+		if err != nil || parsed == zerolog.NoLevel {
+			return zerolog.Nop(), nil, fmt.Errorf("%w: %q", ErrUnknownLevel, cfg.Level)
+		}
+		level = parsed
+	}
+
+	var out io.Writer = os.Stdout
+	closeOut := nop
+	if cfg.File != "" {
+		file, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return zerolog.Nop(), nil, err
+		}
+		out, closeOut = file, file.Close
+	}
+
+	switch cfg.Format {
+	case "", FormatConsole:
+		out = zerolog.ConsoleWriter{
+			Out:        out,
+			NoColor:    cfg.File != "",
+			TimeFormat: consoleTimeFormat,
+		}
+	case FormatJSON:
+	default:
+		closeOut()
+		return zerolog.Nop(), nil, fmt.Errorf("%w: %q", ErrUnknownFormat, cfg.Format)
+	}
+
+	logger := zerolog.New(Redact(out)).With().Timestamp().Logger().Level(level)
+	return logger, closeOut, nil
+}
+
+// WithLogger returns context carrying logger of the request.
+func WithLogger(ctx context.Context, logger zerolog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, &logger)
+}
+
+// FromContext returns logger of the request ctx belongs to,
+// or fallback if ctx doesn't carry one.
+func FromContext(ctx context.Context, fallback *zerolog.Logger) *zerolog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(ctxKey{}).(*zerolog.Logger); ok {
+			return logger
+		}
+	}
+	return fallback
+}
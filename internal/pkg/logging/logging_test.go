@@ -0,0 +1,71 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("default level", func(t *testing.T) {
+		logger, closeLog, err := New(config.Log{}, false)
+		require.NoError(t, err)
+		defer closeLog()
+		require.Equal(t, zerolog.InfoLevel, logger.GetLevel())
+
+		logger, closeLog, err = New(config.Log{}, true)
+		require.NoError(t, err)
+		defer closeLog()
+		require.Equal(t, zerolog.DebugLevel, logger.GetLevel())
+	})
+
+	t.Run("configured level", func(t *testing.T) {
+		logger, closeLog, err := New(config.Log{Level: "WARN"}, true)
+		require.NoError(t, err)
+		defer closeLog()
+		require.Equal(t, zerolog.WarnLevel, logger.GetLevel())
+	})
+
+	t.Run("unknown level", func(t *testing.T) {
+		_, _, err := New(config.Log{Level: "verbose"}, false)
+		require.ErrorIs(t, err, ErrUnknownLevel)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, _, err := New(config.Log{Format: "xml"}, false)
+		require.ErrorIs(t, err, ErrUnknownFormat)
+	})
+
+	t.Run("json file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "server.log")
+		logger, closeLog, err := New(config.Log{Format: FormatJSON, File: file}, false)
+		require.NoError(t, err)
+		logger.Info().Str("user", "test").Str("password", "qwerty").Msg("logged in")
+		require.NoError(t, closeLog())
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &fields))
+		require.Equal(t, "logged in", fields["message"])
+		require.Equal(t, "test", fields["user"])
+		require.Equal(t, Redacted, fields["password"])
+	})
+}
+
+func TestFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	fallback := zerolog.New(&buf)
+	require.Same(t, &fallback, FromContext(context.Background(), &fallback))
+
+	ctx := WithLogger(context.Background(), fallback.With().Str("request_id", "42").Logger())
+	FromContext(ctx, &fallback).Info().Msg("")
+	require.Contains(t, buf.String(), `"request_id":"42"`)
+}
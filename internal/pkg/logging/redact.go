@@ -0,0 +1,139 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces secrets in log events.
+const Redacted = "[REDACTED]"
+
+var (
+	// sensitiveSuffixes are normalized field names, fields ending with which hold secrets.
+	sensitiveSuffixes = []string{
+		"password",
+		"passwd",
+		"token",
+		"secret",
+		"authorization",
+		"payload",
+		"wrappedkey",
+		"cardsecuritycode",
+		"csc",
+	}
+	// sensitiveNames are normalized field names of item contents,
+	// which are too generic to be matched by suffix.
+	sensitiveNames = map[string]struct{}{
+		"key":     {},
+		"data":    {},
+		"value":   {},
+		"content": {},
+		"number":  {},
+		"cvv":     {},
+	}
+	// secretPatterns match secrets which may be embedded in messages and errors.
+	secretPatterns = []*regexp.Regexp{
+		// bearer credentials
+		regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9._~+/=-]+`),
+		// JSON web tokens
+		regexp.MustCompile(`eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	}
+)
+
+// redactingWriter removes secrets from JSON log events before writing them.
+type redactingWriter struct {
+	out io.Writer
+}
+
+// Redact wraps writer of JSON log events, so values of fields holding
+// passwords, tokens, keys and item contents, as well as tokens embedded
+// in any string, never reach it. Events which can't be parsed are dropped.
+func Redact(out io.Writer) io.Writer {
+	return &redactingWriter{out: out}
+}
+
+// Write writes event p with secrets replaced.
+func (w *redactingWriter) Write(p []byte) (int, error) {
+	dec := json.NewDecoder(bytes.NewReader(p))
+	dec.UseNumber()
+	var event map[string]interface{}
+	if err := dec.Decode(&event); err != nil {
+		// event can't be checked, so it isn't written at all
+		return len(p), nil
+	}
+	if !redactObject(event) {
+		if _, err := w.out.Write(p); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return len(p), nil
+	}
+	if _, err := w.out.Write(append(data, '\n')); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// redactObject replaces secrets in fields of the object,
+// reporting whether any were found.
+func redactObject(obj map[string]interface{}) bool {
+	redacted := false
+	for key, value := range obj {
+		if isSensitive(key) {
+			obj[key] = Redacted
+			redacted = true
+			continue
+		}
+		if v, ok := redactValue(value); ok {
+			obj[key] = v
+			redacted = true
+		}
+	}
+	return redacted
+}
+
+// redactValue returns value with secrets replaced and whether any were found.
+func redactValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		replaced := v
+		for _, pattern := range secretPatterns {
+			replaced = pattern.ReplaceAllString(replaced, Redacted)
+		}
+		return replaced, replaced != v
+	case map[string]interface{}:
+		return v, redactObject(v)
+	case []interface{}:
+		redacted := false
+		for i := range v {
+			if item, ok := redactValue(v[i]); ok {
+				v[i] = item
+				redacted = true
+			}
+		}
+		return v, redacted
+	default:
+		return value, false
+	}
+}
+
+// isSensitive reports whether field with provided name holds a secret.
+func isSensitive(name string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+	if _, ok := sensitiveNames[normalized]; ok {
+		return true
+	}
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(normalized, suffix) {
+			return true
+		}
+	}
+	return false
+}
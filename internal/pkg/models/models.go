@@ -1,46 +1,215 @@
-// Package models provides items and user models for gokeeper app.
+// Package models provides items, user, session and audit models for gokeeper app.
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
 // User holds information about app's user.
 type User struct {
-	ID        uuid.UUID            `bson:"id"`
-	Login     string               `bson:"login"`
-	Password  string               `bson:"password"`
-	Logins    []*LoginPasswordItem `bson:"logins"`
-	BankCards []*BankCardItem      `bson:"cards"`
-	Texts     []*TextItem          `bson:"texts"`
-	Binaries  []*BinaryItem        `bson:"binaries"`
+	ID       uuid.UUID `bson:"id"`
+	Login    string    `bson:"login"`
+	Password string    `bson:"password"`
+	VaultKey *VaultKey `bson:"vault_key,omitempty"`
+	TOTP     *TOTP     `bson:"totp,omitempty"`
+	Revision int64     `bson:"revision"`
+	Usage    Usage     `bson:"usage"`
+}
+
+// TOTP holds user's two-factor authentication settings. Until enrollment
+// is confirmed with a valid code, secret is kept but not Enabled.
+type TOTP struct {
+	Secret  string `bson:"secret"`
+	Enabled bool   `bson:"enabled"`
+	// LastStep is the time step of the last accepted code,
+	// codes of it and earlier steps can't be used again.
+	LastStep int64 `bson:"last_step"`
+	// RecoveryCodes holds hashes of unused recovery codes.
+	RecoveryCodes []string `bson:"recovery_codes"`
+}
+
+// Vault holds items of the user. When vault holds changes since
+// some revision, Deleted holds ids of items removed since then,
+// and Revision is the latest revision among the changes.
+type Vault struct {
+	Revision  int64
+	Logins    []*LoginPasswordItem
+	BankCards []*BankCardItem
+	Texts     []*TextItem
+	Binaries  []*BinaryItem
+	Items     []*Item
+	Deleted   []uuid.UUID
+}
+
+// VaultKey holds user's vault key, wrapped on the client side
+// with the key derived from user's master password,
+// along with key derivation parameters.
+type VaultKey struct {
+	WrappedKey []byte `bson:"wrapped_key"`
+	KDFSalt    []byte `bson:"kdf_salt"`
+	KDFTime    uint32 `bson:"kdf_time"`
+	KDFMemory  uint32 `bson:"kdf_memory"`
+	KDFThreads uint32 `bson:"kdf_threads"`
 }
 
 // LoginPasswordItem holds information about
 // single login-password entry.
 type LoginPasswordItem struct {
-	Login    string            `bson:"login"`
-	Password []byte            `bson:"password"`
-	Meta     map[string]string `bson:"meta"`
+	ID        uuid.UUID         `bson:"id"`
+	Login     string            `bson:"login"`
+	Password  []byte            `bson:"password"`
+	Meta      map[string]string `bson:"meta"`
+	CreatedAt time.Time         `bson:"created_at"`
+	UpdatedAt time.Time         `bson:"updated_at"`
+	Revision  int64             `bson:"revision"`
 }
 
 // BankCardItem holds bank card related information.
 type BankCardItem struct {
+	ID               uuid.UUID         `bson:"id"`
 	Number           string            `bson:"number"`
 	Holder           string            `bson:"holder"`
 	Expires          string            `bson:"expires"`
 	CardSecurityCode []byte            `bson:"csc"`
 	Meta             map[string]string `bson:"meta"`
+	CreatedAt        time.Time         `bson:"created_at"`
+	UpdatedAt        time.Time         `bson:"updated_at"`
+	Revision         int64             `bson:"revision"`
 }
 
 // TextItem holds arbitrary text information.
 type TextItem struct {
-	Value string            `bson:"value"`
-	Meta  map[string]string `bson:"meta"`
+	ID        uuid.UUID         `bson:"id"`
+	Value     string            `bson:"value"`
+	Meta      map[string]string `bson:"meta"`
+	CreatedAt time.Time         `bson:"created_at"`
+	UpdatedAt time.Time         `bson:"updated_at"`
+	Revision  int64             `bson:"revision"`
 }
 
-// BinaryItem holds arbitrary binary information.
+// BinaryItem holds arbitrary binary information. Value is kept
+// in the blob store, the item refers to it by BlobID.
 type BinaryItem struct {
-	Value []byte            `bson:"value"`
-	Meta  map[string]string `bson:"meta"`
+	ID        uuid.UUID         `bson:"id"`
+	Value     []byte            `bson:"value,omitempty"`
+	BlobID    uuid.UUID         `bson:"blob_id"`
+	Size      int64             `bson:"size"`
+	Hash      string            `bson:"hash"`
+	Meta      map[string]string `bson:"meta"`
+	CreatedAt time.Time         `bson:"created_at"`
+	UpdatedAt time.Time         `bson:"updated_at"`
+	Revision  int64             `bson:"revision"`
+}
+
+// ItemType is the kind of item encrypted in the item's payload.
+type ItemType string
+
+// Supported item types.
+const (
+	ItemTypeLogin    ItemType = "login"
+	ItemTypeBankCard ItemType = "card"
+	ItemTypeText     ItemType = "text"
+	ItemTypeBinary   ItemType = "binary"
+)
+
+// Item holds single encrypted vault entry. Payload is encrypted
+// on the client side, other fields are non-sensitive envelope.
+// Binary items may refer to blob their encrypted content is stored in.
+type Item struct {
+	ID        uuid.UUID `bson:"id"`
+	Type      ItemType  `bson:"type"`
+	Revision  int64     `bson:"revision"`
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+	Payload   []byte    `bson:"payload"`
+	BlobID    uuid.UUID `bson:"blob_id"`
+	BlobSize  int64     `bson:"blob_size"`
+	BlobHash  string    `bson:"blob_hash"`
+}
+
+// Blob holds information about binary content uploaded by the user in chunks.
+// Blob is complete when its last chunk was received and the content was moved
+// to the blob store. Hash is hex encoded SHA-256 of the complete content.
+type Blob struct {
+	ID        uuid.UUID `bson:"id"`
+	UserID    uuid.UUID `bson:"user_id"`
+	Size      int64     `bson:"size"`
+	Hash      string    `bson:"hash"`
+	Complete  bool      `bson:"complete"`
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// BlobChunk holds part of the blob starting at Offset, which
+// is staged until the whole blob is received.
+// Checksum is CRC-32C of Data. Chunks of the blob, which isn't
+// completed until ExpiresAt, are removed by the database.
+type BlobChunk struct {
+	UserID    uuid.UUID `bson:"user_id"`
+	BlobID    uuid.UUID `bson:"blob_id"`
+	Offset    int64     `bson:"offset"`
+	End       int64     `bson:"end"`
+	Data      []byte    `bson:"data"`
+	Checksum  uint32    `bson:"checksum"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// Usage holds storage used by the user. Bytes include
+// both items and content of the blobs, uploaded ones
+// are counted before they are attached to items.
+type Usage struct {
+	Items int64 `bson:"items"`
+	Bytes int64 `bson:"bytes"`
+}
+
+// Stats holds totals of the whole storage.
+type Stats struct {
+	Users int64
+	Items int64
+}
+
+// Session holds information about single user's login session.
+// PreviousRefreshHash is the hash of the refresh token rotated last,
+// presenting it again means that the token was stolen.
+type Session struct {
+	ID                  uuid.UUID `bson:"id"`
+	UserID              uuid.UUID `bson:"user_id"`
+	Device              string    `bson:"device"`
+	RefreshHash         string    `bson:"refresh_hash"`
+	PreviousRefreshHash string    `bson:"previous_refresh_hash,omitempty"`
+	CreatedAt           time.Time `bson:"created_at"`
+	LastSeenAt          time.Time `bson:"last_seen_at"`
+	ExpiresAt           time.Time `bson:"expires_at"`
+	Revoked             bool      `bson:"revoked"`
+}
+
+// AuditEventType is the kind of security-relevant event.
+type AuditEventType string
+
+// Audited events.
+const (
+	AuditSignUp          AuditEventType = "sign_up"
+	AuditLoginSucceeded  AuditEventType = "login_succeeded"
+	AuditLoginFailed     AuditEventType = "login_failed"
+	AuditItemCreated     AuditEventType = "item_created"
+	AuditItemUpdated     AuditEventType = "item_updated"
+	AuditItemDeleted     AuditEventType = "item_deleted"
+	AuditSessionRevoked  AuditEventType = "session_revoked"
+	AuditTOTPEnabled     AuditEventType = "totp_enabled"
+	AuditTOTPDisabled    AuditEventType = "totp_disabled"
+	AuditPasswordChanged AuditEventType = "password_changed"
+	AuditAccountDeleted  AuditEventType = "account_deleted"
+)
+
+// AuditEvent holds single security-relevant event of the user's account.
+// Details hold event specific non-sensitive data, like id of the changed item.
+type AuditEvent struct {
+	ID       uuid.UUID         `bson:"id"`
+	UserID   uuid.UUID         `bson:"user_id"`
+	Type     AuditEventType    `bson:"type"`
+	Time     time.Time         `bson:"time"`
+	ClientIP string            `bson:"client_ip"`
+	Details  map[string]string `bson:"details,omitempty"`
 }
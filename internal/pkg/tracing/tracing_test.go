@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/serjyuriev/yandex-diploma-2/internal/pkg/config"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSetup(t *testing.T) {
+	logger := zerolog.Nop()
+	ctx := context.Background()
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	t.Run("disabled", func(t *testing.T) {
+		shutdown, err := Setup(ctx, config.Tracing{}, "test", logger)
+		require.NoError(t, err)
+		require.NoError(t, shutdown(ctx))
+		require.ElementsMatch(t, []string{"traceparent", "tracestate", "baggage"}, otel.GetTextMapPropagator().Fields())
+	})
+
+	t.Run("unknown exporter", func(t *testing.T) {
+		_, err := Setup(ctx, config.Tracing{Exporter: "zipkin"}, "test", logger)
+		require.ErrorIs(t, err, ErrUnknownExporter)
+	})
+
+	t.Run("file without path", func(t *testing.T) {
+		_, err := Setup(ctx, config.Tracing{Exporter: ExporterFile}, "test", logger)
+		require.ErrorIs(t, err, ErrNoFile)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "traces.json")
+		shutdown, err := Setup(ctx, config.Tracing{Exporter: ExporterFile, File: file}, "test", logger)
+		require.NoError(t, err)
+
+		_, span := otel.Tracer("test").Start(ctx, "operation")
+		span.End()
+		require.NoError(t, shutdown(ctx))
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"Name":"operation"`)
+		require.Contains(t, string(data), span.SpanContext().TraceID().String())
+	})
+}
@@ -24,10 +24,34 @@ const _ = grpc.SupportPackageIsVersion7
 type GokeeperClient interface {
 	SignUpUser(ctx context.Context, in *SignUpUserRequest, opts ...grpc.CallOption) (*SignUpUserResponse, error)
 	LoginUser(ctx context.Context, in *LoginUserRequest, opts ...grpc.CallOption) (*LoginUserResponse, error)
+	RefreshSession(ctx context.Context, in *RefreshSessionRequest, opts ...grpc.CallOption) (*RefreshSessionResponse, error)
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error)
+	GetVaultKey(ctx context.Context, in *GetVaultKeyRequest, opts ...grpc.CallOption) (*GetVaultKeyResponse, error)
+	SetVaultKey(ctx context.Context, in *SetVaultKeyRequest, opts ...grpc.CallOption) (*SetVaultKeyResponse, error)
 	UpdateItems(ctx context.Context, in *UpdateItemsRequest, opts ...grpc.CallOption) (*UpdateItemsResponse, error)
+	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error)
+	AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*AddItemResponse, error)
+	UpdateItem(ctx context.Context, in *UpdateItemRequest, opts ...grpc.CallOption) (*UpdateItemResponse, error)
+	DeleteItem(ctx context.Context, in *DeleteItemRequest, opts ...grpc.CallOption) (*DeleteItemResponse, error)
+	UploadBinary(ctx context.Context, opts ...grpc.CallOption) (Gokeeper_UploadBinaryClient, error)
+	GetUploadOffset(ctx context.Context, in *GetUploadOffsetRequest, opts ...grpc.CallOption) (*GetUploadOffsetResponse, error)
+	DownloadBinary(ctx context.Context, in *DownloadBinaryRequest, opts ...grpc.CallOption) (Gokeeper_DownloadBinaryClient, error)
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	EnableTOTP(ctx context.Context, in *EnableTOTPRequest, opts ...grpc.CallOption) (*EnableTOTPResponse, error)
+	ConfirmTOTP(ctx context.Context, in *ConfirmTOTPRequest, opts ...grpc.CallOption) (*ConfirmTOTPResponse, error)
+	DisableTOTP(ctx context.Context, in *DisableTOTPRequest, opts ...grpc.CallOption) (*DisableTOTPResponse, error)
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
+	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error)
+	// Deprecated: use AddItem with client-side encrypted payload.
 	AddLoginItem(ctx context.Context, in *AddLoginItemRequest, opts ...grpc.CallOption) (*AddLoginItemResponse, error)
+	// Deprecated: use AddItem with client-side encrypted payload.
 	AddBankCardItem(ctx context.Context, in *AddBankCardItemRequest, opts ...grpc.CallOption) (*AddBankCardItemResponse, error)
+	// Deprecated: use AddItem with client-side encrypted payload.
 	AddTextItem(ctx context.Context, in *AddTextItemRequest, opts ...grpc.CallOption) (*AddTextItemResponse, error)
+	// Deprecated: use AddItem with client-side encrypted payload.
 	AddBinaryItem(ctx context.Context, in *AddBinaryItemRequest, opts ...grpc.CallOption) (*AddBinaryItemResponse, error)
 }
 
@@ -57,6 +81,60 @@ func (c *gokeeperClient) LoginUser(ctx context.Context, in *LoginUserRequest, op
 	return out, nil
 }
 
+func (c *gokeeperClient) RefreshSession(ctx context.Context, in *RefreshSessionRequest, opts ...grpc.CallOption) (*RefreshSessionResponse, error) {
+	out := new(RefreshSessionResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/RefreshSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error) {
+	out := new(LogoutResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/Logout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/ListSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error) {
+	out := new(RevokeSessionResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/RevokeSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) GetVaultKey(ctx context.Context, in *GetVaultKeyRequest, opts ...grpc.CallOption) (*GetVaultKeyResponse, error) {
+	out := new(GetVaultKeyResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/GetVaultKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) SetVaultKey(ctx context.Context, in *SetVaultKeyRequest, opts ...grpc.CallOption) (*SetVaultKeyResponse, error) {
+	out := new(SetVaultKeyResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/SetVaultKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gokeeperClient) UpdateItems(ctx context.Context, in *UpdateItemsRequest, opts ...grpc.CallOption) (*UpdateItemsResponse, error) {
 	out := new(UpdateItemsResponse)
 	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/UpdateItems", in, out, opts...)
@@ -66,6 +144,180 @@ func (c *gokeeperClient) UpdateItems(ctx context.Context, in *UpdateItemsRequest
 	return out, nil
 }
 
+func (c *gokeeperClient) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error) {
+	out := new(SyncResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/Sync", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*AddItemResponse, error) {
+	out := new(AddItemResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/AddItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) UpdateItem(ctx context.Context, in *UpdateItemRequest, opts ...grpc.CallOption) (*UpdateItemResponse, error) {
+	out := new(UpdateItemResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/UpdateItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) DeleteItem(ctx context.Context, in *DeleteItemRequest, opts ...grpc.CallOption) (*DeleteItemResponse, error) {
+	out := new(DeleteItemResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/DeleteItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) UploadBinary(ctx context.Context, opts ...grpc.CallOption) (Gokeeper_UploadBinaryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Gokeeper_ServiceDesc.Streams[0], "/proto.server.Gokeeper/UploadBinary", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gokeeperUploadBinaryClient{stream}
+	return x, nil
+}
+
+type Gokeeper_UploadBinaryClient interface {
+	Send(*UploadBinaryRequest) error
+	CloseAndRecv() (*UploadBinaryResponse, error)
+	grpc.ClientStream
+}
+
+type gokeeperUploadBinaryClient struct {
+	grpc.ClientStream
+}
+
+func (x *gokeeperUploadBinaryClient) Send(m *UploadBinaryRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *gokeeperUploadBinaryClient) CloseAndRecv() (*UploadBinaryResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadBinaryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gokeeperClient) GetUploadOffset(ctx context.Context, in *GetUploadOffsetRequest, opts ...grpc.CallOption) (*GetUploadOffsetResponse, error) {
+	out := new(GetUploadOffsetResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/GetUploadOffset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) DownloadBinary(ctx context.Context, in *DownloadBinaryRequest, opts ...grpc.CallOption) (Gokeeper_DownloadBinaryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Gokeeper_ServiceDesc.Streams[1], "/proto.server.Gokeeper/DownloadBinary", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gokeeperDownloadBinaryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Gokeeper_DownloadBinaryClient interface {
+	Recv() (*DownloadBinaryResponse, error)
+	grpc.ClientStream
+}
+
+type gokeeperDownloadBinaryClient struct {
+	grpc.ClientStream
+}
+
+func (x *gokeeperDownloadBinaryClient) Recv() (*DownloadBinaryResponse, error) {
+	m := new(DownloadBinaryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gokeeperClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/GetUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) EnableTOTP(ctx context.Context, in *EnableTOTPRequest, opts ...grpc.CallOption) (*EnableTOTPResponse, error) {
+	out := new(EnableTOTPResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/EnableTOTP", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) ConfirmTOTP(ctx context.Context, in *ConfirmTOTPRequest, opts ...grpc.CallOption) (*ConfirmTOTPResponse, error) {
+	out := new(ConfirmTOTPResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/ConfirmTOTP", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) DisableTOTP(ctx context.Context, in *DisableTOTPRequest, opts ...grpc.CallOption) (*DisableTOTPResponse, error) {
+	out := new(DisableTOTPResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/DisableTOTP", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error) {
+	out := new(ChangePasswordResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/ChangePassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokeeperClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error) {
+	out := new(DeleteAccountResponse)
+	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/DeleteAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gokeeperClient) AddLoginItem(ctx context.Context, in *AddLoginItemRequest, opts ...grpc.CallOption) (*AddLoginItemResponse, error) {
 	out := new(AddLoginItemResponse)
 	err := c.cc.Invoke(ctx, "/proto.server.Gokeeper/AddLoginItem", in, out, opts...)
@@ -108,10 +360,34 @@ func (c *gokeeperClient) AddBinaryItem(ctx context.Context, in *AddBinaryItemReq
 type GokeeperServer interface {
 	SignUpUser(context.Context, *SignUpUserRequest) (*SignUpUserResponse, error)
 	LoginUser(context.Context, *LoginUserRequest) (*LoginUserResponse, error)
+	RefreshSession(context.Context, *RefreshSessionRequest) (*RefreshSessionResponse, error)
+	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error)
+	GetVaultKey(context.Context, *GetVaultKeyRequest) (*GetVaultKeyResponse, error)
+	SetVaultKey(context.Context, *SetVaultKeyRequest) (*SetVaultKeyResponse, error)
 	UpdateItems(context.Context, *UpdateItemsRequest) (*UpdateItemsResponse, error)
+	Sync(context.Context, *SyncRequest) (*SyncResponse, error)
+	AddItem(context.Context, *AddItemRequest) (*AddItemResponse, error)
+	UpdateItem(context.Context, *UpdateItemRequest) (*UpdateItemResponse, error)
+	DeleteItem(context.Context, *DeleteItemRequest) (*DeleteItemResponse, error)
+	UploadBinary(Gokeeper_UploadBinaryServer) error
+	GetUploadOffset(context.Context, *GetUploadOffsetRequest) (*GetUploadOffsetResponse, error)
+	DownloadBinary(*DownloadBinaryRequest, Gokeeper_DownloadBinaryServer) error
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	EnableTOTP(context.Context, *EnableTOTPRequest) (*EnableTOTPResponse, error)
+	ConfirmTOTP(context.Context, *ConfirmTOTPRequest) (*ConfirmTOTPResponse, error)
+	DisableTOTP(context.Context, *DisableTOTPRequest) (*DisableTOTPResponse, error)
+	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
+	DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error)
+	// Deprecated: use AddItem with client-side encrypted payload.
 	AddLoginItem(context.Context, *AddLoginItemRequest) (*AddLoginItemResponse, error)
+	// Deprecated: use AddItem with client-side encrypted payload.
 	AddBankCardItem(context.Context, *AddBankCardItemRequest) (*AddBankCardItemResponse, error)
+	// Deprecated: use AddItem with client-side encrypted payload.
 	AddTextItem(context.Context, *AddTextItemRequest) (*AddTextItemResponse, error)
+	// Deprecated: use AddItem with client-side encrypted payload.
 	AddBinaryItem(context.Context, *AddBinaryItemRequest) (*AddBinaryItemResponse, error)
 	mustEmbedUnimplementedGokeeperServer()
 }
@@ -126,9 +402,69 @@ func (UnimplementedGokeeperServer) SignUpUser(context.Context, *SignUpUserReques
 func (UnimplementedGokeeperServer) LoginUser(context.Context, *LoginUserRequest) (*LoginUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LoginUser not implemented")
 }
+func (UnimplementedGokeeperServer) RefreshSession(context.Context, *RefreshSessionRequest) (*RefreshSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshSession not implemented")
+}
+func (UnimplementedGokeeperServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedGokeeperServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedGokeeperServer) RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedGokeeperServer) GetVaultKey(context.Context, *GetVaultKeyRequest) (*GetVaultKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVaultKey not implemented")
+}
+func (UnimplementedGokeeperServer) SetVaultKey(context.Context, *SetVaultKeyRequest) (*SetVaultKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVaultKey not implemented")
+}
 func (UnimplementedGokeeperServer) UpdateItems(context.Context, *UpdateItemsRequest) (*UpdateItemsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateItems not implemented")
 }
+func (UnimplementedGokeeperServer) Sync(context.Context, *SyncRequest) (*SyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sync not implemented")
+}
+func (UnimplementedGokeeperServer) AddItem(context.Context, *AddItemRequest) (*AddItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddItem not implemented")
+}
+func (UnimplementedGokeeperServer) UpdateItem(context.Context, *UpdateItemRequest) (*UpdateItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateItem not implemented")
+}
+func (UnimplementedGokeeperServer) DeleteItem(context.Context, *DeleteItemRequest) (*DeleteItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteItem not implemented")
+}
+func (UnimplementedGokeeperServer) UploadBinary(Gokeeper_UploadBinaryServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadBinary not implemented")
+}
+func (UnimplementedGokeeperServer) GetUploadOffset(context.Context, *GetUploadOffsetRequest) (*GetUploadOffsetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUploadOffset not implemented")
+}
+func (UnimplementedGokeeperServer) DownloadBinary(*DownloadBinaryRequest, Gokeeper_DownloadBinaryServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadBinary not implemented")
+}
+func (UnimplementedGokeeperServer) GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedGokeeperServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedGokeeperServer) EnableTOTP(context.Context, *EnableTOTPRequest) (*EnableTOTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableTOTP not implemented")
+}
+func (UnimplementedGokeeperServer) ConfirmTOTP(context.Context, *ConfirmTOTPRequest) (*ConfirmTOTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTOTP not implemented")
+}
+func (UnimplementedGokeeperServer) DisableTOTP(context.Context, *DisableTOTPRequest) (*DisableTOTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTOTP not implemented")
+}
+func (UnimplementedGokeeperServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedGokeeperServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
 func (UnimplementedGokeeperServer) AddLoginItem(context.Context, *AddLoginItemRequest) (*AddLoginItemResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddLoginItem not implemented")
 }
@@ -190,6 +526,114 @@ func _Gokeeper_LoginUser_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Gokeeper_RefreshSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).RefreshSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/RefreshSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).RefreshSession(ctx, req.(*RefreshSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/Logout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/ListSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/RevokeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_GetVaultKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVaultKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).GetVaultKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/GetVaultKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).GetVaultKey(ctx, req.(*GetVaultKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_SetVaultKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVaultKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).SetVaultKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/SetVaultKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).SetVaultKey(ctx, req.(*SetVaultKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Gokeeper_UpdateItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateItemsRequest)
 	if err := dec(in); err != nil {
@@ -208,6 +652,269 @@ func _Gokeeper_UpdateItems_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Gokeeper_Sync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).Sync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/Sync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).Sync(ctx, req.(*SyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_AddItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).AddItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/AddItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).AddItem(ctx, req.(*AddItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_UpdateItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).UpdateItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/UpdateItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).UpdateItem(ctx, req.(*UpdateItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_DeleteItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).DeleteItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/DeleteItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).DeleteItem(ctx, req.(*DeleteItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_UploadBinary_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GokeeperServer).UploadBinary(&gokeeperUploadBinaryServer{stream})
+}
+
+type Gokeeper_UploadBinaryServer interface {
+	SendAndClose(*UploadBinaryResponse) error
+	Recv() (*UploadBinaryRequest, error)
+	grpc.ServerStream
+}
+
+type gokeeperUploadBinaryServer struct {
+	grpc.ServerStream
+}
+
+func (x *gokeeperUploadBinaryServer) SendAndClose(m *UploadBinaryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gokeeperUploadBinaryServer) Recv() (*UploadBinaryRequest, error) {
+	m := new(UploadBinaryRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Gokeeper_GetUploadOffset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUploadOffsetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).GetUploadOffset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/GetUploadOffset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).GetUploadOffset(ctx, req.(*GetUploadOffsetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_DownloadBinary_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadBinaryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GokeeperServer).DownloadBinary(m, &gokeeperDownloadBinaryServer{stream})
+}
+
+type Gokeeper_DownloadBinaryServer interface {
+	Send(*DownloadBinaryResponse) error
+	grpc.ServerStream
+}
+
+type gokeeperDownloadBinaryServer struct {
+	grpc.ServerStream
+}
+
+func (x *gokeeperDownloadBinaryServer) Send(m *DownloadBinaryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Gokeeper_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/GetUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_EnableTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableTOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).EnableTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/EnableTOTP",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).EnableTOTP(ctx, req.(*EnableTOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_ConfirmTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).ConfirmTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/ConfirmTOTP",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).ConfirmTOTP(ctx, req.(*ConfirmTOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_DisableTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableTOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).DisableTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/DisableTOTP",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).DisableTOTP(ctx, req.(*DisableTOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/ChangePassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gokeeper_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokeeperServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.server.Gokeeper/DeleteAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokeeperServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Gokeeper_AddLoginItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddLoginItemRequest)
 	if err := dec(in); err != nil {
@@ -295,10 +1002,82 @@ var Gokeeper_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LoginUser",
 			Handler:    _Gokeeper_LoginUser_Handler,
 		},
+		{
+			MethodName: "RefreshSession",
+			Handler:    _Gokeeper_RefreshSession_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _Gokeeper_Logout_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _Gokeeper_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _Gokeeper_RevokeSession_Handler,
+		},
+		{
+			MethodName: "GetVaultKey",
+			Handler:    _Gokeeper_GetVaultKey_Handler,
+		},
+		{
+			MethodName: "SetVaultKey",
+			Handler:    _Gokeeper_SetVaultKey_Handler,
+		},
 		{
 			MethodName: "UpdateItems",
 			Handler:    _Gokeeper_UpdateItems_Handler,
 		},
+		{
+			MethodName: "Sync",
+			Handler:    _Gokeeper_Sync_Handler,
+		},
+		{
+			MethodName: "AddItem",
+			Handler:    _Gokeeper_AddItem_Handler,
+		},
+		{
+			MethodName: "UpdateItem",
+			Handler:    _Gokeeper_UpdateItem_Handler,
+		},
+		{
+			MethodName: "DeleteItem",
+			Handler:    _Gokeeper_DeleteItem_Handler,
+		},
+		{
+			MethodName: "GetUploadOffset",
+			Handler:    _Gokeeper_GetUploadOffset_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _Gokeeper_GetUsage_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _Gokeeper_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "EnableTOTP",
+			Handler:    _Gokeeper_EnableTOTP_Handler,
+		},
+		{
+			MethodName: "ConfirmTOTP",
+			Handler:    _Gokeeper_ConfirmTOTP_Handler,
+		},
+		{
+			MethodName: "DisableTOTP",
+			Handler:    _Gokeeper_DisableTOTP_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _Gokeeper_ChangePassword_Handler,
+		},
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _Gokeeper_DeleteAccount_Handler,
+		},
 		{
 			MethodName: "AddLoginItem",
 			Handler:    _Gokeeper_AddLoginItem_Handler,
@@ -316,6 +1095,17 @@ var Gokeeper_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Gokeeper_AddBinaryItem_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadBinary",
+			Handler:       _Gokeeper_UploadBinary_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadBinary",
+			Handler:       _Gokeeper_DownloadBinary_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "proto/go-keeper-server.proto",
 }